@@ -120,7 +120,7 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 	}
 
 	// If there are no changes, or we're auto-approving or just previewing, we can skip the confirmation prompt.
-	if op.Opts.AutoApprove || kind == apitype.PreviewUpdate {
+	if op.Opts.AutoApprove || op.Opts.PreviewOnly || kind == apitype.PreviewUpdate {
 		close(eventsChannel)
 		return plan, changes, nil
 	}
@@ -208,7 +208,7 @@ func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind,
 		}
 
 		plan, changes, res := PreviewThenPrompt(ctx, kind, stack, op, apply)
-		if res != nil || kind == apitype.PreviewUpdate {
+		if res != nil || op.Opts.PreviewOnly || kind == apitype.PreviewUpdate {
 			return changes, res
 		}
 
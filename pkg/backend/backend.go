@@ -109,6 +109,19 @@ type ListStacksFilter struct {
 	TagValue     *string
 }
 
+// MatchesTags returns true if the tags satisfy the tag constraints of the filter. As with the service, a tag name
+// filter on its own matches any stack with that tag, and a tag value additionally requires the value to match.
+func (f ListStacksFilter) MatchesTags(tags map[apitype.StackTagName]string) bool {
+	if f.TagName == nil {
+		return true
+	}
+	value, has := tags[*f.TagName]
+	if !has {
+		return false
+	}
+	return f.TagValue == nil || value == *f.TagValue
+}
+
 // ContinuationToken is an opaque string used for paginated backend requests. If non-nil, means
 // there are more results to be returned and the continuation token should be passed into a
 // subsequent call to the backend method. A nil continuation token means all results have been
@@ -258,6 +271,8 @@ type UpdateOptions struct {
 	AutoApprove bool
 	// SkipPreview, when true, causes the preview step to be skipped.
 	SkipPreview bool
+	// PreviewOnly, when true, stops after the preview step without prompting or performing the operation.
+	PreviewOnly bool
 	// Experimental plan support, when true cause plans to be generated.
 	ExperimentalPlans bool
 }
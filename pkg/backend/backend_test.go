@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 )
@@ -117,3 +118,42 @@ func liveState(typ, name string, outs resource.PropertyMap) *resource.State {
 		Delete: false, Type: tokens.Type(typ), URN: testURN(typ, name), Outputs: outs,
 	}
 }
+
+func TestListStacksFilterMatchesTags(t *testing.T) {
+	t.Parallel()
+
+	name, value, other := "env", "prod", "dev"
+	tags := map[apitype.StackTagName]string{"env": "prod", "team": "infra"}
+
+	cases := []struct {
+		name     string
+		filter   ListStacksFilter
+		tags     map[apitype.StackTagName]string
+		expected bool
+	}{
+		{name: "no filter", tags: tags, expected: true},
+		{name: "no filter or tags", expected: true},
+		{name: "tag name", filter: ListStacksFilter{TagName: &name}, tags: tags, expected: true},
+		{name: "missing tag", filter: ListStacksFilter{TagName: &other}, tags: tags, expected: false},
+		{name: "no tags", filter: ListStacksFilter{TagName: &name}, expected: false},
+		{
+			name:     "tag value",
+			filter:   ListStacksFilter{TagName: &name, TagValue: &value},
+			tags:     tags,
+			expected: true,
+		},
+		{
+			name:     "different tag value",
+			filter:   ListStacksFilter{TagName: &name, TagValue: &other},
+			tags:     tags,
+			expected: false,
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, c.filter.MatchesTags(c.tags))
+		})
+	}
+}
@@ -113,6 +113,8 @@ func RenderDiffEvent(event engine.Event, seen map[resource.URN]engine.StepEventM
 		// that the display is appropriate for both.
 	case engine.ResourceOperationFailed:
 		return renderDiffResourceOperationFailedEvent(event.Payload().(engine.ResourceOperationFailedPayload), opts)
+	case engine.ResourceRetryEvent:
+		return renderDiffResourceRetryEvent(event.Payload().(engine.ResourceRetryEventPayload), opts)
 	case engine.ResourceOutputsEvent:
 		return renderDiffResourceOutputsEvent(event.Payload().(engine.ResourceOutputsEventPayload), seen, opts)
 	case engine.ResourcePreEvent:
@@ -223,6 +225,15 @@ func renderSummaryEvent(event engine.SummaryEventPayload, wroteDiagnosticHeader
 		fprintfIgnoreError(out, "\n")
 	}
 
+	// Print the resources whose operations failed, which only happens when continuing past errors.
+	if len(event.FailedResources) > 0 {
+		fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("\n%sFailed:%s\n",
+			colors.SpecHeadline, colors.Reset)))
+		for _, urn := range event.FailedResources {
+			fprintfIgnoreError(out, "    %s\n", opts.Color.Colorize(colors.SpecError+string(urn)+colors.Reset))
+		}
+	}
+
 	// Print policy packs loaded. Data is rendered as a table of {policy-pack-name, version}.
 	renderPolicyPacks(out, event.PolicyPacks, opts)
 
@@ -302,6 +313,15 @@ func renderDiffResourceOperationFailedEvent(
 	return ""
 }
 
+func renderDiffResourceRetryEvent(payload engine.ResourceRetryEventPayload, opts Options) string {
+	if !shouldShow(payload.Metadata, opts) {
+		return ""
+	}
+	return opts.Color.Colorize(fmt.Sprintf("%s%s %s failed; retrying (%d of %d) in %v: %s%s\n",
+		colors.SpecWarning, payload.Metadata.Op, payload.Metadata.URN, payload.Attempt, payload.MaxAttempts,
+		payload.Delay, payload.Error, colors.Reset))
+}
+
 func renderDiff(
 	out io.Writer,
 	metadata engine.StepEventMetadata,
@@ -43,6 +43,12 @@ func ShowEvents(
 		events, done = startEventLogger(events, done, opts)
 	}
 
+	// Drift reports render their own JSON, so they are handled before the JSON displays.
+	if opts.Type == DisplayDrift {
+		ShowDriftEvents(events, done, opts)
+		return
+	}
+
 	streamPreview := cmdutil.IsTruthy(os.Getenv("PULUMI_ENABLE_STREAMING_JSON_PREVIEW"))
 
 	if opts.JSONDisplay {
@@ -0,0 +1,189 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// ShowDriftEvents renders a report of the drift detected by a refresh preview. Rather than displaying each event as it
+// arrives, the report is accumulated until the event stream is closed and then rendered either as JSON or as a
+// human-readable summary.
+func ShowDriftEvents(events <-chan engine.Event, done chan<- bool, opts Options) {
+	// Ensure we close the done channel before exiting.
+	defer func() { close(done) }()
+
+	stdout := opts.Stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	stderr := opts.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	report := display.DriftReport{Resources: []display.ResourceDrift{}}
+	for e := range events {
+		// In the event of cancellation, break out of the loop immediately.
+		if e.Type == engine.CancelEvent {
+			break
+		}
+
+		switch e.Type {
+		case engine.DiagEvent:
+			p := e.Payload().(engine.DiagEventPayload)
+			if !p.Ephemeral && (p.Severity == diag.Error || p.Severity == diag.Warning) {
+				report.Diagnostics = append(report.Diagnostics, display.PreviewDiagnostic{
+					URN:      p.URN,
+					Message:  colors.Never.Colorize(p.Prefix + p.Message),
+					Severity: p.Severity,
+				})
+			}
+		case engine.ResourceOutputsEvent:
+			m := e.Payload().(engine.ResourceOutputsEventPayload).Metadata
+			if drift, checked := resourceDrift(m); checked {
+				report.Checked++
+				if drift != nil {
+					report.Resources = append(report.Resources, *drift)
+				}
+			}
+		}
+	}
+	sort.Slice(report.Resources, func(i, j int) bool {
+		return report.Resources[i].URN < report.Resources[j].URN
+	})
+
+	if opts.JSONDisplay {
+		out, err := json.MarshalIndent(&report, "", "    ")
+		contract.Assertf(err == nil, "unexpected JSON error: %v", err)
+		fprintfIgnoreError(stdout, "%s\n", out)
+		return
+	}
+
+	for _, d := range report.Diagnostics {
+		fprintfIgnoreError(stderr, "%s: %s\n", d.Severity, d.Message)
+	}
+	fprintIgnoreError(stdout, opts.Color.Colorize(renderDriftReport(report)))
+}
+
+// resourceDrift computes the drift of the resource refreshed by the step described by the given metadata. The second
+// result is false if the step did not read the live state of a custom resource.
+func resourceDrift(m engine.StepEventMetadata) (*display.ResourceDrift, bool) {
+	if m.Old == nil || !m.Old.Custom || providers.IsProviderType(m.Old.Type) {
+		return nil, false
+	}
+
+	drift := &display.ResourceDrift{URN: m.URN, Type: string(m.Old.Type), ID: string(m.Old.ID)}
+	switch m.Op {
+	case deploy.OpSame:
+		return nil, true
+	case deploy.OpDelete:
+		drift.Deleted = true
+		return drift, true
+	case deploy.OpUpdate:
+		contract.Assertf(m.New != nil, "refreshed resource %v has no new state", m.URN)
+		diff := m.Old.Outputs.Diff(m.New.Outputs)
+		if diff == nil {
+			return nil, true
+		}
+		for k, v := range diff.Adds {
+			drift.Properties = append(drift.Properties, display.PropertyDrift{
+				Key: k, Kind: "add", Live: driftValue(v),
+			})
+		}
+		for k, v := range diff.Deletes {
+			drift.Properties = append(drift.Properties, display.PropertyDrift{
+				Key: k, Kind: "delete", Recorded: driftValue(v),
+			})
+		}
+		for k, v := range diff.Updates {
+			drift.Properties = append(drift.Properties, display.PropertyDrift{
+				Key: k, Kind: "update", Recorded: driftValue(v.Old), Live: driftValue(v.New),
+			})
+		}
+		sort.Slice(drift.Properties, func(i, j int) bool {
+			return drift.Properties[i].Key < drift.Properties[j].Key
+		})
+		return drift, true
+	default:
+		return nil, false
+	}
+}
+
+// driftValue returns the JSON-serializable form of a property value, blinding any secrets it contains.
+func driftValue(v resource.PropertyValue) interface{} {
+	if v.ContainsSecrets() {
+		return "[secret]"
+	}
+	return v.Mappable()
+}
+
+// renderDriftReport renders a human-readable summary of the given drift report.
+func renderDriftReport(report display.DriftReport) string {
+	if len(report.Resources) == 0 {
+		return fmt.Sprintf("No drift detected in %d resources.\n", report.Checked)
+	}
+
+	out := &bytes.Buffer{}
+	fprintfIgnoreError(out, "%sDrift detected in %d of %d resources:%s\n",
+		colors.SpecHeadline, len(report.Resources), report.Checked, colors.Reset)
+	for _, r := range report.Resources {
+		fprintIgnoreError(out, "\n")
+		if r.Deleted {
+			fprintfIgnoreError(out, "    %s%s %s (id: %s) was deleted%s\n",
+				deploy.Prefix(deploy.OpDelete, true), r.Type, r.URN.Name(), r.ID, colors.Reset)
+			continue
+		}
+
+		fprintfIgnoreError(out, "    %s%s %s (id: %s)%s\n",
+			deploy.Prefix(deploy.OpUpdate, true), r.Type, r.URN.Name(), r.ID, colors.Reset)
+		for _, p := range r.Properties {
+			switch p.Kind {
+			case "add":
+				fprintfIgnoreError(out, "        %s%s: %s%s\n",
+					deploy.Prefix(deploy.OpCreate, true), p.Key, driftJSON(p.Live), colors.Reset)
+			case "delete":
+				fprintfIgnoreError(out, "        %s%s: %s%s\n",
+					deploy.Prefix(deploy.OpDelete, true), p.Key, driftJSON(p.Recorded), colors.Reset)
+			default:
+				fprintfIgnoreError(out, "        %s%s: %s => %s%s\n",
+					deploy.Prefix(deploy.OpUpdate, true), p.Key, driftJSON(p.Recorded), driftJSON(p.Live), colors.Reset)
+			}
+		}
+	}
+	return out.String()
+}
+
+// driftJSON renders a property value in a drift report as compact JSON.
+func driftJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
@@ -0,0 +1,142 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func driftStepMetadata(op display.StepOp, urn resource.URN, custom bool,
+	old, new resource.PropertyMap) engine.StepEventMetadata {
+
+	m := engine.StepEventMetadata{
+		Op:  op,
+		URN: urn,
+		Old: &engine.StepEventStateMetadata{Type: urn.Type(), URN: urn, Custom: custom, ID: "id1", Outputs: old},
+	}
+	if new != nil {
+		m.New = &engine.StepEventStateMetadata{Type: urn.Type(), URN: urn, Custom: custom, ID: "id1", Outputs: new}
+	}
+	return m
+}
+
+func TestResourceDrift(t *testing.T) {
+	t.Parallel()
+
+	urn := resource.URN("urn:pulumi:stack::project::pkgA:m:typA::resA")
+	recorded := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"acl":  "private",
+		"tags": map[string]interface{}{"env": "prod"},
+	})
+
+	// Resources whose live state matches their recorded state have not drifted.
+	drift, checked := resourceDrift(driftStepMetadata(deploy.OpSame, urn, true, recorded, recorded))
+	assert.True(t, checked)
+	assert.Nil(t, drift)
+
+	// Changed, added, and removed outputs are all reported, with secrets blinded.
+	live := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"tags":    map[string]interface{}{"env": "dev"},
+		"website": "index.html",
+	})
+	live["password"] = resource.MakeSecret(resource.NewStringProperty("hunter2"))
+	drift, checked = resourceDrift(driftStepMetadata(deploy.OpUpdate, urn, true, recorded, live))
+	assert.True(t, checked)
+	require.NotNil(t, drift)
+	assert.Equal(t, display.ResourceDrift{
+		URN:  urn,
+		Type: "pkgA:m:typA",
+		ID:   "id1",
+		Properties: []display.PropertyDrift{
+			{Key: "acl", Kind: "delete", Recorded: "private"},
+			{Key: "password", Kind: "add", Live: "[secret]"},
+			{Key: "tags", Kind: "update",
+				Recorded: map[string]interface{}{"env": "prod"}, Live: map[string]interface{}{"env": "dev"}},
+			{Key: "website", Kind: "add", Live: "index.html"},
+		},
+	}, *drift)
+
+	// Resources that no longer exist have drifted.
+	drift, checked = resourceDrift(driftStepMetadata(deploy.OpDelete, urn, true, recorded, nil))
+	assert.True(t, checked)
+	require.NotNil(t, drift)
+	assert.True(t, drift.Deleted)
+
+	// Components and providers are not read, so they are not checked for drift.
+	_, checked = resourceDrift(driftStepMetadata(deploy.OpSame, urn, false, recorded, recorded))
+	assert.False(t, checked)
+	provURN := resource.URN("urn:pulumi:stack::project::pulumi:providers:pkgA::default")
+	_, checked = resourceDrift(driftStepMetadata(deploy.OpSame, provURN, true, nil, nil))
+	assert.False(t, checked)
+}
+
+func TestShowDriftEvents(t *testing.T) {
+	t.Parallel()
+
+	urnA := resource.URN("urn:pulumi:stack::project::pkgA:m:typA::resA")
+	urnB := resource.URN("urn:pulumi:stack::project::pkgA:m:typA::resB")
+	recorded := resource.NewPropertyMapFromMap(map[string]interface{}{"size": 1})
+	live := resource.NewPropertyMapFromMap(map[string]interface{}{"size": 2})
+
+	show := func(jsonDisplay bool) string {
+		events := make(chan engine.Event, 3)
+		events <- engine.NewEvent(engine.ResourceOutputsEvent, engine.ResourceOutputsEventPayload{
+			Metadata: driftStepMetadata(deploy.OpUpdate, urnB, true, recorded, live),
+		})
+		events <- engine.NewEvent(engine.ResourceOutputsEvent, engine.ResourceOutputsEventPayload{
+			Metadata: driftStepMetadata(deploy.OpDelete, urnA, true, recorded, nil),
+		})
+		close(events)
+
+		var stdout bytes.Buffer
+		done := make(chan bool)
+		go ShowDriftEvents(events, done, Options{
+			Color:       colors.Never,
+			Stdout:      &stdout,
+			JSONDisplay: jsonDisplay,
+		})
+		<-done
+		return stdout.String()
+	}
+
+	var report display.DriftReport
+	require.NoError(t, json.Unmarshal([]byte(show(true)), &report))
+	assert.Equal(t, 2, report.Checked)
+	require.Len(t, report.Resources, 2)
+	assert.Equal(t, urnA, report.Resources[0].URN)
+	assert.True(t, report.Resources[0].Deleted)
+	assert.Equal(t, urnB, report.Resources[1].URN)
+	assert.Equal(t, []display.PropertyDrift{
+		{Key: "size", Kind: "update", Recorded: float64(1), Live: float64(2)},
+	}, report.Resources[1].Properties)
+
+	assert.Equal(t, "Drift detected in 2 of 2 resources:\n"+
+		"\n"+
+		"    - pkgA:m:typA resA (id: id1) was deleted\n"+
+		"\n"+
+		"    ~ pkgA:m:typA resB (id: id1)\n"+
+		"        ~ size: 1 => 2\n", show(false))
+}
@@ -98,11 +98,16 @@ func ConvertEngineEvent(e engine.Event, showSecrets bool) (apitype.EngineEvent,
 		for op, count := range p.ResourceChanges {
 			changes[apitype.OpType(op)] = count
 		}
+		var failed []string
+		for _, urn := range p.FailedResources {
+			failed = append(failed, string(urn))
+		}
 		apiEvent.SummaryEvent = &apitype.SummaryEvent{
 			MaybeCorrupt:    p.MaybeCorrupt,
 			DurationSeconds: int(p.Duration.Seconds()),
 			ResourceChanges: changes,
 			PolicyPacks:     p.PolicyPacks,
+			FailedResources: failed,
 		}
 
 	case engine.ResourcePreEvent:
@@ -125,6 +130,19 @@ func ConvertEngineEvent(e engine.Event, showSecrets bool) (apitype.EngineEvent,
 			Planning: p.Planning,
 		}
 
+	case engine.ResourceRetryEvent:
+		p, ok := e.Payload().(engine.ResourceRetryEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		apiEvent.ResRetryEvent = &apitype.ResRetryEvent{
+			Metadata:     convertStepEventMetadata(p.Metadata, showSecrets),
+			Attempt:      p.Attempt,
+			MaxAttempts:  p.MaxAttempts,
+			DelaySeconds: int(p.Delay.Seconds()),
+			Error:        p.Error,
+		}
+
 	case engine.ResourceOperationFailed:
 		p, ok := e.Payload().(engine.ResourceOperationFailedPayload)
 		if !ok {
@@ -188,11 +206,12 @@ func convertStepEventMetadata(md engine.StepEventMetadata, showSecrets bool) api
 		Old: convertStepEventStateMetadata(md.Old, showSecrets),
 		New: convertStepEventStateMetadata(md.New, showSecrets),
 
-		Keys:         keys,
-		Diffs:        diffs,
-		DetailedDiff: detailedDiff,
-		Logical:      md.Logical,
-		Provider:     md.Provider,
+		Keys:           keys,
+		Diffs:          diffs,
+		DetailedDiff:   detailedDiff,
+		Logical:        md.Logical,
+		Provider:       md.Provider,
+		SourcePosition: md.SourcePosition,
 	}
 }
 
@@ -288,11 +307,16 @@ func ConvertJSONEvent(apiEvent apitype.EngineEvent) (engine.Event, error) {
 		for op, count := range p.ResourceChanges {
 			changes[display.StepOp(op)] = count
 		}
+		var failed []resource.URN
+		for _, urn := range p.FailedResources {
+			failed = append(failed, resource.URN(urn))
+		}
 		event = engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
 			MaybeCorrupt:    p.MaybeCorrupt,
 			Duration:        time.Duration(p.DurationSeconds) * time.Second,
 			ResourceChanges: changes,
 			PolicyPacks:     p.PolicyPacks,
+			FailedResources: failed,
 		})
 
 	case apiEvent.ResourcePreEvent != nil:
@@ -309,6 +333,16 @@ func ConvertJSONEvent(apiEvent apitype.EngineEvent) (engine.Event, error) {
 			Planning: p.Planning,
 		})
 
+	case apiEvent.ResRetryEvent != nil:
+		p := apiEvent.ResRetryEvent
+		event = engine.NewEvent(engine.ResourceRetryEvent, engine.ResourceRetryEventPayload{
+			Metadata:    convertJSONStepEventMetadata(p.Metadata),
+			Attempt:     p.Attempt,
+			MaxAttempts: p.MaxAttempts,
+			Delay:       time.Duration(p.DelaySeconds) * time.Second,
+			Error:       p.Error,
+		})
+
 	case apiEvent.ResOpFailedEvent != nil:
 		p := apiEvent.ResOpFailedEvent
 		event = engine.NewEvent(engine.ResourceOperationFailed, engine.ResourceOperationFailedPayload{
@@ -377,11 +411,12 @@ func convertJSONStepEventMetadata(md apitype.StepEventMetadata) engine.StepEvent
 		New: new,
 		Res: res,
 
-		Keys:         keys,
-		Diffs:        diffs,
-		DetailedDiff: detailedDiff,
-		Logical:      md.Logical,
-		Provider:     md.Provider,
+		Keys:           keys,
+		Diffs:          diffs,
+		DetailedDiff:   detailedDiff,
+		Logical:        md.Logical,
+		Provider:       md.Provider,
+		SourcePosition: md.SourcePosition,
 	}
 }
 
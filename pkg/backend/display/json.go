@@ -82,10 +82,13 @@ func stateForJSONOutput(s *resource.State, opts Options) *resource.State {
 		outputs = resource.PropertyMap{}
 	}
 
-	return resource.NewState(s.Type, s.URN, s.Custom, s.Delete, s.ID, inputs,
+	state := resource.NewState(s.Type, s.URN, s.Custom, s.Delete, s.ID, inputs,
 		outputs, s.Parent, s.Protect, s.External, s.Dependencies, s.InitErrors, s.Provider,
 		s.PropertyDependencies, s.PendingReplacement, s.AdditionalSecretOutputs, s.Aliases, &s.CustomTimeouts,
-		s.ImportID, s.SequenceNumber, s.RetainOnDelete)
+		s.ImportID, s.SequenceNumber, s.RetainOnDelete, s.DeletedWith)
+	state.Created, state.Modified = s.Created, s.Modified
+	state.RetryPolicy = s.RetryPolicy
+	return state
 }
 
 // ShowJSONEvents renders incremental engine events to stdout.
@@ -195,9 +198,9 @@ func ShowPreviewDigest(events <-chan engine.Event, done chan<- bool, opts Option
 
 				digest.Steps = append(digest.Steps, step)
 			}
-		case engine.ResourceOutputsEvent, engine.ResourceOperationFailed:
+		case engine.ResourceOutputsEvent, engine.ResourceOperationFailed, engine.ResourceRetryEvent:
 		// Because we are only JSON serializing previews, we don't need to worry about outputs
-		// resolving or operations failing.
+		// resolving or operations failing or being retried.
 
 		// Events occurring late:
 		case engine.PolicyViolationEvent:
@@ -32,6 +32,8 @@ const (
 	DisplayQuery
 	// DisplayWatch displays watch output.
 	DisplayWatch
+	// DisplayDrift displays a report of the drift detected by a refresh preview.
+	DisplayDrift
 )
 
 // Options controls how the output of events are rendered
@@ -221,6 +221,9 @@ func getEventUrnAndMetadata(event engine.Event) (resource.URN, *engine.StepEvent
 	case engine.ResourceOperationFailed:
 		payload := event.Payload().(engine.ResourceOperationFailedPayload)
 		return payload.Metadata.URN, &payload.Metadata
+	case engine.ResourceRetryEvent:
+		payload := event.Payload().(engine.ResourceRetryEventPayload)
+		return payload.Metadata.URN, &payload.Metadata
 	case engine.DiagEvent:
 		return event.Payload().(engine.DiagEventPayload).URN, nil
 	case engine.PolicyViolationEvent:
@@ -795,9 +798,12 @@ func (display *ProgressDisplay) printDiagnostics() bool {
 				if !wroteResourceHeader {
 					wroteResourceHeader = true
 					columns := row.ColorizedColumns()
+					header := columns[typeColumn] + " (" + columns[nameColumn] + ")"
+					if pos := row.Step().SourcePosition; pos != "" {
+						header += " at " + pos
+					}
 					display.writeSimpleMessage("  " +
-						display.opts.Color.Colorize(
-							colors.BrightBlue+columns[typeColumn]+" ("+columns[nameColumn]+"):"+colors.Reset))
+						display.opts.Color.Colorize(colors.BrightBlue+header+":"+colors.Reset))
 				}
 
 				for _, line := range lines {
@@ -1124,6 +1130,9 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 		}
 	} else if event.Type == engine.ResourceOperationFailed {
 		row.SetFailed()
+	} else if event.Type == engine.ResourceRetryEvent {
+		payload := event.Payload().(engine.ResourceRetryEventPayload)
+		row.SetRetrying(payload.Attempt, payload.MaxAttempts)
 	} else if event.Type == engine.DiagEvent {
 		// also record this diagnostic so we print it at the end.
 		row.RecordDiagEvent(event)
@@ -56,6 +56,7 @@ type ResourceRow interface {
 	IsDone() bool
 
 	SetFailed()
+	SetRetrying(attempt, maxAttempts int)
 
 	DiagInfo() *DiagInfo
 	PolicyPayloads() []engine.PolicyViolationEventPayload
@@ -125,6 +126,10 @@ type resourceRowData struct {
 	// If we failed this operation for any reason.
 	failed bool
 
+	// The number of times the operation has been retried after failing, and the most its retry policy allows.
+	retries    int
+	maxRetries int
+
 	diagInfo       *DiagInfo
 	policyPayloads []engine.PolicyViolationEventPayload
 
@@ -177,6 +182,10 @@ func (data *resourceRowData) SetFailed() {
 	data.failed = true
 }
 
+func (data *resourceRowData) SetRetrying(attempt, maxAttempts int) {
+	data.retries, data.maxRetries = attempt, maxAttempts
+}
+
 func (data *resourceRowData) DiagInfo() *DiagInfo {
 	return data.diagInfo
 }
@@ -346,11 +355,24 @@ func (data *resourceRowData) getInfoColumn() string {
 		diagMsg += msg
 	}
 
+	// If we know where in the program this resource was registered, show that first.
+	if pos := data.sourcePosition(); pos != "" {
+		appendDiagMessage(pos)
+	}
+
 	changes := getDiffInfo(step, data.display.action)
 	if colors.Never.Colorize(changes) != "" {
 		appendDiagMessage("[" + changes + "]")
 	}
 
+	if data.retries > 0 {
+		if data.IsDone() {
+			appendDiagMessage(fmt.Sprintf("%d %s", data.retries, english.PluralWord(data.retries, "retry", "")))
+		} else {
+			appendDiagMessage(fmt.Sprintf("retrying (%d of %d)", data.retries, data.maxRetries))
+		}
+	}
+
 	diagInfo := data.diagInfo
 	if data.display.done {
 		// If we are done, show a summary of how many messages were printed.
@@ -401,6 +423,20 @@ func (data *resourceRowData) getInfoColumn() string {
 	return diagMsg
 }
 
+// sourcePosition returns the position in the program that registered this row's resource, if known. Steps that
+// do not originate from a registration (e.g. deletes) carry no position, so fall back to the row's other steps.
+func (data *resourceRowData) sourcePosition() string {
+	if data.step.SourcePosition != "" {
+		return data.step.SourcePosition
+	}
+	for _, outputStep := range data.outputSteps {
+		if outputStep.SourcePosition != "" {
+			return outputStep.SourcePosition
+		}
+	}
+	return ""
+}
+
 func getDiffInfo(step engine.StepEventMetadata, action apitype.UpdateKind) string {
 	diffOutputs := action == apitype.RefreshUpdate
 	changesBuf := &bytes.Buffer{}
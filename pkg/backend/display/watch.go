@@ -79,6 +79,13 @@ func ShowWatchEvents(op string, events <-chan engine.Event, done chan<- bool, op
 				PrintfWithWatchPrefix(time.Now(), string(p.Metadata.URN.Name()),
 					"failed %s %s\n", p.Metadata.Op, p.Metadata.URN.Type())
 			}
+		case engine.ResourceRetryEvent:
+			p := e.Payload().(engine.ResourceRetryEventPayload)
+			if shouldShow(p.Metadata, opts) {
+				PrintfWithWatchPrefix(time.Now(), string(p.Metadata.URN.Name()),
+					"retrying %s %s (%d of %d): %s\n", p.Metadata.Op, p.Metadata.URN.Type(),
+					p.Attempt, p.MaxAttempts, p.Error)
+			}
 		default:
 			contract.Failf("unknown event type '%s'", e.Type)
 		}
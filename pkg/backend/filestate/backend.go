@@ -24,6 +24,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -40,7 +41,6 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v3/authhelpers"
 	"github.com/pulumi/pulumi/pkg/v3/backend"
-	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/operations"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
@@ -51,7 +51,6 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
 	sdkDisplay "github.com/pulumi/pulumi/sdk/v3/go/common/display"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -68,6 +67,15 @@ const PulumiFilestateGzipEnvVar = "PULUMI_SELF_MANAGED_STATE_GZIP"
 type Backend interface {
 	backend.Backend
 	local() // at the moment, no local specific info, so just use a marker function.
+
+	// PruneHistory deletes the history entries and checkpoint backups of a stack that fall outside of the given
+	// retention policy, returning the objects that were (or, for a dry run, would be) deleted.
+	PruneHistory(ctx context.Context, stackRef backend.StackReference, policy HistoryRetentionPolicy,
+		dryRun bool) ([]PrunedObject, error)
+
+	// Upgrade migrates the backend from the legacy layout, in which stacks are not scoped by project, to the
+	// project-scoped layout. It does nothing if the backend already uses the project-scoped layout.
+	Upgrade(ctx context.Context) error
 }
 
 type localBackend struct {
@@ -84,19 +92,25 @@ type localBackend struct {
 
 	lockID string
 
+	// lockTTL is how long locks written by this backend remain valid without being renewed.
+	lockTTL time.Duration
+	// leases renew the locks held by this backend, keyed by the path of the lock.
+	leases     map[string]*lockLease
+	leaseMutex sync.Mutex
+
 	gzip bool
-}
 
-type localBackendReference struct {
-	name tokens.Name
-}
+	// encrypt is true if checkpoints are encrypted in their entirety with the stack's secrets provider.
+	encrypt bool
+	// decrypters caches the decrypters used to read encrypted checkpoints, keyed by secrets provider. It is guarded
+	// by mutex.
+	decrypters map[string]config.Decrypter
 
-func (r localBackendReference) String() string {
-	return string(r.name)
-}
+	// store maps stack references to their location in the bucket, according to the bucket's layout.
+	store referenceStore
 
-func (r localBackendReference) Name() tokens.Name {
-	return r.name
+	// currentProject is the project in the current working directory, if any.
+	currentProject *workspace.Project
 }
 
 func IsFileStateBackendURL(urlstr string) bool {
@@ -111,6 +125,16 @@ func IsFileStateBackendURL(urlstr string) bool {
 const FilePathPrefix = "file://"
 
 func New(d diag.Sink, originalURL string) (Backend, error) {
+	// Detect the current project, which is used to qualify stack references that don't name a project.
+	currentProject, err := workspace.DetectProject()
+	if err != nil {
+		currentProject = nil
+	}
+
+	return newLocalBackend(d, originalURL, currentProject)
+}
+
+func newLocalBackend(d diag.Sink, originalURL string, currentProject *workspace.Project) (*localBackend, error) {
 	if !IsFileStateBackendURL(originalURL) {
 		return nil, fmt.Errorf("local URL %s has an illegal prefix; expected one of: %s",
 			originalURL, strings.Join(blob.DefaultURLMux().BucketSchemes(), ", "))
@@ -160,15 +184,27 @@ func New(d diag.Sink, originalURL string) (Backend, error) {
 	}
 
 	gzipCompression := cmdutil.IsTruthy(os.Getenv(PulumiFilestateGzipEnvVar))
+	legacyLayout := cmdutil.IsTruthy(os.Getenv(PulumiFilestateLegacyLayoutEnvVar))
+
+	b := &localBackend{
+		d:              d,
+		originalURL:    originalURL,
+		url:            u,
+		bucket:         &wrappedBucket{bucket: bucket},
+		lockID:         lockID.String(),
+		lockTTL:        lockTTL(),
+		leases:         make(map[string]*lockLease),
+		gzip:           gzipCompression,
+		encrypt:        cmdutil.IsTruthy(os.Getenv(PulumiFilestateEncryptEnvVar)),
+		decrypters:     make(map[string]config.Decrypter),
+		currentProject: currentProject,
+	}
+
+	if err := b.initReferenceStore(context.TODO(), legacyLayout); err != nil {
+		return nil, err
+	}
 
-	return &localBackend{
-		d:           d,
-		originalURL: originalURL,
-		url:         u,
-		bucket:      &wrappedBucket{bucket: bucket},
-		lockID:      lockID.String(),
-		gzip:        gzipCompression,
-	}, nil
+	return b, nil
 }
 
 // massageBlobPath takes the path the user provided and converts it to an appropriate form go-cloud
@@ -224,6 +260,8 @@ func Login(d diag.Sink, url string) (Backend, error) {
 	return be, workspace.StoreAccount(be.URL(), workspace.Account{}, true)
 }
 
+var _ backend.SpecificDeploymentExporter = &localBackend{}
+
 func (b *localBackend) local() {}
 
 func (b *localBackend) Name() string {
@@ -260,7 +298,7 @@ func (b *localBackend) ListPolicyPacks(ctx context.Context, orgName string, _ ba
 }
 
 func (b *localBackend) SupportsTags() bool {
-	return false
+	return true
 }
 
 func (b *localBackend) SupportsOrganizations() bool {
@@ -268,18 +306,23 @@ func (b *localBackend) SupportsOrganizations() bool {
 }
 
 func (b *localBackend) ParseStackReference(stackRefName string) (backend.StackReference, error) {
-	return localBackendReference{name: tokens.Name(stackRefName)}, nil
+	return b.parseStackReference(stackRefName)
+}
+
+func (b *localBackend) parseStackReference(stackRefName string) (*localBackendReference, error) {
+	return b.store.ParseReference(stackRefName)
 }
 
 // ValidateStackName verifies the stack name is valid for the local backend. We use the same rules as the
 // httpstate backend.
 func (b *localBackend) ValidateStackName(stackName string) error {
-	if strings.Contains(stackName, "/") {
-		return errors.New("stack names may not contain slashes")
+	ref, err := b.parseStackReference(stackName)
+	if err != nil {
+		return err
 	}
 
 	validNameRegex := regexp.MustCompile("^[A-Za-z0-9_.-]{1,100}$")
-	if !validNameRegex.MatchString(stackName) {
+	if !validNameRegex.MatchString(string(ref.name)) {
 		return errors.New("stack names may only contain alphanumeric, hyphens, underscores, or periods")
 	}
 
@@ -287,27 +330,40 @@ func (b *localBackend) ValidateStackName(stackName string) error {
 }
 
 func (b *localBackend) DoesProjectExist(ctx context.Context, projectName string) (bool, error) {
-	// Local backends don't really have multiple projects, so just return false here.
-	return false, nil
+	return b.store.ProjectExists(projectName)
+}
+
+// getReference converts a stack reference handed to us by the CLI into a reference for this backend.
+func (b *localBackend) getReference(ref backend.StackReference) (*localBackendReference, error) {
+	stackRef, ok := ref.(*localBackendReference)
+	if !ok {
+		return nil, fmt.Errorf("bad stack reference type %T", ref)
+	}
+	return stackRef, nil
 }
 
 func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackReference,
-	opts interface{}) (backend.Stack, error) {
+	opts interface{}) (_ backend.Stack, err error) {
 
-	err := b.Lock(ctx, stackRef)
+	err = b.Lock(ctx, stackRef)
 	if err != nil {
 		return nil, err
 	}
-	defer b.Unlock(ctx, stackRef)
+	defer b.unlock(ctx, stackRef, &err)
 
 	contract.Requiref(opts == nil, "opts", "local stacks do not support any options")
 
-	stackName := stackRef.Name()
+	localStackRef, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+
+	stackName := localStackRef.Name()
 	if stackName == "" {
 		return nil, errors.New("invalid empty stack name")
 	}
 
-	if _, _, err := b.getStack(stackName); err == nil {
+	if _, _, err := b.getStack(localStackRef); err == nil {
 		return nil, &backend.StackAlreadyExistsError{StackName: string(stackName)}
 	}
 
@@ -319,48 +375,67 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 		return nil, fmt.Errorf("validating stack properties: %w", err)
 	}
 
-	file, err := b.saveStack(stackName, nil, nil)
+	file, err := b.saveStack(localStackRef, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	stack := newStack(stackRef, file, nil, b)
+	if err = b.saveStackTags(ctx, localStackRef, tags); err != nil {
+		return nil, err
+	}
+
+	stack := newStack(localStackRef, file, nil, tags, b)
 	fmt.Printf("Created stack '%s'\n", stack.Ref())
 
 	return stack, nil
 }
 
 func (b *localBackend) GetStack(ctx context.Context, stackRef backend.StackReference) (backend.Stack, error) {
-	stackName := stackRef.Name()
-	snapshot, path, err := b.getStack(stackName)
+	localStackRef, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, path, err := b.getStack(localStackRef)
 
 	switch {
 	case gcerrors.Code(err) == gcerrors.NotFound:
 		return nil, nil
 	case err != nil:
 		return nil, err
-	default:
-		return newStack(stackRef, path, snapshot, b), nil
 	}
+
+	tags, err := b.getStackTags(ctx, localStackRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStack(localStackRef, path, snapshot, tags, b), nil
 }
 
 func (b *localBackend) ListStacks(
-	ctx context.Context, _ backend.ListStacksFilter, _ backend.ContinuationToken) (
+	ctx context.Context, filter backend.ListStacksFilter, _ backend.ContinuationToken) (
 	[]backend.StackSummary, backend.ContinuationToken, error) {
-	stacks, err := b.getLocalStacks()
+	// Organizations aren't persisted in the local backend, so the organization filter is not honored. Buckets
+	// using the legacy layout don't record projects either, so they ignore the project filter too.
+	stacks, err := b.store.ListReferences(filter.Project)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Note that the provided stack filter is not honored, since fields like
-	// organizations and tags aren't persisted in the local backend.
 	var results []backend.StackSummary
-	for _, stackName := range stacks {
-		chk, err := b.getCheckpoint(stackName)
-		if err != nil {
-			return nil, nil, err
+	for _, stackRef := range stacks {
+		if filter.TagName != nil {
+			tags, err := b.getStackTags(ctx, stackRef)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !filter.MatchesTags(tags) {
+				continue
+			}
 		}
-		stackRef, err := b.ParseStackReference(string(stackName))
+
+		chk, err := b.getCheckpointForSummary(stackRef)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -370,16 +445,20 @@ func (b *localBackend) ListStacks(
 	return results, nil, nil
 }
 
-func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, force bool) (bool, error) {
+func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, force bool) (_ bool, err error) {
 
-	err := b.Lock(ctx, stack.Ref())
+	err = b.Lock(ctx, stack.Ref())
+	if err != nil {
+		return false, err
+	}
+	defer b.unlock(ctx, stack.Ref(), &err)
+
+	stackRef, err := b.getReference(stack.Ref())
 	if err != nil {
 		return false, err
 	}
-	defer b.Unlock(ctx, stack.Ref())
 
-	stackName := stack.Ref().Name()
-	snapshot, _, err := b.getStack(stackName)
+	snapshot, _, err := b.getStack(stackRef)
 	if err != nil {
 		return false, err
 	}
@@ -389,35 +468,36 @@ func (b *localBackend) RemoveStack(ctx context.Context, stack backend.Stack, for
 		return true, errors.New("refusing to remove stack because it still contains resources")
 	}
 
-	return false, b.removeStack(stackName)
+	return false, b.removeStack(stackRef)
 }
 
 func (b *localBackend) RenameStack(ctx context.Context, stack backend.Stack,
-	newName tokens.QName) (backend.StackReference, error) {
+	newName tokens.QName) (_ backend.StackReference, err error) {
 
-	err := b.Lock(ctx, stack.Ref())
+	err = b.Lock(ctx, stack.Ref())
 	if err != nil {
 		return nil, err
 	}
-	defer b.Unlock(ctx, stack.Ref())
+	defer b.unlock(ctx, stack.Ref(), &err)
 
 	// Get the current state from the stack to be renamed.
-	stackName := stack.Ref().Name()
-	snap, _, err := b.getStack(stackName)
+	oldRef, err := b.getReference(stack.Ref())
+	if err != nil {
+		return nil, err
+	}
+	snap, _, err := b.getStack(oldRef)
 	if err != nil {
 		return nil, err
 	}
 
 	// Ensure the new stack name is valid.
-	newRef, err := b.ParseStackReference(string(newName))
+	newRef, err := b.parseStackReference(string(newName))
 	if err != nil {
 		return nil, err
 	}
 
-	newStackName := newRef.Name()
-
 	// Ensure the destination stack does not already exist.
-	hasExisting, err := b.bucket.Exists(ctx, b.stackPath(newStackName))
+	hasExisting, err := b.bucket.Exists(ctx, b.stackPath(newRef))
 	if err != nil {
 		return nil, err
 	}
@@ -425,24 +505,35 @@ func (b *localBackend) RenameStack(ctx context.Context, stack backend.Stack,
 		return nil, fmt.Errorf("a stack named %s already exists", newName)
 	}
 
-	// If we have a snapshot, we need to rename the URNs inside it to use the new stack name.
+	// If we have a snapshot, we need to rename the URNs inside it to use the new stack name, and the new project
+	// name if the stack is moving between projects.
 	if snap != nil {
-		if err = edit.RenameStack(snap, newStackName, ""); err != nil {
+		var newProject tokens.PackageName
+		if newRef.project != oldRef.project {
+			newProject = tokens.PackageName(newRef.project)
+		}
+		if err = edit.RenameStack(snap, newRef.name, newProject); err != nil {
 			return nil, err
 		}
 	}
 
 	// Now save the snapshot with a new name (we pass nil to re-use the existing secrets manager from the snapshot).
-	if _, err = b.saveStack(newStackName, snap, nil); err != nil {
+	if _, err = b.saveStack(newRef, snap, nil); err != nil {
 		return nil, err
 	}
 
 	// To remove the old stack, just make a backup of the file and don't write out anything new.
-	file := b.stackPath(stackName)
+	file := b.stackPath(oldRef)
 	backupTarget(b.bucket, file, false)
 
-	// And rename the history folder as well.
-	if err = b.renameHistory(stackName, newStackName); err != nil {
+	// And rename the history and backup folders and tags as well.
+	if err = b.renameHistory(oldRef, newRef); err != nil {
+		return nil, err
+	}
+	if err = b.renameBackups(oldRef, newRef); err != nil {
+		return nil, err
+	}
+	if err = b.moveStackTags(ctx, oldRef, newRef); err != nil {
 		return nil, err
 	}
 	return newRef, err
@@ -482,50 +573,50 @@ func (b *localBackend) Preview(ctx context.Context, stack backend.Stack,
 }
 
 func (b *localBackend) Update(ctx context.Context, stack backend.Stack,
-	op backend.UpdateOperation) (sdkDisplay.ResourceChanges, result.Result) {
+	op backend.UpdateOperation) (_ sdkDisplay.ResourceChanges, res result.Result) {
 
 	err := b.Lock(ctx, stack.Ref())
 	if err != nil {
 		return nil, result.FromError(err)
 	}
-	defer b.Unlock(ctx, stack.Ref())
+	defer b.unlockResult(ctx, stack.Ref(), &res)
 
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.UpdateUpdate, stack, op, b.apply)
 }
 
 func (b *localBackend) Import(ctx context.Context, stack backend.Stack,
-	op backend.UpdateOperation, imports []deploy.Import) (sdkDisplay.ResourceChanges, result.Result) {
+	op backend.UpdateOperation, imports []deploy.Import) (_ sdkDisplay.ResourceChanges, res result.Result) {
 
 	err := b.Lock(ctx, stack.Ref())
 	if err != nil {
 		return nil, result.FromError(err)
 	}
-	defer b.Unlock(ctx, stack.Ref())
+	defer b.unlockResult(ctx, stack.Ref(), &res)
 
 	op.Imports = imports
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.ResourceImportUpdate, stack, op, b.apply)
 }
 
 func (b *localBackend) Refresh(ctx context.Context, stack backend.Stack,
-	op backend.UpdateOperation) (sdkDisplay.ResourceChanges, result.Result) {
+	op backend.UpdateOperation) (_ sdkDisplay.ResourceChanges, res result.Result) {
 
 	err := b.Lock(ctx, stack.Ref())
 	if err != nil {
 		return nil, result.FromError(err)
 	}
-	defer b.Unlock(ctx, stack.Ref())
+	defer b.unlockResult(ctx, stack.Ref(), &res)
 
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.RefreshUpdate, stack, op, b.apply)
 }
 
 func (b *localBackend) Destroy(ctx context.Context, stack backend.Stack,
-	op backend.UpdateOperation) (sdkDisplay.ResourceChanges, result.Result) {
+	op backend.UpdateOperation) (_ sdkDisplay.ResourceChanges, res result.Result) {
 
 	err := b.Lock(ctx, stack.Ref())
 	if err != nil {
 		return nil, result.FromError(err)
 	}
-	defer b.Unlock(ctx, stack.Ref())
+	defer b.unlockResult(ctx, stack.Ref(), &res)
 
 	return backend.PreviewThenPromptThenExecute(ctx, apitype.DestroyUpdate, stack, op, b.apply)
 }
@@ -546,123 +637,34 @@ func (b *localBackend) apply(
 	op backend.UpdateOperation, opts backend.ApplierOptions,
 	events chan<- engine.Event) (*deploy.Plan, sdkDisplay.ResourceChanges, result.Result) {
 
-	stackRef := stack.Ref()
-	stackName := stackRef.Name()
-	actionLabel := backend.ActionLabel(kind, opts.DryRun)
-
-	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch) {
-		// Print a banner so it's clear this is a local deployment.
-		fmt.Printf(op.Opts.Display.Color.Colorize(
-			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
-	}
-
-	// Start the update.
-	update, err := b.newUpdate(stackName, op)
+	stackRef, err := b.getReference(stack.Ref())
 	if err != nil {
 		return nil, nil, result.FromError(err)
 	}
 
-	// Spawn a display loop to show events on the CLI.
-	displayEvents := make(chan engine.Event)
-	displayDone := make(chan bool)
-	go display.ShowEvents(
-		strings.ToLower(actionLabel), kind, stackName, op.Proj.Name,
-		displayEvents, displayDone, op.Opts.Display, opts.DryRun)
-
-	// Create a separate event channel for engine events that we'll pipe to both listening streams.
-	engineEvents := make(chan engine.Event)
-
-	scope := op.Scopes.NewScope(engineEvents, opts.DryRun)
-	eventsDone := make(chan bool)
-	go func() {
-		// Pull in all events from the engine and send them to the two listeners.
-		for e := range engineEvents {
-			displayEvents <- e
+	plan, changes, res := backend.RunSelfManagedUpdate(ctx, kind, stack, op, opts, events, backend.SelfManagedUpdate{
+		Backend:  b,
+		StackRef: stackRef,
+		NewUpdate: func() (engine.UpdateInfo, error) {
+			return b.newUpdate(stackRef, op)
+		},
+		SaveStackTags: func(tags map[apitype.StackTagName]string) error {
+			return b.saveStackTags(ctx, stackRef, tags)
+		},
+		Persister: b.newSnapshotPersister(stackRef, op.SecretsManager),
+		AddToHistory: func(info backend.UpdateInfo) error {
+			return b.addToHistory(stackRef, info, op.SecretsManager)
+		},
+	})
 
-			// If the caller also wants to see the events, stream them there also.
-			if events != nil {
-				events <- e
-			}
-		}
-
-		close(eventsDone)
-	}()
-
-	// Create the management machinery.
-	persister := b.newSnapshotPersister(stackName, op.SecretsManager)
-	manager := backend.NewSnapshotManager(persister, update.GetTarget().Snapshot)
-	engineCtx := &engine.Context{
-		Cancel:          scope.Context(),
-		Events:          engineEvents,
-		SnapshotManager: manager,
-		BackendClient:   backend.NewBackendClient(b),
-	}
-
-	// Perform the update
-	start := time.Now().Unix()
-	var plan *deploy.Plan
-	var changes sdkDisplay.ResourceChanges
-	var updateRes result.Result
-	switch kind {
-	case apitype.PreviewUpdate:
-		plan, changes, updateRes = engine.Update(update, engineCtx, op.Opts.Engine, true)
-	case apitype.UpdateUpdate:
-		_, changes, updateRes = engine.Update(update, engineCtx, op.Opts.Engine, opts.DryRun)
-	case apitype.ResourceImportUpdate:
-		_, changes, updateRes = engine.Import(update, engineCtx, op.Opts.Engine, op.Imports, opts.DryRun)
-	case apitype.RefreshUpdate:
-		_, changes, updateRes = engine.Refresh(update, engineCtx, op.Opts.Engine, opts.DryRun)
-	case apitype.DestroyUpdate:
-		_, changes, updateRes = engine.Destroy(update, engineCtx, op.Opts.Engine, opts.DryRun)
-	default:
-		contract.Failf("Unrecognized update kind: %s", kind)
-	}
-	end := time.Now().Unix()
-
-	// Wait for the display to finish showing all the events.
-	<-displayDone
-	scope.Close() // Don't take any cancellations anymore, we're shutting down.
-	close(engineEvents)
-	contract.IgnoreClose(manager)
-
-	// Make sure the goroutine writing to displayEvents and events has exited before proceeding.
-	<-eventsDone
-	close(displayEvents)
-
-	// Save update results.
-	backendUpdateResult := backend.SucceededResult
-	if updateRes != nil {
-		backendUpdateResult = backend.FailedResult
-	}
-	info := backend.UpdateInfo{
-		Kind:        kind,
-		StartTime:   start,
-		Message:     op.M.Message,
-		Environment: op.M.Environment,
-		Config:      update.GetTarget().Config,
-		Result:      backendUpdateResult,
-		EndTime:     end,
-		// IDEA: it would be nice to populate the *Deployment, so that addToHistory below doesn't need to
-		//     rudely assume it knows where the checkpoint file is on disk as it makes a copy of it.  This isn't
-		//     trivial to achieve today given the event driven nature of plan-walking, however.
-		ResourceChanges: changes,
-	}
-
-	var saveErr error
 	var backupErr error
 	if !opts.DryRun {
-		saveErr = b.addToHistory(stackName, info)
-		backupErr = b.backupStack(stackName)
-	}
-
-	if updateRes != nil {
-		// We swallow saveErr and backupErr as they are less important than the updateErr.
-		return plan, changes, updateRes
+		backupErr = b.backupStack(stackRef)
 	}
 
-	if saveErr != nil {
-		// We swallow backupErr as it is less important than the saveErr.
-		return plan, changes, result.FromError(fmt.Errorf("saving update info: %w", saveErr))
+	if res != nil {
+		// We swallow backupErr as it is less important than the update or save error.
+		return plan, changes, res
 	}
 
 	if backupErr != nil {
@@ -676,10 +678,10 @@ func (b *localBackend) apply(
 		var link string
 		if strings.HasPrefix(b.url, FilePathPrefix) {
 			u, _ := url.Parse(b.url)
-			u.Path = filepath.ToSlash(path.Join(u.Path, b.stackPath(stackName)))
+			u.Path = filepath.ToSlash(path.Join(u.Path, b.stackPath(stackRef)))
 			link = u.String()
 		} else {
-			link, err = b.bucket.SignedURL(context.TODO(), b.stackPath(stackName), nil)
+			link, err = b.bucket.SignedURL(context.TODO(), b.stackPath(stackRef), nil)
 			if err != nil {
 				// set link to be empty to when there is an error to hide use of Permalinks
 				link = ""
@@ -717,8 +719,11 @@ func (b *localBackend) GetHistory(
 	stackRef backend.StackReference,
 	pageSize int,
 	page int) ([]backend.UpdateInfo, error) {
-	stackName := stackRef.Name()
-	updates, err := b.getHistory(stackName, pageSize, page)
+	localStackRef, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := b.getHistory(localStackRef, pageSize, page)
 	if err != nil {
 		return nil, err
 	}
@@ -728,8 +733,11 @@ func (b *localBackend) GetHistory(
 func (b *localBackend) GetLogs(ctx context.Context, stack backend.Stack, cfg backend.StackConfiguration,
 	query operations.LogQuery) ([]operations.LogEntry, error) {
 
-	stackName := stack.Ref().Name()
-	target, err := b.getTarget(stackName, cfg.Config, cfg.Decrypter)
+	stackRef, err := b.getReference(stack.Ref())
+	if err != nil {
+		return nil, err
+	}
+	target, err := b.getTarget(stackRef, cfg.Config, cfg.Decrypter)
 	if err != nil {
 		return nil, err
 	}
@@ -763,8 +771,11 @@ func GetLogsForTarget(target *deploy.Target, query operations.LogQuery) ([]opera
 func (b *localBackend) ExportDeployment(ctx context.Context,
 	stk backend.Stack) (*apitype.UntypedDeployment, error) {
 
-	stackName := stk.Ref().Name()
-	snap, _, err := b.getStack(stackName)
+	stackRef, err := b.getReference(stk.Ref())
+	if err != nil {
+		return nil, err
+	}
+	snap, _, err := b.getStack(stackRef)
 	if err != nil {
 		return nil, err
 	}
@@ -789,17 +800,57 @@ func (b *localBackend) ExportDeployment(ctx context.Context,
 	}, nil
 }
 
+func (b *localBackend) ExportDeploymentForVersion(ctx context.Context, stk backend.Stack,
+	version string) (*apitype.UntypedDeployment, error) {
+
+	// As with the service, versions are positive integers: the first update is version 1, the second 2 and so on.
+	// See `pulumi stack history` for the versions of a stack's updates.
+	versionNumber, err := strconv.Atoi(version)
+	if err != nil || versionNumber <= 0 {
+		return nil, fmt.Errorf(
+			"%q is not a valid stack version. It should be a positive integer",
+			version)
+	}
+
+	stackRef, err := b.getReference(stk.Ref())
+	if err != nil {
+		return nil, err
+	}
+	chk, err := b.getHistoryCheckpoint(stackRef, versionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// The checkpoint is exported as-is, so there is no need to decrypt its secrets.
+	latest := chk.Latest
+	if latest == nil {
+		latest = &apitype.DeploymentV3{}
+	}
+	data, err := json.Marshal(latest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitype.UntypedDeployment{
+		Version:    3,
+		Deployment: json.RawMessage(data),
+	}, nil
+}
+
 func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
-	deployment *apitype.UntypedDeployment) error {
+	deployment *apitype.UntypedDeployment) (err error) {
 
-	err := b.Lock(ctx, stk.Ref())
+	err = b.Lock(ctx, stk.Ref())
 	if err != nil {
 		return err
 	}
-	defer b.Unlock(ctx, stk.Ref())
+	defer b.unlock(ctx, stk.Ref(), &err)
 
-	stackName := stk.Ref().Name()
-	_, _, err = b.getStack(stackName)
+	stackRef, err := b.getReference(stk.Ref())
+	if err != nil {
+		return err
+	}
+	_, _, err = b.getStack(stackRef)
 	if err != nil {
 		return err
 	}
@@ -809,7 +860,7 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stk backend.Stack,
 		return err
 	}
 
-	_, err = b.saveStack(stackName, snap, snap.SecretsManager)
+	_, err = b.saveStack(stackRef, snap, snap.SecretsManager)
 	return err
 }
 
@@ -829,56 +880,30 @@ func (b *localBackend) CurrentUser() (string, []string, error) {
 	return user.Username, nil, nil
 }
 
-func (b *localBackend) getLocalStacks() ([]tokens.Name, error) {
-	var stacks []tokens.Name
-
-	// Read the stack directory.
-	path := b.stackPath("")
+// UpdateStackTags updates the stacks's tags, replacing all existing tags.
+func (b *localBackend) UpdateStackTags(ctx context.Context,
+	stack backend.Stack, tags map[apitype.StackTagName]string) error {
 
-	files, err := listBucket(b.bucket, path)
+	stackRef, err := b.getReference(stack.Ref())
 	if err != nil {
-		return nil, fmt.Errorf("error listing stacks: %w", err)
+		return err
 	}
 
-	for _, file := range files {
-		// Ignore directories.
-		if file.IsDir {
-			continue
-		}
-
-		// Skip files without valid extensions (e.g., *.bak files).
-		stackfn := objectName(file)
-		ext := filepath.Ext(stackfn)
-		// But accept gzip compression
-		if ext == encoding.GZIPExt {
-			stackfn = strings.TrimSuffix(stackfn, encoding.GZIPExt)
-			ext = filepath.Ext(stackfn)
-		}
-
-		if _, has := encoding.Marshalers[ext]; !has {
-			continue
-		}
-
-		// Read in this stack's information.
-		name := tokens.Name(stackfn[:len(stackfn)-len(ext)])
-
-		stacks = append(stacks, name)
+	if err := validation.ValidateStackTags(tags); err != nil {
+		return err
 	}
 
-	return stacks, nil
-}
-
-// UpdateStackTags updates the stacks's tags, replacing all existing tags.
-func (b *localBackend) UpdateStackTags(ctx context.Context,
-	stack backend.Stack, tags map[apitype.StackTagName]string) error {
-
-	// The local backend does not currently persist tags.
-	return errors.New("stack tags not supported in --local mode")
+	return b.saveStackTags(ctx, stackRef, tags)
 }
 
 func (b *localBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error {
+	localStackRef, err := b.getReference(stackRef)
+	if err != nil {
+		return err
+	}
+
 	// Try to delete ALL the lock files
-	allFiles, err := listBucket(b.bucket, stackLockDir(stackRef.Name()))
+	allFiles, err := listBucket(b.bucket, stackLockDir(localStackRef))
 	if err != nil {
 		// Don't error if it just wasn't found
 		if gcerrors.Code(err) == gcerrors.NotFound {
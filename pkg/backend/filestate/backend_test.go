@@ -3,12 +3,14 @@ package filestate
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	user "github.com/tweekmonster/luser"
@@ -17,14 +19,25 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/operations"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/b64"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
+// newTestBackend creates a filestate backend that treats "testproj" as the current project.
+func newTestBackend(url string) (Backend, error) {
+	b, err := newLocalBackend(cmdutil.Diag(), url, &workspace.Project{Name: "testproj"})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 func TestMassageBlobPath(t *testing.T) {
 	t.Parallel()
 
@@ -144,7 +157,7 @@ func TestListStacksWithMultiplePassphrases(t *testing.T) {
 	// Login to a temp dir filestate backend
 	tmpDir, err := ioutil.TempDir("", "filestatebackend")
 	assert.NoError(t, err)
-	b, err := New(cmdutil.Diag(), "file://"+filepath.ToSlash(tmpDir))
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
 	assert.NoError(t, err)
 	ctx := context.Background()
 
@@ -208,7 +221,7 @@ func TestDrillError(t *testing.T) {
 	// Login to a temp dir filestate backend
 	tmpDir, err := ioutil.TempDir("", "filestatebackend")
 	assert.NoError(t, err)
-	b, err := New(cmdutil.Diag(), "file://"+filepath.ToSlash(tmpDir))
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
 	assert.NoError(t, err)
 	ctx := context.Background()
 
@@ -227,7 +240,7 @@ func TestCancel(t *testing.T) {
 	// Login to a temp dir filestate backend
 	tmpDir, err := ioutil.TempDir("", "filestatebackend")
 	assert.NoError(t, err)
-	b, err := New(cmdutil.Diag(), "file://"+filepath.ToSlash(tmpDir))
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
 	assert.NoError(t, err)
 	ctx := context.Background()
 
@@ -253,19 +266,19 @@ func TestCancel(t *testing.T) {
 	err = lb.Lock(ctx, aStackRef)
 	assert.NoError(t, err)
 	// check the lock file exists
-	lockExists, err := lb.bucket.Exists(ctx, lb.lockPath(aStackRef.Name()))
+	lockExists, err := lb.bucket.Exists(ctx, lb.lockPath(aStackRef.(*localBackendReference)))
 	assert.NoError(t, err)
 	assert.True(t, lockExists)
 	// Call CancelCurrentUpdate
 	err = lb.CancelCurrentUpdate(ctx, aStackRef)
 	assert.NoError(t, err)
 	// Now check the lock file no longer exists
-	lockExists, err = lb.bucket.Exists(ctx, lb.lockPath(aStackRef.Name()))
+	lockExists, err = lb.bucket.Exists(ctx, lb.lockPath(aStackRef.(*localBackendReference)))
 	assert.NoError(t, err)
 	assert.False(t, lockExists)
 
 	// Make another filestate backend which will have a different lockId
-	ob, err := New(cmdutil.Diag(), "file://"+filepath.ToSlash(tmpDir))
+	ob, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
 	assert.NoError(t, err)
 	otherBackend, ok := ob.(*localBackend)
 	assert.True(t, ok)
@@ -289,7 +302,7 @@ func TestRemoveMakesBackups(t *testing.T) {
 	// Login to a temp dir filestate backend
 	tmpDir, err := ioutil.TempDir("", "filestatebackend")
 	assert.NoError(t, err)
-	b, err := New(cmdutil.Diag(), "file://"+filepath.ToSlash(tmpDir))
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
 	assert.NoError(t, err)
 	ctx := context.Background()
 
@@ -306,10 +319,10 @@ func TestRemoveMakesBackups(t *testing.T) {
 	assert.NotNil(t, aStack)
 
 	// Check the stack file now exists, but the backup file doesn't
-	stackFileExists, err := lb.bucket.Exists(ctx, lb.stackPath(aStackRef.Name()))
+	stackFileExists, err := lb.bucket.Exists(ctx, lb.stackPath(aStackRef.(*localBackendReference)))
 	assert.NoError(t, err)
 	assert.True(t, stackFileExists)
-	backupFileExists, err := lb.bucket.Exists(ctx, lb.stackPath(aStackRef.Name())+".bak")
+	backupFileExists, err := lb.bucket.Exists(ctx, lb.stackPath(aStackRef.(*localBackendReference))+".bak")
 	assert.NoError(t, err)
 	assert.False(t, backupFileExists)
 
@@ -319,10 +332,10 @@ func TestRemoveMakesBackups(t *testing.T) {
 	assert.False(t, removed)
 
 	// Check the stack file is now gone, but the backup file exists
-	stackFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(aStackRef.Name()))
+	stackFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(aStackRef.(*localBackendReference)))
 	assert.NoError(t, err)
 	assert.False(t, stackFileExists)
-	backupFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(aStackRef.Name())+".bak")
+	backupFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(aStackRef.(*localBackendReference))+".bak")
 	assert.NoError(t, err)
 	assert.True(t, backupFileExists)
 }
@@ -333,7 +346,7 @@ func TestRenameWorks(t *testing.T) {
 	// Login to a temp dir filestate backend
 	tmpDir, err := ioutil.TempDir("", "filestatebackend")
 	assert.NoError(t, err)
-	b, err := New(cmdutil.Diag(), "file://"+filepath.ToSlash(tmpDir))
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
 	assert.NoError(t, err)
 	ctx := context.Background()
 
@@ -350,15 +363,15 @@ func TestRenameWorks(t *testing.T) {
 	assert.NotNil(t, aStack)
 
 	// Check the stack file now exists
-	stackFileExists, err := lb.bucket.Exists(ctx, lb.stackPath(aStackRef.Name()))
+	stackFileExists, err := lb.bucket.Exists(ctx, lb.stackPath(aStackRef.(*localBackendReference)))
 	assert.NoError(t, err)
 	assert.True(t, stackFileExists)
 
 	// Fake up some history
-	err = lb.addToHistory("a", backend.UpdateInfo{Kind: apitype.DestroyUpdate})
+	err = lb.addToHistory(aStackRef.(*localBackendReference), backend.UpdateInfo{Kind: apitype.DestroyUpdate}, nil)
 	assert.NoError(t, err)
 	// And pollute the history folder
-	err = lb.bucket.WriteAll(ctx, path.Join(lb.historyDirectory("a"), "randomfile.txt"), []byte{0, 13}, nil)
+	err = lb.bucket.WriteAll(ctx, path.Join(lb.historyDirectory(aStackRef.(*localBackendReference)), "randomfile.txt"), []byte{0, 13}, nil)
 	assert.NoError(t, err)
 
 	// Rename the stack
@@ -367,10 +380,10 @@ func TestRenameWorks(t *testing.T) {
 	assert.Equal(t, "b", bStackRef.String())
 
 	// Check the new stack file now exists and the old one is gone
-	stackFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(bStackRef.Name()))
+	stackFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(bStackRef.(*localBackendReference)))
 	assert.NoError(t, err)
 	assert.True(t, stackFileExists)
-	stackFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(aStackRef.Name()))
+	stackFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(aStackRef.(*localBackendReference)))
 	assert.NoError(t, err)
 	assert.False(t, stackFileExists)
 
@@ -382,10 +395,10 @@ func TestRenameWorks(t *testing.T) {
 	assert.Equal(t, "c", cStackRef.String())
 
 	// Check the new stack file now exists and the old one is gone
-	stackFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(cStackRef.Name()))
+	stackFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(cStackRef.(*localBackendReference)))
 	assert.NoError(t, err)
 	assert.True(t, stackFileExists)
-	stackFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(bStackRef.Name()))
+	stackFileExists, err = lb.bucket.Exists(ctx, lb.stackPath(bStackRef.(*localBackendReference)))
 	assert.NoError(t, err)
 	assert.False(t, stackFileExists)
 
@@ -394,4 +407,590 @@ func TestRenameWorks(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, history, 1)
 	assert.Equal(t, apitype.DestroyUpdate, history[0].Kind)
+
+	// Renaming the stack into another project moves its history and backups too.
+	err = lb.backupStack(cStackRef.(*localBackendReference))
+	assert.NoError(t, err)
+	cStack, err := b.GetStack(ctx, cStackRef)
+	assert.NoError(t, err)
+	dStackRef, err := b.RenameStack(ctx, cStack, "organization/otherproj/d")
+	assert.NoError(t, err)
+
+	history, err = b.GetHistory(ctx, dStackRef, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	backups, err := listBucket(lb.bucket, lb.backupDirectory(dStackRef.(*localBackendReference)))
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+	backups, err = listBucket(lb.bucket, lb.backupDirectory(cStackRef.(*localBackendReference)))
+	assert.NoError(t, err)
+	assert.Empty(t, backups)
+}
+
+func TestProjectScopedStacks(t *testing.T) {
+	t.Parallel()
+
+	// Login to a temp dir filestate backend
+	tmpDir, err := ioutil.TempDir("", "filestatebackend")
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	// Create a "dev" stack in two different projects sharing the same bucket.
+	for _, project := range []tokens.PackageName{"proja", "projb"} {
+		b, err := newLocalBackend(cmdutil.Diag(), "file://"+filepath.ToSlash(tmpDir), &workspace.Project{Name: project})
+		assert.NoError(t, err)
+
+		ref, err := b.ParseStackReference("dev")
+		assert.NoError(t, err)
+		assert.Equal(t, "dev", ref.String())
+		_, err = b.CreateStack(ctx, ref, nil)
+		assert.NoError(t, err)
+	}
+
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
+	assert.NoError(t, err)
+
+	// Stacks in other projects are referred to by their fully qualified names.
+	ref, err := b.ParseStackReference("organization/proja/dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "organization/proja/dev", ref.String())
+	stack, err := b.GetStack(ctx, ref)
+	assert.NoError(t, err)
+	assert.NotNil(t, stack)
+
+	_, err = b.ParseStackReference("someorg/proja/dev")
+	assert.Error(t, err)
+
+	stacks, _, err := b.ListStacks(ctx, backend.ListStacksFilter{}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, stacks, 2)
+
+	project := "projb"
+	stacks, _, err = b.ListStacks(ctx, backend.ListStacksFilter{Project: &project}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, stacks, 1)
+	assert.Equal(t, "organization/projb/dev", stacks[0].Name().String())
+
+	exists, err := b.DoesProjectExist(ctx, "proja")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = b.DoesProjectExist(ctx, "testproj")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestMigrateLegacyLayout(t *testing.T) {
+	t.Parallel()
+
+	// Login to a temp dir filestate backend
+	tmpDir, err := ioutil.TempDir("", "filestatebackend")
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	// Write out a stack using the legacy layout.
+	lb, err := newLocalBackend(cmdutil.Diag(), "file://"+filepath.ToSlash(tmpDir), &workspace.Project{Name: "testproj"})
+	assert.NoError(t, err)
+	lb.store = &legacyReferenceStore{b: lb}
+	err = lb.bucket.Delete(ctx, metaPath())
+	assert.NoError(t, err)
+
+	aStackRef, err := lb.ParseStackReference("a")
+	assert.NoError(t, err)
+	aStack, err := lb.CreateStack(ctx, aStackRef, nil)
+	assert.NoError(t, err)
+	deployment, err := makeUntypedDeployment("a", "abc123",
+		"v1:4iF78gb0nF0=:v1:Co6IbTWYs/UdrjgY:FSrAWOFZnj9ealCUDdJL7LrUKXX9BA==")
+	assert.NoError(t, err)
+	err = lb.ImportDeployment(ctx, aStack, deployment)
+	assert.NoError(t, err)
+	err = lb.addToHistory(aStackRef.(*localBackendReference), backend.UpdateInfo{Kind: apitype.UpdateUpdate}, nil)
+	assert.NoError(t, err)
+
+	// A stack without any resources is migrated into the project it was tagged with when it was created. A stack
+	// without a project tag is left where it is.
+	taggedRef, err := lb.ParseStackReference("tagged")
+	assert.NoError(t, err)
+	_, err = lb.CreateStack(ctx, taggedRef, nil)
+	assert.NoError(t, err)
+	err = lb.saveStackTags(ctx, taggedRef.(*localBackendReference),
+		map[apitype.StackTagName]string{apitype.ProjectNameTag: "other"})
+	assert.NoError(t, err)
+	emptyRef, err := lb.ParseStackReference("empty")
+	assert.NoError(t, err)
+	_, err = lb.CreateStack(ctx, emptyRef, nil)
+	assert.NoError(t, err)
+
+	legacyPath := lb.stackPath(aStackRef.(*localBackendReference))
+	exists, err := lb.bucket.Exists(ctx, legacyPath)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	// While another process holds the lock on a stack, opening the bucket leaves every stack where it is.
+	err = lb.Lock(ctx, aStackRef)
+	assert.NoError(t, err)
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
+	assert.NoError(t, err)
+	exists, err = lb.bucket.Exists(ctx, legacyPath)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	meta, err := b.(*localBackend).readMeta(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, legacyLayoutVersion, meta.Version)
+	err = lb.Unlock(ctx, aStackRef)
+	assert.NoError(t, err)
+
+	// Once the lock has been released, opening the bucket again resumes the migration, which moves the stack into
+	// the project recorded in its resources' URNs.
+	b, err = newTestBackend("file://" + filepath.ToSlash(tmpDir))
+	assert.NoError(t, err)
+	exists, err = lb.bucket.Exists(ctx, legacyPath)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = lb.bucket.Exists(ctx, lb.stackPath(emptyRef.(*localBackendReference)))
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	// The locks taken during the migration have been released.
+	locks, err := listBucket(lb.bucket, lockDir())
+	assert.NoError(t, err)
+	assert.Empty(t, locks)
+
+	tagged, err := b.ParseStackReference("organization/other/tagged")
+	assert.NoError(t, err)
+	taggedStack, err := b.GetStack(ctx, tagged)
+	assert.NoError(t, err)
+	assert.NotNil(t, taggedStack)
+
+	ref, err := b.ParseStackReference("organization/proj/a")
+	assert.NoError(t, err)
+	stack, err := b.GetStack(ctx, ref)
+	assert.NoError(t, err)
+	assert.NotNil(t, stack)
+
+	history, err := b.GetHistory(ctx, ref, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	meta, err = b.(*localBackend).readMeta(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, projectLayoutVersion, meta.Version)
+}
+
+func TestStackTags(t *testing.T) {
+	t.Parallel()
+
+	// Login to a temp dir filestate backend
+	tmpDir, err := ioutil.TempDir("", "filestatebackend")
+	assert.NoError(t, err)
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.True(t, b.SupportsTags())
+
+	for _, name := range []string{"a", "b"} {
+		ref, err := b.ParseStackReference(name)
+		assert.NoError(t, err)
+		_, err = b.CreateStack(ctx, ref, nil)
+		assert.NoError(t, err)
+	}
+
+	// Tag stack "a" and check the tags are persisted.
+	aStackRef, err := b.ParseStackReference("a")
+	assert.NoError(t, err)
+	aStack, err := b.GetStack(ctx, aStackRef)
+	assert.NoError(t, err)
+	err = b.UpdateStackTags(ctx, aStack, map[apitype.StackTagName]string{"team": "platform"})
+	assert.NoError(t, err)
+
+	aStack, err = b.GetStack(ctx, aStackRef)
+	assert.NoError(t, err)
+	assert.Equal(t, map[apitype.StackTagName]string{"team": "platform"}, aStack.Tags())
+
+	// Invalid tags are rejected.
+	err = b.UpdateStackTags(ctx, aStack, map[apitype.StackTagName]string{"bad tag!": "x"})
+	assert.Error(t, err)
+
+	// Listing stacks honors the tag filters.
+	tagName, tagValue, otherValue := "team", "platform", "other"
+	stacks, _, err := b.ListStacks(ctx, backend.ListStacksFilter{TagName: &tagName}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, stacks, 1)
+	assert.Equal(t, "a", stacks[0].Name().String())
+	stacks, _, err = b.ListStacks(ctx, backend.ListStacksFilter{TagName: &tagName, TagValue: &tagValue}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, stacks, 1)
+	stacks, _, err = b.ListStacks(ctx, backend.ListStacksFilter{TagName: &tagName, TagValue: &otherValue}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, stacks, 0)
+
+	// Tags follow the stack when it is renamed.
+	cStackRef, err := b.RenameStack(ctx, aStack, "c")
+	assert.NoError(t, err)
+	cStack, err := b.GetStack(ctx, cStackRef)
+	assert.NoError(t, err)
+	assert.Equal(t, map[apitype.StackTagName]string{"team": "platform"}, cStack.Tags())
+}
+
+type testRegisterResourceEvent struct {
+	deploy.SourceEvent
+}
+
+func (testRegisterResourceEvent) Goal() *resource.Goal               { return nil }
+func (testRegisterResourceEvent) Done(result *deploy.RegisterResult) {}
+
+func TestJournalRecovery(t *testing.T) {
+	t.Parallel()
+
+	// Login to a temp dir filestate backend
+	tmpDir, err := ioutil.TempDir("", "filestatebackend")
+	assert.NoError(t, err)
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
+	assert.NoError(t, err)
+	ctx := context.Background()
+	lb := b.(*localBackend)
+
+	stackRef, err := b.ParseStackReference("a")
+	assert.NoError(t, err)
+	_, err = b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref := stackRef.(*localBackendReference)
+
+	sm := b64.NewBase64SecretsManager()
+	a := &resource.State{
+		URN:     resource.NewURN("a", "testproj", "", "a:b:c", "a"),
+		Type:    "a:b:c",
+		Inputs:  resource.PropertyMap{},
+		Outputs: resource.PropertyMap{},
+	}
+	_, err = lb.saveStack(ref, deploy.NewSnapshot(deploy.Manifest{}, sm, []*resource.State{a}, nil), sm)
+	assert.NoError(t, err)
+
+	// Start an update that journals a new resource, and then abandon it without closing the manager, as if the
+	// process had been killed.
+	base, _, err := lb.getStack(ref)
+	assert.NoError(t, err)
+	persister := &localJournalPersister{localSnapshotPersister: lb.newSnapshotPersister(ref, sm).(*localSnapshotPersister),
+		interval: defaultJournalCompactionInterval}
+	manager := backend.NewSnapshotManager(persister, base)
+
+	bRes := &resource.State{
+		URN:  resource.NewURN("a", "testproj", "", "a:b:c", "b"),
+		Type: "a:b:c",
+		Inputs: resource.PropertyMap{
+			"secret": resource.MakeSecret(resource.NewStringProperty("s3cr3t")),
+		},
+		Outputs: resource.PropertyMap{},
+	}
+	step := deploy.NewCreateStep(nil, testRegisterResourceEvent{}, bRes)
+	mutation, err := manager.BeginMutation(step)
+	assert.NoError(t, err)
+	assert.NoError(t, mutation.End(step, true))
+
+	// The checkpoint itself hasn't been written yet.
+	snap, _, err := lb.getStack(ref)
+	assert.NoError(t, err)
+	assert.Len(t, snap.Resources, 1)
+
+	// Taking the lock recovers the interrupted update from its journal.
+	assert.NoError(t, lb.Lock(ctx, stackRef))
+	assert.NoError(t, lb.Unlock(ctx, stackRef))
+
+	snap, _, err = lb.getStack(ref)
+	assert.NoError(t, err)
+	if assert.Len(t, snap.Resources, 2) {
+		assert.Equal(t, bRes.URN, snap.Resources[0].URN)
+		assert.Equal(t, bRes.Inputs, snap.Resources[0].Inputs)
+		assert.Equal(t, a.URN, snap.Resources[1].URN)
+	}
+	files, err := listBucket(lb.bucket, lb.journalDirectory(ref))
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestPruneHistory(t *testing.T) {
+	t.Parallel()
+
+	// Login to a temp dir filestate backend
+	tmpDir, err := ioutil.TempDir("", "filestatebackend")
+	assert.NoError(t, err)
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
+	assert.NoError(t, err)
+	ctx := context.Background()
+	lb := b.(*localBackend)
+
+	stackRef, err := b.ParseStackReference("a")
+	assert.NoError(t, err)
+	_, err = b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref := stackRef.(*localBackendReference)
+
+	// Write three updates and three backups, a day apart, plus a file we don't recognize.
+	now := time.Now()
+	write := func(key string) {
+		assert.NoError(t, lb.bucket.WriteAll(ctx, key, []byte("{}"), nil))
+	}
+	for i := 0; i < 3; i++ {
+		ts := now.Add(time.Duration(-i) * 24 * time.Hour).UnixNano()
+		write(path.Join(lb.historyDirectory(ref), fmt.Sprintf("a-%d.history.json", ts)))
+		write(path.Join(lb.historyDirectory(ref), fmt.Sprintf("a-%d.checkpoint.json", ts)))
+		write(path.Join(lb.backupDirectory(ref), fmt.Sprintf("a.%d.json", ts)))
+	}
+	write(path.Join(lb.historyDirectory(ref), "README"))
+
+	// A policy is required.
+	_, err = lb.PruneHistory(ctx, stackRef, HistoryRetentionPolicy{}, true)
+	assert.Error(t, err)
+
+	// A dry run lists the oldest update and backup, but doesn't delete them.
+	pruned, err := lb.PruneHistory(ctx, stackRef, HistoryRetentionPolicy{KeepCount: 2}, true)
+	assert.NoError(t, err)
+	assert.Len(t, pruned, 3)
+	updates, err := b.GetHistory(ctx, stackRef, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, updates, 3)
+
+	pruned, err = lb.PruneHistory(ctx, stackRef, HistoryRetentionPolicy{KeepCount: 2}, false)
+	assert.NoError(t, err)
+	assert.Len(t, pruned, 3)
+	updates, err = b.GetHistory(ctx, stackRef, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, updates, 2)
+
+	// The updates were written without versions, which were saved before the oldest was pruned, so the remaining
+	// updates keep their numbers.
+	assert.Equal(t, 3, updates[0].Version)
+	assert.Equal(t, 2, updates[1].Version)
+	next, err := lb.nextHistoryVersion(ref)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, next)
+
+	// Pruning by age removes everything older than a few hours, leaving only the most recent update and backup.
+	pruned, err = lb.PruneHistory(ctx, stackRef, HistoryRetentionPolicy{MaxAge: 12 * time.Hour}, false)
+	assert.NoError(t, err)
+	assert.Len(t, pruned, 3)
+
+	history, err := listBucket(lb.bucket, lb.historyDirectory(ref))
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+	backups, err := listBucket(lb.bucket, lb.backupDirectory(ref))
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+
+	// The stack itself is untouched.
+	_, err = b.GetStack(ctx, stackRef)
+	assert.NoError(t, err)
+}
+
+func TestExportDeploymentForVersion(t *testing.T) {
+	t.Parallel()
+
+	// Login to a temp dir filestate backend
+	tmpDir, err := ioutil.TempDir("", "filestatebackend")
+	assert.NoError(t, err)
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
+	assert.NoError(t, err)
+	ctx := context.Background()
+	lb := b.(*localBackend)
+
+	stackRef, err := b.ParseStackReference("a")
+	assert.NoError(t, err)
+	aStack, err := b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref := stackRef.(*localBackendReference)
+
+	// Record two updates, the first with one resource and the second with two.
+	sm := b64.NewBase64SecretsManager()
+	var resources []*resource.State
+	for _, name := range []string{"x", "y"} {
+		resources = append(resources, &resource.State{
+			URN:     resource.NewURN("a", "testproj", "", "a:b:c", tokens.QName(name)),
+			Type:    "a:b:c",
+			Inputs:  resource.PropertyMap{},
+			Outputs: resource.PropertyMap{},
+		})
+		_, err = lb.saveStack(ref, deploy.NewSnapshot(deploy.Manifest{}, sm, resources, nil), sm)
+		assert.NoError(t, err)
+		err = lb.addToHistory(ref, backend.UpdateInfo{Kind: apitype.UpdateUpdate, Result: backend.SucceededResult}, nil)
+		assert.NoError(t, err)
+	}
+
+	updates, err := b.GetHistory(ctx, stackRef, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, updates, 2) {
+		assert.Equal(t, 2, updates[0].Version)
+		assert.Equal(t, 1, updates[1].Version)
+	}
+
+	exporter, ok := b.(backend.SpecificDeploymentExporter)
+	assert.True(t, ok)
+	for version, count := range map[string]int{"1": 1, "2": 2} {
+		deployment, err := exporter.ExportDeploymentForVersion(ctx, aStack, version)
+		assert.NoError(t, err)
+		snap, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+		assert.NoError(t, err)
+		assert.Len(t, snap.Resources, count)
+	}
+
+	_, err = exporter.ExportDeploymentForVersion(ctx, aStack, "3")
+	assert.ErrorContains(t, err, "not found")
+	_, err = exporter.ExportDeploymentForVersion(ctx, aStack, "latest")
+	assert.ErrorContains(t, err, "not a valid stack version")
+}
+
+func TestLockExpiry(t *testing.T) {
+	t.Parallel()
+
+	// Login to a temp dir filestate backend
+	tmpDir, err := ioutil.TempDir("", "filestatebackend")
+	assert.NoError(t, err)
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
+	assert.NoError(t, err)
+	ctx := context.Background()
+	lb := b.(*localBackend)
+	lb.lockTTL = 300 * time.Millisecond
+
+	stackRef, err := b.ParseStackReference("a")
+	assert.NoError(t, err)
+	_, err = b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref := stackRef.(*localBackendReference)
+
+	writeLock := func(key string, expiry time.Time) {
+		content, err := json.Marshal(lockContent{Pid: 1, Username: "u", Hostname: "h", Expiry: expiry})
+		assert.NoError(t, err)
+		assert.NoError(t, lb.bucket.WriteAll(ctx, path.Join(stackLockDir(ref), key), content, nil))
+	}
+
+	// Locks without an expiry, as written by older CLIs, are never taken over.
+	writeLock("legacy.json", time.Time{})
+	assert.Error(t, lb.Lock(ctx, stackRef))
+	assert.NoError(t, lb.CancelCurrentUpdate(ctx, stackRef))
+
+	// Expired locks are taken over.
+	writeLock("stale.json", time.Now().Add(-time.Minute))
+	assert.NoError(t, lb.Lock(ctx, stackRef))
+	exists, err := lb.bucket.Exists(ctx, path.Join(stackLockDir(ref), "stale.json"))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Our lock is renewed while we hold it, so another backend can't take it over.
+	ob, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
+	assert.NoError(t, err)
+	otherBackend := ob.(*localBackend)
+	time.Sleep(2 * lb.lockTTL)
+	assert.Error(t, otherBackend.checkForLock(ctx, stackRef))
+
+	assert.NoError(t, lb.Unlock(ctx, stackRef))
+
+	// Canceling the update removes our lock, after which we refuse to save the stack, even before the lock would next
+	// have been renewed.
+	lb.lockTTL = time.Hour
+	assert.NoError(t, lb.Lock(ctx, stackRef))
+	persister := lb.newSnapshotPersister(ref, b64.NewBase64SecretsManager())
+	assert.NoError(t, persister.Save(deploy.NewSnapshot(deploy.Manifest{}, nil, nil, nil)))
+	assert.NoError(t, otherBackend.CancelCurrentUpdate(ctx, stackRef))
+	assert.Error(t, persister.Save(deploy.NewSnapshot(deploy.Manifest{}, nil, nil, nil)))
+	lease := lb.leases[lb.lockPath(ref)]
+	assert.False(t, lb.renewLock(ctx, ref, lease))
+	assert.NoError(t, lb.Unlock(ctx, stackRef))
+
+	// The same holds when locks never expire, and so are never renewed.
+	lb.lockTTL = 0
+	assert.NoError(t, lb.Lock(ctx, stackRef))
+	assert.NoError(t, persister.Save(deploy.NewSnapshot(deploy.Manifest{}, nil, nil, nil)))
+	assert.NoError(t, otherBackend.CancelCurrentUpdate(ctx, stackRef))
+	assert.Error(t, persister.Save(deploy.NewSnapshot(deploy.Manifest{}, nil, nil, nil)))
+	assert.NoError(t, lb.Unlock(ctx, stackRef))
+
+	// Once unlocked, the stack can be locked again.
+	assert.NoError(t, lb.Lock(ctx, stackRef))
+	assert.NoError(t, lb.Unlock(ctx, stackRef))
+}
+
+func TestEncryptedCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	// Login to a temp dir filestate backend
+	tmpDir, err := ioutil.TempDir("", "filestatebackend")
+	assert.NoError(t, err)
+	b, err := newTestBackend("file://" + filepath.ToSlash(tmpDir))
+	assert.NoError(t, err)
+	ctx := context.Background()
+	lb := b.(*localBackend)
+	lb.encrypt = true
+
+	// New stacks don't have a secrets manager yet, so their empty checkpoints are written as they are.
+	stackRef, err := b.ParseStackReference("a")
+	assert.NoError(t, err)
+	aStack, err := b.CreateStack(ctx, stackRef, nil)
+	assert.NoError(t, err)
+	ref := stackRef.(*localBackendReference)
+
+	sm := b64.NewBase64SecretsManager()
+	res := &resource.State{
+		URN:     resource.NewURN("a", "testproj", "", "a:b:c", "x"),
+		Type:    "a:b:c",
+		Inputs:  resource.PropertyMap{},
+		Outputs: resource.PropertyMap{},
+	}
+	// Checkpoints and journal entries can't be written without a secrets manager to encrypt them with, even if they
+	// don't have any resources.
+	_, err = lb.saveStack(ref, deploy.NewSnapshot(deploy.Manifest{}, nil, nil, nil), nil)
+	assert.ErrorContains(t, err, "requires it to have a secrets provider")
+	journal := &localJournalPersister{localSnapshotPersister: lb.newSnapshotPersister(ref, nil).(*localSnapshotPersister)}
+	err = journal.AppendJournalEntry(apitype.JournalEntryV1{Kind: apitype.JournalEntryBegin})
+	assert.ErrorContains(t, err, "requires it to have a secrets provider")
+
+	_, err = lb.saveStack(ref, deploy.NewSnapshot(deploy.Manifest{}, sm, []*resource.State{res}, nil), sm)
+	assert.NoError(t, err)
+	err = lb.addToHistory(ref, backend.UpdateInfo{
+		Kind:    apitype.UpdateUpdate,
+		Result:  backend.SucceededResult,
+		Message: "deploy the secret-service",
+	}, sm)
+	assert.NoError(t, err)
+
+	// Nothing about the stack's resources is readable from the checkpoint file.
+	byts, err := lb.bucket.ReadAll(ctx, lb.stackPath(ref))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(byts), string(res.URN))
+	assert.Contains(t, string(byts), `"encrypted"`)
+
+	// Nor is the update record from the history file.
+	historyFiles, err := lb.listHistoryFiles(ref)
+	assert.NoError(t, err)
+	if assert.Len(t, historyFiles, 1) {
+		byts, err = lb.bucket.ReadAll(ctx, historyFiles[0].Key)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(byts), "secret-service")
+		assert.Contains(t, string(byts), `"encrypted"`)
+	}
+	history, err := lb.GetHistory(ctx, stackRef, 0, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 1) {
+		assert.Equal(t, "deploy the secret-service", history[0].Message)
+		assert.Equal(t, 1, history[0].Version)
+	}
+
+	// The checkpoint is decrypted when read, even if encryption has since been disabled.
+	lb.encrypt = false
+	snap, _, err := lb.getStack(ref)
+	assert.NoError(t, err)
+	if assert.Len(t, snap.Resources, 1) {
+		assert.Equal(t, res.URN, snap.Resources[0].URN)
+	}
+
+	deployment, err := lb.ExportDeploymentForVersion(ctx, aStack, "1")
+	assert.NoError(t, err)
+	exported, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+	assert.NoError(t, err)
+	assert.Len(t, exported.Resources, 1)
+
+	// Listing stacks doesn't decrypt their checkpoints, so their resources aren't counted.
+	summaries, _, err := b.ListStacks(ctx, backend.ListStacksFilter{}, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, summaries, 1) {
+		assert.Nil(t, summaries[0].ResourceCount())
+	}
 }
@@ -0,0 +1,173 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+)
+
+// PulumiFilestateEncryptEnvVar is an env var that, when truthy, makes the filestate backend encrypt checkpoints,
+// journals and update history records in their entirety using the stack's secrets provider, rather than just the
+// secret values within them. Encrypted files can be read regardless of this setting. The empty checkpoints of new
+// stacks, which don't have a secrets provider yet, and stack tags are not encrypted.
+const PulumiFilestateEncryptEnvVar = "PULUMI_SELF_MANAGED_STATE_ENCRYPT"
+
+// encryptedEnvelope is the format of a file whose contents have been encrypted with a stack's secrets provider. The
+// secrets provider is recorded in the clear, so that the contents can be decrypted without any other information.
+type encryptedEnvelope struct {
+	// Version is always the current deployment schema version. There is deliberately no `checkpoint` field, so that
+	// older versions of the CLI fail to read an encrypted checkpoint instead of mistaking it for an empty one.
+	Version   int                  `json:"version"`
+	Encrypted *encryptedContentsV1 `json:"encrypted"`
+}
+
+// encryptedContentsV1 holds the encrypted contents of a file.
+type encryptedContentsV1 struct {
+	// SecretsProviders is the secrets provider that encrypted the contents.
+	SecretsProviders apitype.SecretsProvidersV1 `json:"secrets_providers"`
+	// Ciphertext is the encrypted contents.
+	Ciphertext string `json:"ciphertext"`
+}
+
+// encryptContents encrypts the given contents with a secrets manager, returning the encrypted envelope to write in
+// their place.
+func encryptContents(plaintext []byte, sm secrets.Manager) ([]byte, error) {
+	if sm == nil {
+		return nil, errors.New("encrypting the state of a stack requires it to have a secrets provider")
+	}
+
+	enc, err := sm.Encrypter()
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := enc.EncryptValue(string(plaintext))
+	if err != nil {
+		return nil, fmt.Errorf("encrypting state: %w", err)
+	}
+
+	provider := apitype.SecretsProvidersV1{Type: sm.Type()}
+	if state := sm.State(); state != nil {
+		if provider.State, err = json.Marshal(state); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(encryptedEnvelope{
+		Version: apitype.DeploymentSchemaVersionCurrent,
+		Encrypted: &encryptedContentsV1{
+			SecretsProviders: provider,
+			Ciphertext:       ciphertext,
+		},
+	})
+}
+
+// parseEncryptedEnvelope returns the encrypted contents of a file, or nil if the file is not encrypted.
+func parseEncryptedEnvelope(m encoding.Marshaler, byts []byte) *encryptedContentsV1 {
+	var envelope encryptedEnvelope
+	if err := m.Unmarshal(byts, &envelope); err != nil {
+		return nil
+	}
+	return envelope.Encrypted
+}
+
+// decryptContents returns the plaintext of a file, decrypting it with the secrets provider recorded in it if it was
+// encrypted. Files that weren't encrypted are returned as they are.
+func (b *localBackend) decryptContents(m encoding.Marshaler, byts []byte) ([]byte, error) {
+	contents := parseEncryptedEnvelope(m, byts)
+	if contents == nil {
+		return byts, nil
+	}
+
+	dec, err := b.decrypterFor(contents.SecretsProviders)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := dec.DecryptValue(contents.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting state: %w", err)
+	}
+	return []byte(plaintext), nil
+}
+
+// decrypterFor returns a decrypter for the given secrets provider. Decrypters are cached, so that e.g. a passphrase
+// is only requested once even when decrypting many files.
+func (b *localBackend) decrypterFor(provider apitype.SecretsProvidersV1) (config.Decrypter, error) {
+	key := provider.Type + ":" + string(provider.State)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if dec, has := b.decrypters[key]; has {
+		return dec, nil
+	}
+
+	sm, err := stack.DefaultSecretsProvider.OfType(provider.Type, provider.State)
+	if err != nil {
+		return nil, err
+	}
+	dec, err := sm.Decrypter()
+	if err != nil {
+		return nil, err
+	}
+	b.decrypters[key] = dec
+	return dec, nil
+}
+
+// unmarshalCheckpoint unmarshals a checkpoint read from the bucket, decrypting it first if necessary.
+func (b *localBackend) unmarshalCheckpoint(byts []byte) (*apitype.CheckpointV3, error) {
+	m := encoding.JSON
+	if encoding.IsCompressed(byts) {
+		m = encoding.Gzip(m)
+	}
+
+	if contents := parseEncryptedEnvelope(m, byts); contents != nil {
+		plaintext, err := b.decryptContents(m, byts)
+		if err != nil {
+			return nil, err
+		}
+		return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(encoding.JSON, plaintext)
+	}
+	return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(m, byts)
+}
+
+// encryptCheckpoint encrypts a serialized checkpoint with the secrets manager used to serialize it, returning the
+// encrypted envelope marshaled with the given marshaler. An error is returned if there is no secrets manager.
+func (b *localBackend) encryptCheckpoint(m encoding.Marshaler, chk *apitype.VersionedCheckpoint,
+	snap *deploy.Snapshot, sm secrets.Manager) ([]byte, error) {
+
+	if sm == nil && snap != nil {
+		sm = snap.SecretsManager
+	}
+	plaintext, err := encoding.JSON.Marshal(chk)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := encryptContents(plaintext, sm)
+	if err != nil {
+		return nil, err
+	}
+	if m == encoding.JSON {
+		return envelope, nil
+	}
+	return m.Marshal(json.RawMessage(envelope))
+}
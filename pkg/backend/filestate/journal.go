@@ -0,0 +1,209 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// PulumiFilestateJournalEnvVar is an env var that, when truthy, makes the filestate backend persist updates
+// incrementally: each step is appended to a journal rather than rewriting the entire checkpoint, which is only saved
+// periodically. This greatly reduces the amount of data written when updating large stacks.
+const PulumiFilestateJournalEnvVar = "PULUMI_SELF_MANAGED_STATE_JOURNAL"
+
+// PulumiFilestateJournalCompactionEnvVar is an env var that overrides the number of journal entries after which the
+// full checkpoint is saved.
+const PulumiFilestateJournalCompactionEnvVar = "PULUMI_SELF_MANAGED_STATE_JOURNAL_COMPACTION_INTERVAL"
+
+// defaultJournalCompactionInterval is the default number of journal entries after which the full checkpoint is saved.
+const defaultJournalCompactionInterval = 1000
+
+// journalsDir is the directory, next to the stacks directory, that holds the journal of the update in progress for
+// each stack. A journal only exists while an update is running, or if an update was interrupted.
+const journalsDir = "journals"
+
+// journalBaseFile is the name of the file holding the checkpoint a journal is relative to.
+const journalBaseFile = "base.json"
+
+func (b *localBackend) journalDirectory(ref *localBackendReference) string {
+	return filepath.Join(b.StateDir(), journalsDir, ref.relPath())
+}
+
+func journalEntryFile(sequence int64) string {
+	return fmt.Sprintf("entry-%020d.json", sequence)
+}
+
+// journalCompactionInterval returns the configured journal compaction interval.
+func journalCompactionInterval() int {
+	if v := os.Getenv(PulumiFilestateJournalCompactionEnvVar); v != "" {
+		if interval, err := strconv.Atoi(v); err == nil && interval > 0 {
+			return interval
+		}
+		logging.V(5).Infof("ignoring invalid journal compaction interval %q", v)
+	}
+	return defaultJournalCompactionInterval
+}
+
+// localJournalPersister is a snapshot persister that additionally supports persisting snapshots incrementally
+// using a journal stored next to the stack's checkpoint.
+type localJournalPersister struct {
+	*localSnapshotPersister
+	interval int
+}
+
+var _ backend.JournalPersister = (*localJournalPersister)(nil)
+
+func (jp *localJournalPersister) BeginJournal(base *deploy.Snapshot) error {
+	ctx, b := context.TODO(), jp.backend
+	if err := b.checkLease(ctx, jp.ref); err != nil {
+		return err
+	}
+	dir := b.journalDirectory(jp.ref)
+	if err := removeAllByPrefix(b.bucket, dir); err != nil {
+		return err
+	}
+
+	// Always record the secrets manager with the base checkpoint, even for new stacks, so that the secrets in the
+	// journal's entries can be decrypted during recovery.
+	if base == nil {
+		base = deploy.NewSnapshot(deploy.Manifest{}, jp.sm, nil, nil)
+	}
+
+	m := encoding.JSON
+	if b.gzip {
+		m = encoding.Gzip(m)
+	}
+	chk, err := stack.SerializeCheckpoint(jp.ref.Name(), base, jp.sm, false /* showSecrets */)
+	if err != nil {
+		return fmt.Errorf("serializing journal base: %w", err)
+	}
+	byts, err := m.Marshal(chk)
+	if err != nil {
+		return err
+	}
+	if b.encrypt {
+		if byts, err = b.encryptCheckpoint(m, chk, base, jp.sm); err != nil {
+			return err
+		}
+	}
+	return b.bucket.WriteAll(ctx, path.Join(dir, journalBaseFile), byts, nil)
+}
+
+func (jp *localJournalPersister) AppendJournalEntry(entry apitype.JournalEntryV1) error {
+	if err := jp.backend.checkLease(context.TODO(), jp.ref); err != nil {
+		return err
+	}
+	byts, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if jp.backend.encrypt {
+		if byts, err = encryptContents(byts, jp.sm); err != nil {
+			return err
+		}
+	}
+	file := path.Join(jp.backend.journalDirectory(jp.ref), journalEntryFile(entry.Sequence))
+	return jp.backend.bucket.WriteAll(context.TODO(), file, byts, nil)
+}
+
+func (jp *localJournalPersister) EndJournal() error {
+	if err := jp.backend.checkLease(context.TODO(), jp.ref); err != nil {
+		return err
+	}
+	return removeAllByPrefix(jp.backend.bucket, jp.backend.journalDirectory(jp.ref))
+}
+
+func (jp *localJournalPersister) JournalCompactionInterval() int {
+	return jp.interval
+}
+
+// recoverJournal checks for the journal of an interrupted update to the given stack and, if there is one, replays it
+// and saves the result as the stack's checkpoint. This must only be called while holding the stack's lock.
+func (b *localBackend) recoverJournal(ctx context.Context, ref *localBackendReference) error {
+	dir := b.journalDirectory(ref)
+	files, err := listBucket(b.bucket, dir)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil
+		}
+		return fmt.Errorf("listing journal: %w", err)
+	}
+
+	var base *apitype.CheckpointV3
+	var entries []apitype.JournalEntryV1
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		name := objectName(file)
+		byts, err := b.bucket.ReadAll(ctx, file.Key)
+		if err != nil {
+			return fmt.Errorf("reading journal file %s: %w", file.Key, err)
+		}
+
+		switch {
+		case name == journalBaseFile:
+			if base, err = b.unmarshalCheckpoint(byts); err != nil {
+				return fmt.Errorf("reading journal base: %w", err)
+			}
+		case strings.HasPrefix(name, "entry-"):
+			if byts, err = b.decryptContents(encoding.JSON, byts); err != nil {
+				return fmt.Errorf("reading journal file %s: %w", file.Key, err)
+			}
+			var entry apitype.JournalEntryV1
+			if err := json.Unmarshal(byts, &entry); err != nil {
+				return fmt.Errorf("reading journal file %s: %w", file.Key, err)
+			}
+			entries = append(entries, entry)
+		}
+	}
+	if base == nil {
+		// The journal was removed while we were listing it, or never got as far as recording its base.
+		return removeAllByPrefix(b.bucket, dir)
+	}
+
+	b.d.Warningf(diag.Message("", "recovering %d step(s) from an interrupted update to stack '%s'"),
+		len(entries), ref)
+
+	baseSnap, err := stack.DeserializeCheckpoint(base)
+	if err != nil {
+		return fmt.Errorf("deserializing journal base: %w", err)
+	}
+	snap, err := backend.ReplayJournal(baseSnap, entries)
+	if err != nil {
+		return fmt.Errorf("recovering stack '%s' from its journal (found in %s): %w", ref, dir, err)
+	}
+	if _, err := b.saveStack(ref, snap, snap.SecretsManager); err != nil {
+		return err
+	}
+	return removeAllByPrefix(b.bucket, dir)
+}
@@ -1,4 +1,4 @@
-// Copyright 2016-2018, Pulumi Corporation.
+// Copyright 2016-2022, Pulumi Corporation.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -17,29 +17,44 @@ package filestate
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 	"os/user"
 	"path"
+	"sync/atomic"
 	"time"
 
+	"gocloud.dev/gcerrors"
+
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/util/fsutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
+// PulumiFilestateLockTTLEnvVar is an env var that overrides how long a lock remains valid without being renewed by
+// the process holding it. Locks are renewed periodically while held, so an expired lock was left behind by a process
+// that died without releasing it, and is taken over by the next process that needs it. Set it to 0 to never expire
+// locks, in which case they are not renewed either, and a lock left behind by a process that died must be removed with
+// `pulumi cancel`.
+const PulumiFilestateLockTTLEnvVar = "PULUMI_SELF_MANAGED_STATE_LOCK_TTL"
+
+// defaultLockTTL is the default time a lock remains valid without being renewed.
+const defaultLockTTL = 5 * time.Minute
+
 type lockContent struct {
 	Pid       int       `json:"pid"`
 	Username  string    `json:"username"`
 	Hostname  string    `json:"hostname"`
 	Timestamp time.Time `json:"timestamp"`
+	// Expiry is the time after which the lock is considered stale unless it has been renewed. Locks written by older
+	// versions of the CLI, or when expiry is disabled, have no expiry and never go stale.
+	Expiry time.Time `json:"expiry,omitempty"`
 }
 
-func newLockContent() (*lockContent, error) {
+func newLockContent(ttl time.Duration) (*lockContent, error) {
 	u, err := user.Current()
 	if err != nil {
 		return nil, err
@@ -48,17 +63,46 @@ func newLockContent() (*lockContent, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &lockContent{
+	now := time.Now()
+	content := &lockContent{
 		Pid:       os.Getpid(),
 		Username:  u.Username,
 		Hostname:  hostname,
-		Timestamp: time.Now(),
-	}, nil
+		Timestamp: now,
+	}
+	if ttl > 0 {
+		content.Expiry = now.Add(ttl)
+	}
+	return content, nil
 }
 
-// checkForLock looks for any existing locks for this stack, and returns a helpful diagnostic if there is one.
+// lockTTL returns the configured lock TTL.
+func lockTTL() time.Duration {
+	if v := os.Getenv(PulumiFilestateLockTTLEnvVar); v != "" {
+		if ttl, err := time.ParseDuration(v); err == nil && ttl >= 0 {
+			return ttl
+		}
+		logging.V(5).Infof("ignoring invalid lock TTL %q", v)
+	}
+	return defaultLockTTL
+}
+
+// lockLease tracks a lock held by this backend until it is released, renewing it if locks expire.
+type lockLease struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	lost   int32 // set to 1 if the lock was removed by someone else, e.g. by `pulumi cancel`.
+}
+
+// checkForLock looks for any existing locks for this stack, and returns a helpful diagnostic if there is one. Locks
+// that have expired are removed.
 func (b *localBackend) checkForLock(ctx context.Context, stackRef backend.StackReference) error {
-	allFiles, err := listBucket(b.bucket, stackLockDir(stackRef.Name()))
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return err
+	}
+
+	allFiles, err := listBucket(b.bucket, stackLockDir(ref))
 	if err != nil {
 		return err
 	}
@@ -68,47 +112,66 @@ func (b *localBackend) checkForLock(ctx context.Context, stackRef backend.StackR
 		if file.IsDir {
 			continue
 		}
-		if file.Key != b.lockPath(stackRef.Name()) {
+		if file.Key != b.lockPath(ref) {
 			lockKeys = append(lockKeys, file.Key)
 		}
 	}
 
-	if len(lockKeys) > 0 {
-		errorString := fmt.Sprintf("the stack is currently locked by %v lock(s). Either wait for the other "+
-			"process(es) to end or manually delete the lock file(s).", len(lockKeys))
-
-		for _, lock := range lockKeys {
-			content, err := b.bucket.ReadAll(ctx, lock)
-			if err != nil {
-				return err
+	var errorString string
+	var count int
+	for _, lock := range lockKeys {
+		content, err := b.bucket.ReadAll(ctx, lock)
+		if err != nil {
+			// The lock was released while we were looking at it.
+			if gcerrors.Code(err) == gcerrors.NotFound {
+				continue
 			}
-			l := &lockContent{}
-			err = json.Unmarshal(content, &l)
-			if err != nil {
+			return err
+		}
+		l := &lockContent{}
+		err = json.Unmarshal(content, &l)
+		if err != nil {
+			return err
+		}
+
+		description := fmt.Sprintf("%v: created by %v@%v (pid %v) at %v",
+			b.url+"/"+lock,
+			l.Username,
+			l.Hostname,
+			l.Pid,
+			l.Timestamp.Format(time.RFC3339),
+		)
+
+		// Take over locks that the process holding them has stopped renewing.
+		if !l.Expiry.IsZero() && time.Now().After(l.Expiry) {
+			b.d.Warningf(diag.Message("", "removing expired lock %v"), description)
+			if err := b.bucket.Delete(ctx, lock); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
 				return err
 			}
-
-			errorString += fmt.Sprintf("\n  %v: created by %v@%v (pid %v) at %v",
-				b.url+"/"+lock,
-				l.Username,
-				l.Hostname,
-				l.Pid,
-				l.Timestamp.Format(time.RFC3339),
-			)
+			continue
 		}
 
-		return errors.New(errorString)
+		count++
+		errorString += "\n  " + description
+	}
+
+	if count > 0 {
+		return fmt.Errorf("the stack is currently locked by %v lock(s). Either wait for the other "+
+			"process(es) to end or manually delete the lock file(s).%s", count, errorString)
 	}
 	return nil
 }
 
 func (b *localBackend) Lock(ctx context.Context, stackRef backend.StackReference) error {
-	//
-	err := b.checkForLock(ctx, stackRef)
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return err
+	}
+	err = b.checkForLock(ctx, stackRef)
 	if err != nil {
 		return err
 	}
-	lockContent, err := newLockContent()
+	lockContent, err := newLockContent(b.lockTTL)
 	if err != nil {
 		return err
 	}
@@ -116,38 +179,181 @@ func (b *localBackend) Lock(ctx context.Context, stackRef backend.StackReference
 	if err != nil {
 		return err
 	}
-	err = b.bucket.WriteAll(ctx, b.lockPath(stackRef.Name()), content, nil)
+	err = b.bucket.WriteAll(ctx, b.lockPath(ref), content, nil)
 	if err != nil {
 		return err
 	}
 	err = b.checkForLock(ctx, stackRef)
 	if err != nil {
-		b.Unlock(ctx, stackRef)
+		contract.IgnoreError(b.Unlock(ctx, stackRef))
+		return err
+	}
+	b.startLease(ref)
+
+	// Now that we hold the lock, recover the stack's state from the journal of any interrupted update.
+	if err = b.recoverJournal(ctx, ref); err != nil {
+		contract.IgnoreError(b.Unlock(ctx, stackRef))
+		return err
+	}
+	return nil
+}
+
+func (b *localBackend) Unlock(ctx context.Context, stackRef backend.StackReference) error {
+	ref, err := b.getReference(stackRef)
+	if err != nil {
 		return err
 	}
+
+	lost := b.stopLease(ref)
+
+	err = b.bucket.Delete(ctx, b.lockPath(ref))
+	if err != nil && !(lost && gcerrors.Code(err) == gcerrors.NotFound) {
+		return fmt.Errorf("there was a problem deleting the lock at %v, manual clean up may be required: %w",
+			path.Join(b.url, b.lockPath(ref)), err)
+	}
 	return nil
 }
 
-func (b *localBackend) Unlock(ctx context.Context, stackRef backend.StackReference) {
-	err := b.bucket.Delete(ctx, b.lockPath(stackRef.Name()))
+// unlock releases the lock on a stack once an operation on it has finished. If the lock cannot be released, the error
+// is returned through err unless the operation already failed.
+func (b *localBackend) unlock(ctx context.Context, stackRef backend.StackReference, err *error) {
+	if unlockErr := b.Unlock(ctx, stackRef); unlockErr != nil && *err == nil {
+		*err = unlockErr
+	}
+}
+
+// unlockResult is unlock for operations that return a result rather than an error.
+func (b *localBackend) unlockResult(ctx context.Context, stackRef backend.StackReference, res *result.Result) {
+	var err error
+	b.unlock(ctx, stackRef, &err)
+	if err != nil && *res == nil {
+		*res = result.FromError(err)
+	}
+}
+
+// startLease records that this backend holds the lock on a stack, and starts renewing it if locks expire. The lock is
+// renewed a few times per TTL, so that it doesn't expire while a long running operation is in progress.
+func (b *localBackend) startLease(ref *localBackendReference) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lease := &lockLease{cancel: cancel, done: make(chan struct{})}
+	key := b.lockPath(ref)
+
+	b.leaseMutex.Lock()
+	defer b.leaseMutex.Unlock()
+	if b.leases[key] != nil {
+		// The lock is already being renewed.
+		cancel()
+		return
+	}
+	b.leases[key] = lease
+
+	if b.lockTTL <= 0 {
+		// Locks don't expire, so there is nothing to renew.
+		close(lease.done)
+		return
+	}
+
+	go func() {
+		defer close(lease.done)
+		ticker := time.NewTicker(b.lockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !b.renewLock(ctx, ref, lease) {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// renewLock renews the lock on a stack held by this backend, returning false if the lock has been lost.
+//
+// If the lock has been removed, the update has been canceled: we stop renewing it, so that we don't recreate it, and
+// refuse to save any further changes to the stack. Note that a lock removed between checking for it and renewing it
+// will be recreated; canceling the update again will remove it for good.
+func (b *localBackend) renewLock(ctx context.Context, ref *localBackendReference, lease *lockLease) bool {
+	key := b.lockPath(ref)
+	content, err := newLockContent(b.lockTTL)
 	if err != nil {
-		b.d.Errorf(
-			diag.Message("", "there was a problem deleting the lock at %v, manual clean up may be required: %v"),
-			path.Join(b.url, b.lockPath(stackRef.Name())),
-			err)
+		logging.V(5).Infof("error renewing lock %s: %v", key, err)
+		return true
+	}
+	byts, err := json.Marshal(content)
+	contract.AssertNoError(err)
+
+	exists, err := b.bucket.Exists(ctx, key)
+	if err != nil {
+		logging.V(5).Infof("error checking lock %s: %v", key, err)
+		return true
+	}
+	if !exists {
+		atomic.StoreInt32(&lease.lost, 1)
+		b.d.Errorf(diag.Message("", "the lock on stack '%s' was removed, perhaps by `pulumi cancel`; "+
+			"no further changes to its state will be saved"), ref)
+		return false
+	}
+
+	if err = b.bucket.WriteAll(ctx, key, byts, nil); err != nil {
+		logging.V(5).Infof("error renewing lock %s: %v", key, err)
+	}
+	return true
+}
+
+// stopLease stops renewing the lock on a stack, returning true if the lock was lost while it was held.
+func (b *localBackend) stopLease(ref *localBackendReference) bool {
+	key := b.lockPath(ref)
+	b.leaseMutex.Lock()
+	lease := b.leases[key]
+	delete(b.leases, key)
+	b.leaseMutex.Unlock()
+
+	if lease == nil {
+		return false
+	}
+	lease.cancel()
+	<-lease.done
+	return atomic.LoadInt32(&lease.lost) == 1
+}
+
+// checkLease returns an error if the lock on a stack held by this backend has been lost, in which case another
+// process may now be modifying the stack. The lock is checked in the bucket rather than relying on the last renewal,
+// so that a lock removed since then is noticed before the stack is written.
+func (b *localBackend) checkLease(ctx context.Context, ref *localBackendReference) error {
+	key := b.lockPath(ref)
+	b.leaseMutex.Lock()
+	lease := b.leases[key]
+	b.leaseMutex.Unlock()
+
+	if lease == nil {
+		return nil
+	}
+	if atomic.LoadInt32(&lease.lost) == 0 {
+		exists, err := b.bucket.Exists(ctx, key)
+		if err != nil {
+			return fmt.Errorf("checking the lock on stack '%s': %w", ref, err)
+		}
+		if exists {
+			return nil
+		}
+		atomic.StoreInt32(&lease.lost, 1)
 	}
+	return fmt.Errorf("the lock on stack '%s' was removed while it was being updated", ref)
 }
 
 func lockDir() string {
 	return path.Join(workspace.BookkeepingDir, workspace.LockDir)
 }
 
-func stackLockDir(stack tokens.Name) string {
-	contract.Require(stack != "", "stack")
-	return path.Join(lockDir(), fsutil.NamePath(stack))
+func stackLockDir(ref *localBackendReference) string {
+	contract.Require(ref != nil, "ref")
+	return path.Join(lockDir(), ref.relPath())
 }
 
-func (b *localBackend) lockPath(stack tokens.Name) string {
-	contract.Require(stack != "", "stack")
-	return path.Join(stackLockDir(stack), b.lockID+".json")
+func (b *localBackend) lockPath(ref *localBackendReference) string {
+	contract.Require(ref != nil, "ref")
+	return path.Join(stackLockDir(ref), b.lockID+".json")
 }
@@ -0,0 +1,312 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// PulumiFilestateLegacyLayoutEnvVar is an env var that, when truthy, keeps buckets that use the legacy layout (in
+// which stacks are not scoped by project) from being migrated to the project-scoped layout.
+const PulumiFilestateLegacyLayoutEnvVar = "PULUMI_SELF_MANAGED_STATE_LEGACY_LAYOUT"
+
+const (
+	// legacyLayoutVersion is the layout version of buckets in which stacks are not scoped by project.
+	legacyLayoutVersion = 0
+	// projectLayoutVersion is the layout version of buckets in which stacks are scoped by project.
+	projectLayoutVersion = 1
+)
+
+// pulumiMeta is the metadata stored in `.pulumi/meta.yaml` describing how state is laid out in a bucket.
+type pulumiMeta struct {
+	// Version is the layout version of the bucket. A missing metadata file implies the legacy layout.
+	Version int `json:"version" yaml:"version"`
+}
+
+func metaPath() string {
+	return path.Join(workspace.BookkeepingDir, "meta.yaml")
+}
+
+// readMeta reads the metadata for the bucket, returning the legacy layout version if there is none.
+func (b *localBackend) readMeta(ctx context.Context) (*pulumiMeta, error) {
+	byts, err := b.bucket.ReadAll(ctx, metaPath())
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return &pulumiMeta{Version: legacyLayoutVersion}, nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", metaPath(), err)
+	}
+
+	var meta pulumiMeta
+	if err := encoding.YAML.Unmarshal(byts, &meta); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", metaPath(), err)
+	}
+	if meta.Version > projectLayoutVersion {
+		return nil, fmt.Errorf("state layout version %d is not supported by this version of the CLI; "+
+			"please upgrade", meta.Version)
+	}
+	return &meta, nil
+}
+
+func (b *localBackend) writeMeta(ctx context.Context, meta *pulumiMeta) error {
+	byts, err := encoding.YAML.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return b.bucket.WriteAll(ctx, metaPath(), byts, nil)
+}
+
+// initReferenceStore selects the reference store matching the layout of the bucket. Unless the legacy layout has been
+// requested, buckets that use it are migrated to the project-scoped layout by Upgrade. If the migration fails, e.g.
+// because another process holds the lock on one of the stacks, the bucket keeps using the legacy layout and the
+// migration is resumed the next time the bucket is opened.
+func (b *localBackend) initReferenceStore(ctx context.Context, legacyLayout bool) error {
+	meta, err := b.readMeta(ctx)
+	if err != nil {
+		return err
+	}
+
+	if meta.Version == projectLayoutVersion {
+		b.store = &projectReferenceStore{b: b}
+		return nil
+	}
+
+	b.store = &legacyReferenceStore{b: b}
+	if legacyLayout {
+		return nil
+	}
+
+	refs, err := b.store.ListReferences(nil)
+	if err != nil {
+		return err
+	}
+	if len(refs) > 0 {
+		if err := b.Upgrade(ctx); err != nil {
+			b.d.Warningf(diag.Message("", "could not migrate the stacks in this backend to the project-scoped "+
+				"state layout, so they will stay in the legacy layout until the migration succeeds: %v"), err)
+		}
+		return nil
+	}
+
+	if err := b.writeMeta(ctx, &pulumiMeta{Version: projectLayoutVersion}); err != nil {
+		return err
+	}
+	b.store = &projectReferenceStore{b: b}
+	return nil
+}
+
+// Upgrade migrates a bucket that uses the legacy layout to the project-scoped layout by moving every stack into the
+// directory of the project it belongs to, and then records the new layout version. Every stack is locked for the
+// duration of the migration so that no other process can update a stack while it is being moved.
+//
+// The migration of each stack is idempotent: its history and backups are copied before its checkpoint, and the legacy
+// checkpoint is deleted last. An interrupted migration is therefore resumed by the next upgrade, which only sees the
+// stacks that have not finished moving.
+//
+// Stacks whose project can't be determined, because they have never been updated and weren't tagged with a project
+// when they were created, are left where they are with a warning; they hold no resources, and can be created again in
+// their project.
+func (b *localBackend) Upgrade(ctx context.Context) (err error) {
+	meta, err := b.readMeta(ctx)
+	if err != nil {
+		return err
+	}
+	if meta.Version == projectLayoutVersion {
+		return nil
+	}
+
+	legacy := &legacyReferenceStore{b: b}
+	refs, err := legacy.ListReferences(nil)
+	if err != nil {
+		return err
+	}
+
+	var locked []*localBackendReference
+	defer func() {
+		for _, ref := range locked {
+			if unlockErr := b.Unlock(ctx, ref); err == nil {
+				err = unlockErr
+			}
+		}
+	}()
+	for _, ref := range refs {
+		if err := b.Lock(ctx, ref); err != nil {
+			return fmt.Errorf("locking stack '%s': %w", ref.name, err)
+		}
+		locked = append(locked, ref)
+	}
+
+	// Stacks created while we were taking the locks would be left behind, so make sure that there aren't any.
+	current, err := legacy.ListReferences(nil)
+	if err != nil {
+		return err
+	}
+	for _, ref := range current {
+		if !containsReference(locked, ref) {
+			return fmt.Errorf("stack '%s' was created during the upgrade; please try again", ref.name)
+		}
+	}
+
+	// Resolve the destination of every stack before moving any of them, so that a stack that can't be read doesn't
+	// leave the bucket half migrated.
+	targets := make([]*localBackendReference, len(refs))
+	var unplaced []string
+	for i, ref := range refs {
+		project, err := b.legacyStackProject(ctx, ref)
+		if err != nil {
+			return err
+		}
+		if project == "" {
+			unplaced = append(unplaced, b.stackPath(ref))
+			continue
+		}
+		targets[i] = &localBackendReference{name: ref.name, project: project, b: b}
+	}
+
+	for i, ref := range refs {
+		if targets[i] == nil {
+			continue
+		}
+		if err := b.migrateStack(ctx, ref, targets[i]); err != nil {
+			return fmt.Errorf("migrating stack '%s': %w", ref.name, err)
+		}
+		logging.V(5).Infof("migrated stack %s to %s", ref.name, targets[i].FullyQualifiedName())
+	}
+	if len(unplaced) > 0 {
+		b.d.Warningf(diag.Message("", "the stacks stored in %s have never been updated and don't record their "+
+			"project, so they were not migrated to the project-scoped state layout; they can be created again with "+
+			"`pulumi stack init` in their projects"), strings.Join(unplaced, ", "))
+	}
+
+	if err := b.writeMeta(ctx, &pulumiMeta{Version: projectLayoutVersion}); err != nil {
+		return err
+	}
+	b.store = &projectReferenceStore{b: b}
+	return nil
+}
+
+// containsReference returns true if the given references include one to the same stack as ref.
+func containsReference(refs []*localBackendReference, ref *localBackendReference) bool {
+	for _, r := range refs {
+		if r.name == ref.name && r.project == ref.project {
+			return true
+		}
+	}
+	return false
+}
+
+// legacyStackProject determines the project of a stack stored using the legacy layout from the URNs of the stack's
+// resources or, if it has none, from its project tag. The empty name is returned if neither records the project.
+func (b *localBackend) legacyStackProject(ctx context.Context, ref *localBackendReference) (tokens.Name, error) {
+	chk, err := b.getCheckpoint(ref)
+	if err != nil {
+		return "", fmt.Errorf("reading checkpoint for stack '%s': %w", ref.name, err)
+	}
+
+	var project tokens.PackageName
+	if chk.Latest != nil {
+		for _, res := range chk.Latest.Resources {
+			urn := resource.URN(res.URN)
+			if !urn.IsValid() {
+				continue
+			}
+			project = urn.Project()
+			if res.Type == resource.RootStackType {
+				break
+			}
+		}
+	}
+	if project != "" {
+		return tokens.Name(project), nil
+	}
+
+	tags, err := b.getStackTags(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	return tokens.Name(tags[apitype.ProjectNameTag]), nil
+}
+
+// migrateStack copies everything stored for a stack to its project-scoped location and then removes the original.
+func (b *localBackend) migrateStack(ctx context.Context, from, to *localBackendReference) error {
+	dirs := []func(*localBackendReference) string{b.historyDirectory, b.backupDirectory, b.journalDirectory}
+	for _, dir := range dirs {
+		if err := copyAllByPrefix(ctx, b.bucket, dir(from), dir(to)); err != nil {
+			return err
+		}
+	}
+
+	// Keep the extension of the existing checkpoint, which may be compressed.
+	oldPath := b.stackPath(from)
+	ext := strings.TrimPrefix(oldPath, path.Join(stacksDir(), from.relPath()))
+	newPath := path.Join(stacksDir(), to.relPath()) + ext
+	if err := b.bucket.Copy(ctx, newPath, oldPath, nil); err != nil {
+		return fmt.Errorf("copying checkpoint: %w", err)
+	}
+	if bakExists, err := b.bucket.Exists(ctx, oldPath+".bak"); err == nil && bakExists {
+		if err := b.bucket.Copy(ctx, newPath+".bak", oldPath+".bak", nil); err != nil {
+			return fmt.Errorf("copying checkpoint backup: %w", err)
+		}
+		contract.IgnoreError(b.bucket.Delete(ctx, oldPath+".bak"))
+	}
+
+	for _, dir := range dirs {
+		if err := removeAllByPrefix(b.bucket, dir(from)); err != nil {
+			return err
+		}
+	}
+
+	if err := b.moveStackTags(ctx, from, to); err != nil {
+		return err
+	}
+
+	// Deleting the legacy checkpoint is the last step, since its presence is what marks the stack as unmigrated.
+	return b.bucket.Delete(ctx, oldPath)
+}
+
+// copyAllByPrefix copies all objects in a directory to another directory.
+func copyAllByPrefix(ctx context.Context, bucket Bucket, from, to string) error {
+	files, err := listBucket(bucket, from)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil
+		}
+		return fmt.Errorf("unable to list bucket objects for copying: %w", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		if err := bucket.Copy(ctx, path.Join(to, objectName(file)), file.Key, nil); err != nil {
+			return fmt.Errorf("copying %s: %w", file.Key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// HistoryRetentionPolicy describes which of the history entries and checkpoint backups of a stack to keep when
+// pruning its history. An object is pruned if it violates any of the limits that are set.
+type HistoryRetentionPolicy struct {
+	// KeepCount is the number of most recent updates (and, separately, backups) to keep. Zero means no limit.
+	KeepCount int
+	// MaxAge is the age beyond which updates and backups are pruned. Zero means no limit.
+	MaxAge time.Duration
+}
+
+// PrunedObject describes an object that was (or, for a dry run, would be) deleted when pruning the history of a stack.
+type PrunedObject struct {
+	Key  string    // the key of the object in the bucket.
+	Size int64     // the size of the object, in bytes.
+	Time time.Time // the time the object was written.
+}
+
+// timestampedGroup is a set of objects that were written together at the same time, e.g. an update's history
+// record and the copy of the checkpoint written with it.
+type timestampedGroup struct {
+	time    time.Time
+	objects []PrunedObject
+}
+
+// PruneHistory deletes the history entries, checkpoint backups and retained checkpoints of a stack that fall outside
+// of the given retention policy, and returns the objects that were deleted. If dryRun is true, nothing is deleted and
+// the objects that would have been deleted are returned. The stack's current checkpoint and its `.bak` copy are
+// never deleted.
+func (b *localBackend) PruneHistory(ctx context.Context, stackRef backend.StackReference,
+	policy HistoryRetentionPolicy, dryRun bool) (_ []PrunedObject, err error) {
+
+	if policy.KeepCount < 0 || policy.MaxAge < 0 {
+		return nil, errors.New("retention limits must not be negative")
+	}
+	if policy.KeepCount == 0 && policy.MaxAge == 0 {
+		return nil, errors.New("a retention policy requires a count or an age limit")
+	}
+
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		if err := b.Lock(ctx, stackRef); err != nil {
+			return nil, err
+		}
+		defer b.unlock(ctx, stackRef, &err)
+
+		// Records written by older versions of the CLI are numbered after the records before them, so they must be
+		// given versions before any of those are pruned.
+		if _, err := b.upgradeHistory(ref); err != nil {
+			return nil, err
+		}
+	}
+
+	name := string(ref.Name())
+
+	// Each kind of object is retained independently, so that e.g. keeping 10 updates keeps 10 history records as
+	// well as 10 backups.
+	sources := []struct {
+		dir   string
+		parse func(file string) (string, int64, bool)
+	}{
+		{b.historyDirectory(ref), func(file string) (string, int64, bool) {
+			return parseHistoryFile(name, file)
+		}},
+		{b.backupDirectory(ref), func(file string) (string, int64, bool) {
+			return parseBackupFile(name, file)
+		}},
+		{filepath.Dir(b.stackPath(ref)), func(file string) (string, int64, bool) {
+			return parseRetainedCheckpointFile(name, file)
+		}},
+	}
+
+	now := time.Now()
+	var pruned []PrunedObject
+	for _, source := range sources {
+		groups, err := b.listTimestampedGroups(source.dir, source.parse)
+		if err != nil {
+			return nil, err
+		}
+		for i, group := range groups {
+			if (policy.KeepCount > 0 && i >= policy.KeepCount) ||
+				(policy.MaxAge > 0 && now.Sub(group.time) > policy.MaxAge) {
+				pruned = append(pruned, group.objects...)
+			}
+		}
+	}
+
+	if dryRun {
+		return pruned, nil
+	}
+	for _, obj := range pruned {
+		if err := b.bucket.Delete(ctx, obj.Key); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+			return nil, fmt.Errorf("deleting %s: %w", obj.Key, err)
+		}
+		logging.V(7).Infof("pruned %s from the history of stack %s", obj.Key, ref)
+	}
+	return pruned, nil
+}
+
+// listTimestampedGroups lists the objects in a directory that the parse function recognizes, grouped by the key it
+// returns for them, most recent first.
+func (b *localBackend) listTimestampedGroups(dir string,
+	parse func(file string) (string, int64, bool)) ([]*timestampedGroup, error) {
+
+	files, err := listBucket(b.bucket, dir)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	groups := make(map[string]*timestampedGroup)
+	var result []*timestampedGroup
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		key, ts, ok := parse(objectName(file))
+		if !ok {
+			continue
+		}
+		group, has := groups[key]
+		if !has {
+			group = &timestampedGroup{time: time.Unix(0, ts)}
+			groups[key] = group
+			result = append(result, group)
+		}
+		group.objects = append(group.objects, PrunedObject{Key: file.Key, Size: file.Size, Time: group.time})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].time.After(result[j].time)
+	})
+	return result, nil
+}
+
+// parseTimestamp parses a timestamp written by time.Now().UnixNano().
+func parseTimestamp(s string) (int64, bool) {
+	ts, err := strconv.ParseInt(s, 10, 64)
+	return ts, err == nil && ts > 0
+}
+
+// parseHistoryFile parses the name of a file in a stack's history directory, which has the form
+// `<stack>-<timestamp>.(history|checkpoint).json[.gz]`. The history record and the checkpoint of an update share
+// the `<stack>-<timestamp>` group key.
+func parseHistoryFile(stackName, file string) (string, int64, bool) {
+	base := strings.TrimSuffix(file, encoding.GZIPExt)
+	for _, suffix := range []string{".history.json", ".checkpoint.json"} {
+		if !strings.HasSuffix(base, suffix) {
+			continue
+		}
+		group := strings.TrimSuffix(base, suffix)
+		if !strings.HasPrefix(group, stackName+"-") {
+			return "", 0, false
+		}
+		ts, ok := parseTimestamp(strings.TrimPrefix(group, stackName+"-"))
+		return group, ts, ok
+	}
+	return "", 0, false
+}
+
+// parseBackupFile parses the name of a file in a stack's backup directory, which has the form
+// `<stack>.<timestamp>.json[.gz]`.
+func parseBackupFile(stackName, file string) (string, int64, bool) {
+	base := strings.TrimSuffix(strings.TrimSuffix(file, encoding.GZIPExt), ".json")
+	if base == file || !strings.HasPrefix(base, stackName+".") {
+		return "", 0, false
+	}
+	ts, ok := parseTimestamp(strings.TrimPrefix(base, stackName+"."))
+	return base, ts, ok
+}
+
+// parseRetainedCheckpointFile parses the name of a checkpoint retained in the stacks directory when
+// PULUMI_RETAIN_CHECKPOINTS is set, which has the form `<stack>.json[.gz].<timestamp>`.
+func parseRetainedCheckpointFile(stackName, file string) (string, int64, bool) {
+	for _, prefix := range []string{stackName + ".json.gz.", stackName + ".json."} {
+		if strings.HasPrefix(file, prefix) {
+			ts, ok := parseTimestamp(strings.TrimPrefix(file, prefix))
+			return file, ts, ok
+		}
+	}
+	return "", 0, false
+}
@@ -15,15 +15,19 @@
 package filestate
 
 import (
+	"context"
+	"os"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 )
 
 // localSnapshotManager is a simple SnapshotManager implementation that persists snapshots
 // to disk on the local machine.
 type localSnapshotPersister struct {
-	name    tokens.Name
+	ref     *localBackendReference
 	backend *localBackend
 	sm      secrets.Manager
 }
@@ -33,11 +37,20 @@ func (sp *localSnapshotPersister) SecretsManager() secrets.Manager {
 }
 
 func (sp *localSnapshotPersister) Save(snapshot *deploy.Snapshot) error {
-	_, err := sp.backend.saveStack(sp.name, snapshot, sp.sm)
+	if err := sp.backend.checkLease(context.TODO(), sp.ref); err != nil {
+		return err
+	}
+	_, err := sp.backend.saveStack(sp.ref, snapshot, sp.sm)
 	return err
 
 }
 
-func (b *localBackend) newSnapshotPersister(stackName tokens.Name, sm secrets.Manager) *localSnapshotPersister {
-	return &localSnapshotPersister{name: stackName, backend: b, sm: sm}
+// newSnapshotPersister returns the persister to use for updates to the given stack, which persists updates
+// incrementally using a journal if that has been enabled.
+func (b *localBackend) newSnapshotPersister(ref *localBackendReference, sm secrets.Manager) backend.SnapshotPersister {
+	persister := &localSnapshotPersister{ref: ref, backend: b, sm: sm}
+	if cmdutil.IsTruthy(os.Getenv(PulumiFilestateJournalEnvVar)) {
+		return &localJournalPersister{localSnapshotPersister: persister, interval: journalCompactionInterval()}
+	}
+	return persister
 }
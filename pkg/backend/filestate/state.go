@@ -16,6 +16,7 @@ package filestate
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -39,10 +40,8 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/util/fsutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
@@ -93,11 +92,11 @@ func (b *localBackend) newQuery(ctx context.Context,
 	return &localQuery{root: op.Root, proj: op.Proj}, nil
 }
 
-func (b *localBackend) newUpdate(stackName tokens.Name, op backend.UpdateOperation) (*update, error) {
-	contract.Require(stackName != "", "stackName")
+func (b *localBackend) newUpdate(ref *localBackendReference, op backend.UpdateOperation) (*update, error) {
+	contract.Require(ref != nil, "ref")
 
 	// Construct the deployment target.
-	target, err := b.getTarget(stackName, op.StackConfiguration.Config, op.StackConfiguration.Decrypter)
+	target, err := b.getTarget(ref, op.StackConfiguration.Config, op.StackConfiguration.Decrypter)
 	if err != nil {
 		return nil, err
 	}
@@ -111,27 +110,29 @@ func (b *localBackend) newUpdate(stackName tokens.Name, op backend.UpdateOperati
 	}, nil
 }
 
-func (b *localBackend) getTarget(stackName tokens.Name, cfg config.Map, dec config.Decrypter) (*deploy.Target, error) {
-	snapshot, _, err := b.getStack(stackName)
+func (b *localBackend) getTarget(ref *localBackendReference, cfg config.Map,
+	dec config.Decrypter) (*deploy.Target, error) {
+
+	snapshot, _, err := b.getStack(ref)
 	if err != nil {
 		return nil, err
 	}
 	return &deploy.Target{
-		Name:      stackName,
+		Name:      ref.Name(),
 		Config:    cfg,
 		Decrypter: dec,
 		Snapshot:  snapshot,
 	}, nil
 }
 
-func (b *localBackend) getStack(name tokens.Name) (*deploy.Snapshot, string, error) {
-	if name == "" {
+func (b *localBackend) getStack(ref *localBackendReference) (*deploy.Snapshot, string, error) {
+	if ref == nil || ref.name == "" {
 		return nil, "", errors.New("invalid empty stack name")
 	}
 
-	file := b.stackPath(name)
+	file := b.stackPath(ref)
 
-	chk, err := b.getCheckpoint(name)
+	chk, err := b.getCheckpoint(ref)
 	if err != nil {
 		return nil, file, fmt.Errorf("failed to load checkpoint: %w", err)
 	}
@@ -153,23 +154,36 @@ func (b *localBackend) getStack(name tokens.Name) (*deploy.Snapshot, string, err
 }
 
 // GetCheckpoint loads a checkpoint file for the given stack in this project, from the current project workspace.
-func (b *localBackend) getCheckpoint(stackName tokens.Name) (*apitype.CheckpointV3, error) {
-	chkpath := b.stackPath(stackName)
+func (b *localBackend) getCheckpoint(ref *localBackendReference) (*apitype.CheckpointV3, error) {
+	chkpath := b.stackPath(ref)
 	bytes, err := b.bucket.ReadAll(context.TODO(), chkpath)
 	if err != nil {
 		return nil, err
 	}
+	return b.unmarshalCheckpoint(bytes)
+}
+
+// getCheckpointForSummary loads the checkpoint of a stack in order to summarize it, e.g. when listing stacks.
+// Encrypted checkpoints are not decrypted, to avoid requiring the secrets provider of every stack; nil is returned
+// for them instead.
+func (b *localBackend) getCheckpointForSummary(ref *localBackendReference) (*apitype.CheckpointV3, error) {
+	bytes, err := b.bucket.ReadAll(context.TODO(), b.stackPath(ref))
+	if err != nil {
+		return nil, err
+	}
 	m := encoding.JSON
 	if encoding.IsCompressed(bytes) {
 		m = encoding.Gzip(m)
 	}
-
+	if parseEncryptedEnvelope(m, bytes) != nil {
+		return nil, nil
+	}
 	return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(m, bytes)
 }
 
-func (b *localBackend) saveStack(name tokens.Name, snap *deploy.Snapshot, sm secrets.Manager) (string, error) {
+func (b *localBackend) saveStack(ref *localBackendReference, snap *deploy.Snapshot, sm secrets.Manager) (string, error) {
 	// Make a serializable stack and then use the encoder to encode it.
-	file := b.stackPath(name)
+	file := b.stackPath(ref)
 	m, ext := encoding.Detect(strings.TrimSuffix(file, ".gz"))
 	if m == nil {
 		return "", fmt.Errorf("resource serialization failed; illegal markup extension: '%v'", ext)
@@ -186,7 +200,7 @@ func (b *localBackend) saveStack(name tokens.Name, snap *deploy.Snapshot, sm sec
 		file = strings.TrimSuffix(file, ".gz")
 	}
 
-	chk, err := stack.SerializeCheckpoint(name, snap, sm, false /* showSecrets */)
+	chk, err := stack.SerializeCheckpoint(ref.Name(), snap, sm, false /* showSecrets */)
 	if err != nil {
 		return "", fmt.Errorf("serializaing checkpoint: %w", err)
 	}
@@ -194,6 +208,13 @@ func (b *localBackend) saveStack(name tokens.Name, snap *deploy.Snapshot, sm sec
 	if err != nil {
 		return "", fmt.Errorf("An IO error occurred while marshalling the checkpoint: %w", err)
 	}
+	// The checkpoint of a new stack doesn't hold any state, and the stack doesn't have a secrets provider to encrypt
+	// it with yet, so it is the only checkpoint that is written unencrypted.
+	if b.encrypt && snap != nil {
+		if byts, err = b.encryptCheckpoint(m, chk, snap, sm); err != nil {
+			return "", err
+		}
+	}
 
 	// Back up the existing file if it already exists. Don't delete the original, the following WriteAll will
 	// atomically replace it anyway and various other bits of the system depend on being able to find the
@@ -246,7 +267,7 @@ func (b *localBackend) saveStack(name tokens.Name, snap *deploy.Snapshot, sm sec
 		}
 	}
 
-	logging.V(7).Infof("Saved stack %s checkpoint to: %s (backup=%s)", name, file, bck)
+	logging.V(7).Infof("Saved stack %s checkpoint to: %s (backup=%s)", ref, file, bck)
 
 	// And if we are retaining historical checkpoint information, write it out again
 	if cmdutil.IsTruthy(os.Getenv("PULUMI_RETAIN_CHECKPOINTS")) {
@@ -271,14 +292,18 @@ func (b *localBackend) saveStack(name tokens.Name, snap *deploy.Snapshot, sm sec
 }
 
 // removeStack removes information about a stack from the current workspace.
-func (b *localBackend) removeStack(name tokens.Name) error {
-	contract.Require(name != "", "name")
+func (b *localBackend) removeStack(ref *localBackendReference) error {
+	contract.Require(ref != nil, "ref")
 
 	// Just make a backup of the file and don't write out anything new.
-	file := b.stackPath(name)
+	file := b.stackPath(ref)
 	backupTarget(b.bucket, file, false)
 
-	historyDir := b.historyDirectory(name)
+	if err := b.removeStackTags(context.TODO(), ref); err != nil {
+		return err
+	}
+
+	historyDir := b.historyDirectory(ref)
 	return removeAllByPrefix(b.bucket, historyDir)
 }
 
@@ -304,8 +329,8 @@ func backupTarget(bucket Bucket, file string, keepOriginal bool) string {
 }
 
 // backupStack copies the current Checkpoint file to ~/.pulumi/backups.
-func (b *localBackend) backupStack(name tokens.Name) error {
-	contract.Require(name != "", "name")
+func (b *localBackend) backupStack(ref *localBackendReference) error {
+	contract.Require(ref != nil, "ref")
 
 	// Exit early if backups are disabled.
 	if cmdutil.IsTruthy(os.Getenv(DisableCheckpointBackupsEnvVar)) {
@@ -313,14 +338,14 @@ func (b *localBackend) backupStack(name tokens.Name) error {
 	}
 
 	// Read the current checkpoint file. (Assuming it aleady exists.)
-	stackPath := b.stackPath(name)
+	stackPath := b.stackPath(ref)
 	byts, err := b.bucket.ReadAll(context.TODO(), stackPath)
 	if err != nil {
 		return err
 	}
 
 	// Get the backup directory.
-	backupDir := b.backupDirectory(name)
+	backupDir := b.backupDirectory(ref)
 
 	// Write out the new backup checkpoint file.
 	stackFile := filepath.Base(stackPath)
@@ -337,16 +362,16 @@ func (b *localBackend) backupStack(name tokens.Name) error {
 	return b.bucket.WriteAll(context.TODO(), filepath.Join(backupDir, backupFile), byts, nil)
 }
 
-func (b *localBackend) stackPath(stack tokens.Name) string {
+func (b *localBackend) stackPath(ref *localBackendReference) string {
 	path := filepath.Join(b.StateDir(), workspace.StackDir)
-	if stack == "" {
+	if ref == nil {
 		return path
 	}
 
 	// We can't use listBucket here for as we need to do a partial prefix match on filename, while the
 	// "dir" option to listBucket is always suffixed with "/". Also means we don't need to save any
 	// results in a slice.
-	plainPath := filepath.Join(path, fsutil.NamePath(stack)) + ".json"
+	plainPath := filepath.Join(path, ref.relPath()) + ".json"
 	gzipedPath := plainPath + ".gz"
 
 	bucketIter := b.bucket.List(&blob.ListOptions{
@@ -382,24 +407,21 @@ func (b *localBackend) stackPath(stack tokens.Name) string {
 	return plainPath
 }
 
-func (b *localBackend) historyDirectory(stack tokens.Name) string {
-	contract.Require(stack != "", "stack")
-	return filepath.Join(b.StateDir(), workspace.HistoryDir, fsutil.NamePath(stack))
+func (b *localBackend) historyDirectory(ref *localBackendReference) string {
+	contract.Require(ref != nil, "ref")
+	return filepath.Join(b.StateDir(), workspace.HistoryDir, ref.relPath())
 }
 
-func (b *localBackend) backupDirectory(stack tokens.Name) string {
-	contract.Require(stack != "", "stack")
-	return filepath.Join(b.StateDir(), workspace.BackupDir, fsutil.NamePath(stack))
+func (b *localBackend) backupDirectory(ref *localBackendReference) string {
+	contract.Require(ref != nil, "ref")
+	return filepath.Join(b.StateDir(), workspace.BackupDir, ref.relPath())
 }
 
-// getHistory returns locally stored update history. The first element of the result will be
-// the most recent update record.
-func (b *localBackend) getHistory(name tokens.Name, pageSize int, page int) ([]backend.UpdateInfo, error) {
-	contract.Require(name != "", "name")
+// listHistoryFiles returns the update records in the history of a stack, oldest first.
+func (b *localBackend) listHistoryFiles(ref *localBackendReference) ([]*blob.ListObject, error) {
+	contract.Require(ref != nil, "ref")
 
-	dir := b.historyDirectory(name)
-	// TODO: we could consider optimizing the list operation using `page` and `pageSize`.
-	// Unfortunately, this is mildly invasive given the gocloud List API.
+	dir := b.historyDirectory(ref)
 	allFiles, err := listBucket(b.bucket, dir)
 	if err != nil {
 		// History doesn't exist until a stack has been updated.
@@ -409,69 +431,275 @@ func (b *localBackend) getHistory(name tokens.Name, pageSize int, page int) ([]b
 		return nil, err
 	}
 
-	var historyEntries []*blob.ListObject
-
-	// filter down to just history entries, reversing list to be in most recent order.
-	// listBucket returns the array sorted by file name, but because of how we name files, older updates come before
+	// listBucket returns the array sorted by file name, and because of how we name files, older updates come before
 	// newer ones.
-	for i := len(allFiles) - 1; i >= 0; i-- {
-		file := allFiles[i]
-		filepath := file.Key
-
+	var historyFiles []*blob.ListObject
+	for _, file := range allFiles {
 		// ignore checkpoints
-		if !strings.HasSuffix(filepath, ".history.json") &&
-			!strings.HasSuffix(filepath, ".history.json.gz") {
+		if !strings.HasSuffix(file.Key, ".history.json") &&
+			!strings.HasSuffix(file.Key, ".history.json.gz") {
 			continue
 		}
+		historyFiles = append(historyFiles, file)
+	}
+	return historyFiles, nil
+}
+
+// readHistoryFile reads an update record from the history of a stack. Records written by older versions of the CLI
+// don't have a version, in which case the version is zero.
+func (b *localBackend) readHistoryFile(key string) (backend.UpdateInfo, error) {
+	update, _, err := b.readHistoryRecord(key)
+	return update, err
+}
+
+// readHistoryRecord reads an update record from the history of a stack, decrypting it if necessary. If the record was
+// encrypted, the secrets provider that encrypted it is returned as well.
+func (b *localBackend) readHistoryRecord(key string) (backend.UpdateInfo, *apitype.SecretsProvidersV1, error) {
+	var update backend.UpdateInfo
+	byts, err := b.bucket.ReadAll(context.TODO(), key)
+	if err != nil {
+		return update, nil, fmt.Errorf("reading history file %s: %w", key, err)
+	}
+	m := encoding.JSON
+	if encoding.IsCompressed(byts) {
+		m = encoding.Gzip(m)
+	}
+
+	var provider *apitype.SecretsProvidersV1
+	if contents := parseEncryptedEnvelope(m, byts); contents != nil {
+		if byts, err = b.decryptContents(m, byts); err != nil {
+			return update, nil, fmt.Errorf("reading history file %s: %w", key, err)
+		}
+		m, provider = encoding.JSON, &contents.SecretsProviders
+	}
+	if err = m.Unmarshal(byts, &update); err != nil {
+		return update, nil, fmt.Errorf("reading history file %s: %w", key, err)
+	}
+	return update, provider, nil
+}
+
+// writeHistoryFile writes an update record to the history of a stack, compressing it if its key asks for it. Records
+// hold the stack's configuration, so they are encrypted with the given secrets manager if encrypt is true.
+func (b *localBackend) writeHistoryFile(key string, update backend.UpdateInfo, encrypt bool,
+	sm secrets.Manager) error {
+
+	m := encoding.JSON
+	if strings.HasSuffix(key, encoding.GZIPExt) {
+		m = encoding.Gzip(m)
+	}
+
+	if !encrypt {
+		byts, err := m.Marshal(&update)
+		if err != nil {
+			return err
+		}
+		return b.bucket.WriteAll(context.TODO(), key, byts, nil)
+	}
+
+	plaintext, err := encoding.JSON.Marshal(&update)
+	if err != nil {
+		return err
+	}
+	byts, err := encryptContents(plaintext, sm)
+	if err != nil {
+		return err
+	}
+	if m != encoding.JSON {
+		if byts, err = m.Marshal(json.RawMessage(byts)); err != nil {
+			return err
+		}
+	}
+	return b.bucket.WriteAll(context.TODO(), key, byts, nil)
+}
+
+// readHistory reads the given update records, oldest first. Records written by older versions of the CLI don't have a
+// version, so they are numbered after the record before them. upgradeHistory saves these numbers in the records before
+// any update is added to or pruned from the history, so that they never change.
+func (b *localBackend) readHistory(historyFiles []*blob.ListObject) ([]backend.UpdateInfo, error) {
+	updates := make([]backend.UpdateInfo, len(historyFiles))
+	version := 0
+	for i, file := range historyFiles {
+		update, err := b.readHistoryFile(file.Key)
+		if err != nil {
+			return nil, err
+		}
+		if update.Version == 0 {
+			update.Version = version + 1
+		}
+		version = update.Version
+		updates[i] = update
+	}
+	return updates, nil
+}
+
+// upgradeHistory saves a version in every update record in the history of a stack that was written by an older
+// version of the CLI, and returns the version of the most recent update, or zero if the stack has no history. It must
+// be called before any record is pruned from the history, since records without a version are numbered after the
+// record before them.
+func (b *localBackend) upgradeHistory(ref *localBackendReference) (int, error) {
+	historyFiles, err := b.listHistoryFiles(ref)
+	if err != nil || len(historyFiles) == 0 {
+		return 0, err
+	}
+
+	// Records are upgraded before new ones are added, so if the most recent record has a version, all of them do.
+	last, err := b.readHistoryFile(historyFiles[len(historyFiles)-1].Key)
+	if err != nil || last.Version != 0 {
+		return last.Version, err
+	}
+
+	version := 0
+	for _, file := range historyFiles {
+		update, provider, err := b.readHistoryRecord(file.Key)
+		if err != nil {
+			return 0, err
+		}
+		if update.Version == 0 {
+			// Records that were encrypted stay encrypted with the same secrets provider.
+			var sm secrets.Manager
+			if provider != nil {
+				if sm, err = stack.DefaultSecretsProvider.OfType(provider.Type, provider.State); err != nil {
+					return 0, fmt.Errorf("upgrading history file %s: %w", file.Key, err)
+				}
+			}
+			update.Version = version + 1
+			if err := b.writeHistoryFile(file.Key, update, provider != nil, sm); err != nil {
+				return 0, fmt.Errorf("upgrading history file %s: %w", file.Key, err)
+			}
+		}
+		version = update.Version
+	}
+	return version, nil
+}
+
+// getHistory returns locally stored update history. The first element of the result will be
+// the most recent update record.
+//
+// Updates are numbered from 1, like they are by the service.
+func (b *localBackend) getHistory(ref *localBackendReference, pageSize int, page int) ([]backend.UpdateInfo, error) {
+	contract.Require(ref != nil, "ref")
 
-		historyEntries = append(historyEntries, file)
+	// TODO: we could consider optimizing the list operation using `page` and `pageSize`.
+	// Unfortunately, this is mildly invasive given the gocloud List API.
+	historyFiles, err := b.listHistoryFiles(ref)
+	if err != nil {
+		return nil, err
 	}
 
 	start := 0
-	end := len(historyEntries) - 1
+	end := len(historyFiles) - 1
 	if pageSize > 0 {
 		if page < 1 {
 			page = 1
 		}
 		start = (page - 1) * pageSize
 		end = start + pageSize - 1
-		if end > len(historyEntries)-1 {
-			end = len(historyEntries) - 1
+		if end > len(historyFiles)-1 {
+			end = len(historyFiles) - 1
 		}
 	}
 
 	var updates []backend.UpdateInfo
 
+	// Walk the history in most recent order.
 	for i := start; i <= end; i++ {
-		file := historyEntries[i]
-		filepath := file.Key
-
-		var update backend.UpdateInfo
-		b, err := b.bucket.ReadAll(context.TODO(), filepath)
+		index := len(historyFiles) - 1 - i
+		update, err := b.readHistoryFile(historyFiles[index].Key)
 		if err != nil {
-			return nil, fmt.Errorf("reading history file %s: %w", filepath, err)
-		}
-		m := encoding.JSON
-		if encoding.IsCompressed(b) {
-			m = encoding.Gzip(m)
+			return nil, err
 		}
-		err = m.Unmarshal(b, &update)
-		if err != nil {
-			return nil, fmt.Errorf("reading history file %s: %w", filepath, err)
+		if update.Version == 0 {
+			// This record was written by an older version of the CLI, so the rest of the page must be numbered by
+			// reading the records before it.
+			older, err := b.readHistory(historyFiles[:index+1])
+			if err != nil {
+				return nil, err
+			}
+			for ; i <= end; i++ {
+				updates = append(updates, older[len(historyFiles)-1-i])
+			}
+			break
 		}
-
 		updates = append(updates, update)
 	}
 
 	return updates, nil
 }
 
-func (b *localBackend) renameHistory(oldName tokens.Name, newName tokens.Name) error {
-	contract.Require(oldName != "", "oldName")
-	contract.Require(newName != "", "newName")
+// nextHistoryVersion returns the version of the next update to be added to the history of a stack.
+func (b *localBackend) nextHistoryVersion(ref *localBackendReference) (int, error) {
+	last, err := b.upgradeHistory(ref)
+	if err != nil {
+		return 0, err
+	}
+	return last + 1, nil
+}
 
-	oldHistory := b.historyDirectory(oldName)
-	newHistory := b.historyDirectory(newName)
+// getHistoryCheckpoint returns the checkpoint saved in the history of a stack for the update with the given version.
+func (b *localBackend) getHistoryCheckpoint(ref *localBackendReference, version int) (*apitype.CheckpointV3, error) {
+	historyFiles, err := b.listHistoryFiles(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// Versions increase with the position in the history, so search backwards from the most recent update.
+	for i := len(historyFiles) - 1; i >= 0; i-- {
+		key := historyFiles[i].Key
+		update, err := b.readHistoryFile(key)
+		if err != nil {
+			return nil, err
+		}
+		if update.Version == 0 {
+			// This record was written by an older version of the CLI, so the records before it must be read to
+			// number it.
+			older, err := b.readHistory(historyFiles[:i+1])
+			if err != nil {
+				return nil, err
+			}
+			for j := i; j >= 0; j-- {
+				if older[j].Version == version {
+					return b.readHistoryCheckpoint(ref, historyFiles[j].Key, version)
+				}
+			}
+			break
+		}
+		if update.Version > version {
+			continue
+		}
+		if update.Version < version {
+			break
+		}
+		return b.readHistoryCheckpoint(ref, key, version)
+	}
+
+	return nil, fmt.Errorf("version %d of stack %s was not found in its history", version, ref)
+}
+
+// readHistoryCheckpoint reads the checkpoint saved next to the update record with the given key.
+func (b *localBackend) readHistoryCheckpoint(ref *localBackendReference, key string,
+	version int) (*apitype.CheckpointV3, error) {
+
+	prefix := strings.TrimSuffix(strings.TrimSuffix(key, encoding.GZIPExt), ".history.json")
+	for _, checkpointFile := range []string{prefix + ".checkpoint.json", prefix + ".checkpoint.json.gz"} {
+		byts, err := b.bucket.ReadAll(context.TODO(), checkpointFile)
+		if err != nil {
+			if gcerrors.Code(err) == gcerrors.NotFound {
+				continue
+			}
+			return nil, fmt.Errorf("reading checkpoint for version %d: %w", version, err)
+		}
+		return b.unmarshalCheckpoint(byts)
+	}
+	return nil, fmt.Errorf("the checkpoint for version %d of stack %s is missing", version, ref)
+}
+
+func (b *localBackend) renameHistory(oldRef *localBackendReference, newRef *localBackendReference) error {
+	contract.Require(oldRef != nil, "oldRef")
+	contract.Require(newRef != nil, "newRef")
+
+	oldName, newName := oldRef.Name(), newRef.Name()
+	oldHistory := b.historyDirectory(oldRef)
+	newHistory := b.historyDirectory(newRef)
 
 	allFiles, err := listBucket(b.bucket, oldHistory)
 	if err != nil {
@@ -509,33 +737,75 @@ func (b *localBackend) renameHistory(oldName tokens.Name, newName tokens.Name) e
 	return nil
 }
 
+// renameBackups moves the checkpoint backups of a stack to the backup directory of its new name, which may be in
+// another project.
+func (b *localBackend) renameBackups(oldRef *localBackendReference, newRef *localBackendReference) error {
+	contract.Require(oldRef != nil, "oldRef")
+	contract.Require(newRef != nil, "newRef")
+
+	oldName, newName := oldRef.Name().String(), newRef.Name().String()
+	oldBackups := b.backupDirectory(oldRef)
+	newBackups := b.backupDirectory(newRef)
+
+	allFiles, err := listBucket(b.bucket, oldBackups)
+	if err != nil {
+		// if there's nothing there, we don't really need to do a rename.
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil
+		}
+		return err
+	}
+
+	for _, file := range allFiles {
+		// Backups are named <stack-name>.<timestamp>.json[.gz]; skip anything else.
+		fileName := objectName(file)
+		if _, _, ok := parseBackupFile(oldName, fileName); !ok {
+			continue
+		}
+
+		oldBlob := path.Join(oldBackups, fileName)
+		newBlob := path.Join(newBackups, newName+strings.TrimPrefix(fileName, oldName))
+		if err := b.bucket.Copy(context.TODO(), newBlob, oldBlob, nil); err != nil {
+			return fmt.Errorf("copying backup file: %w", err)
+		}
+		if err := b.bucket.Delete(context.TODO(), oldBlob); err != nil {
+			return fmt.Errorf("deleting existing backup file: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // addToHistory saves the UpdateInfo and makes a copy of the current Checkpoint file.
-func (b *localBackend) addToHistory(name tokens.Name, update backend.UpdateInfo) error {
-	contract.Require(name != "", "name")
+func (b *localBackend) addToHistory(ref *localBackendReference, update backend.UpdateInfo,
+	sm secrets.Manager) error {
+
+	contract.Require(ref != nil, "ref")
 
-	dir := b.historyDirectory(name)
+	dir := b.historyDirectory(ref)
 
 	// Prefix for the update and checkpoint files.
-	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", name, time.Now().UnixNano()))
+	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", ref.Name(), time.Now().UnixNano()))
 
-	m, ext := encoding.JSON, "json"
+	// Number the update after the last one in the history.
+	version, err := b.nextHistoryVersion(ref)
+	if err != nil {
+		return err
+	}
+	update.Version = version
+
+	ext := "json"
 	if b.gzip {
-		m = encoding.Gzip(m)
 		ext += ".gz"
 	}
 
 	// Save the history file.
-	byts, err := m.Marshal(&update)
-	if err != nil {
-		return err
-	}
-
 	historyFile := fmt.Sprintf("%s.history.%s", pathPrefix, ext)
-	if err = b.bucket.WriteAll(context.TODO(), historyFile, byts, nil); err != nil {
+	if err = b.writeHistoryFile(historyFile, update, b.encrypt, sm); err != nil {
 		return err
 	}
 
 	// Make a copy of the checkpoint file. (Assuming it already exists.)
 	checkpointFile := fmt.Sprintf("%s.checkpoint.%s", pathPrefix, ext)
-	return b.bucket.Copy(context.TODO(), checkpointFile, b.stackPath(name), nil)
+	return b.bucket.Copy(context.TODO(), checkpointFile, b.stackPath(ref), nil)
 }
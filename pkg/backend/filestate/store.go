@@ -0,0 +1,238 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/fsutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// organizationName is the only organization name accepted in fully qualified stack references. The filestate
+// backend has no notion of organizations, but we accept the same `organization/project/stack` form as the service.
+const organizationName = "organization"
+
+// localBackendReference is a reference to a stack stored in a filestate bucket.
+type localBackendReference struct {
+	name tokens.Name
+	// project is the project the stack belongs to. It is empty for stacks stored using the legacy layout, where
+	// stacks are not scoped by project.
+	project tokens.Name
+	b       *localBackend
+}
+
+func (r *localBackendReference) String() string {
+	// Legacy references and references to stacks in the current project are displayed using just the name.
+	if r.project == "" {
+		return string(r.name)
+	}
+	if r.b != nil && r.b.currentProject != nil && string(r.project) == string(r.b.currentProject.Name) {
+		return string(r.name)
+	}
+	return r.FullyQualifiedName()
+}
+
+func (r *localBackendReference) Name() tokens.Name {
+	return r.name
+}
+
+// Project returns the name of the project the stack belongs to, or an empty name for legacy references.
+func (r *localBackendReference) Project() tokens.Name {
+	return r.project
+}
+
+// FullyQualifiedName returns the `organization/project/stack` form of the reference, or just the stack name for
+// legacy references.
+func (r *localBackendReference) FullyQualifiedName() string {
+	if r.project == "" {
+		return string(r.name)
+	}
+	return fmt.Sprintf("%s/%s/%s", organizationName, r.project, r.name)
+}
+
+// relPath returns the path for this stack relative to the stacks, history, backups and locks directories.
+func (r *localBackendReference) relPath() string {
+	if r.project == "" {
+		return fsutil.NamePath(r.name)
+	}
+	return path.Join(fsutil.NamePath(r.project), fsutil.NamePath(r.name))
+}
+
+// referenceStore maps stack references to and from their location in the bucket. There is an implementation for
+// each supported bucket layout.
+type referenceStore interface {
+	// ParseReference parses a stack name, which may be fully qualified, into a reference.
+	ParseReference(stackRef string) (*localBackendReference, error)
+	// ListReferences lists all of the stacks in the bucket, optionally restricted to a single project.
+	ListReferences(project *string) ([]*localBackendReference, error)
+	// ProjectExists returns true if the bucket contains any stacks for the given project.
+	ProjectExists(project string) (bool, error)
+}
+
+// legacyReferenceStore implements the original bucket layout, in which every stack is stored directly in
+// `.pulumi/stacks/<stack>.json` regardless of the project it belongs to.
+type legacyReferenceStore struct {
+	b *localBackend
+}
+
+func (s *legacyReferenceStore) ParseReference(stackRef string) (*localBackendReference, error) {
+	if strings.Contains(stackRef, "/") {
+		return nil, errors.New("stack names may not contain slashes when using the legacy state layout")
+	}
+	return &localBackendReference{name: tokens.Name(stackRef), b: s.b}, nil
+}
+
+func (s *legacyReferenceStore) ListReferences(_ *string) ([]*localBackendReference, error) {
+	// Projects aren't recorded in the legacy layout, so the project filter can't be honored.
+	names, err := listStackFiles(s.b.bucket, stacksDir())
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]*localBackendReference, len(names))
+	for i, name := range names {
+		refs[i] = &localBackendReference{name: name, b: s.b}
+	}
+	return refs, nil
+}
+
+func (s *legacyReferenceStore) ProjectExists(project string) (bool, error) {
+	// Local backends using the legacy layout don't really have multiple projects, so just return false here.
+	return false, nil
+}
+
+// projectReferenceStore implements the project-scoped bucket layout, in which stacks are stored in
+// `.pulumi/stacks/<project>/<stack>.json`.
+type projectReferenceStore struct {
+	b *localBackend
+}
+
+func (s *projectReferenceStore) ParseReference(stackRef string) (*localBackendReference, error) {
+	var name, project string
+	split := strings.Split(stackRef, "/")
+	switch len(split) {
+	case 1:
+		name = split[0]
+	case 2:
+		if split[0] != organizationName {
+			return nil, fmt.Errorf("organizations are not supported by the filestate backend; "+
+				"use '%s/<project>/<stack>' to refer to a stack in another project", organizationName)
+		}
+		name = split[1]
+	case 3:
+		if split[0] != organizationName {
+			return nil, fmt.Errorf("organizations are not supported by the filestate backend; "+
+				"use '%s/<project>/<stack>' to refer to a stack in another project", organizationName)
+		}
+		project, name = split[1], split[2]
+	default:
+		return nil, fmt.Errorf("could not parse stack reference '%s'", stackRef)
+	}
+
+	if project == "" {
+		if s.b.currentProject == nil {
+			return nil, fmt.Errorf("no current project found; pass the fully qualified stack name "+
+				"(%s/<project>/<stack>)", organizationName)
+		}
+		project = string(s.b.currentProject.Name)
+	}
+
+	if !tokens.IsName(project) {
+		return nil, fmt.Errorf("project name '%s' is not valid", project)
+	}
+
+	return &localBackendReference{name: tokens.Name(name), project: tokens.Name(project), b: s.b}, nil
+}
+
+func (s *projectReferenceStore) ListReferences(project *string) ([]*localBackendReference, error) {
+	var projects []tokens.Name
+	if project != nil {
+		projects = []tokens.Name{tokens.Name(*project)}
+	} else {
+		dirs, err := listBucket(s.b.bucket, stacksDir())
+		if err != nil {
+			return nil, fmt.Errorf("error listing projects: %w", err)
+		}
+		for _, dir := range dirs {
+			if dir.IsDir {
+				projects = append(projects, tokens.Name(path.Base(strings.TrimSuffix(dir.Key, "/"))))
+			}
+		}
+	}
+
+	var refs []*localBackendReference
+	for _, proj := range projects {
+		names, err := listStackFiles(s.b.bucket, path.Join(stacksDir(), fsutil.NamePath(proj)))
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			refs = append(refs, &localBackendReference{name: name, project: proj, b: s.b})
+		}
+	}
+	return refs, nil
+}
+
+func (s *projectReferenceStore) ProjectExists(project string) (bool, error) {
+	names, err := listStackFiles(s.b.bucket, path.Join(stacksDir(), fsutil.NamePath(tokens.Name(project))))
+	if err != nil {
+		return false, err
+	}
+	return len(names) > 0, nil
+}
+
+// stacksDir returns the directory that holds all stack checkpoints.
+func stacksDir() string {
+	return path.Join(workspace.BookkeepingDir, workspace.StackDir)
+}
+
+// listStackFiles returns the names of the stacks whose checkpoints are stored directly in the given directory.
+func listStackFiles(bucket Bucket, dir string) ([]tokens.Name, error) {
+	files, err := listBucket(bucket, dir)
+	if err != nil {
+		return nil, fmt.Errorf("error listing stacks: %w", err)
+	}
+
+	var stacks []tokens.Name
+	for _, file := range files {
+		// Ignore directories.
+		if file.IsDir {
+			continue
+		}
+
+		// Skip files without valid extensions (e.g., *.bak files).
+		stackfn := objectName(file)
+		ext := filepath.Ext(stackfn)
+		// But accept gzip compression
+		if ext == encoding.GZIPExt {
+			stackfn = strings.TrimSuffix(stackfn, encoding.GZIPExt)
+			ext = filepath.Ext(stackfn)
+		}
+
+		if _, has := encoding.Marshalers[ext]; !has {
+			continue
+		}
+
+		stacks = append(stacks, tokens.Name(stackfn[:len(stackfn)-len(ext)]))
+	}
+
+	return stacks, nil
+}
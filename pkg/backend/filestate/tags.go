@@ -0,0 +1,90 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"gocloud.dev/gcerrors"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// tagsDir is the directory, next to the stacks directory, that holds the tags for each stack. Tags are kept out of
+// the checkpoint itself so that updating them doesn't require rewriting the checkpoint, and vice versa.
+const tagsDir = "tags"
+
+func (b *localBackend) tagsPath(ref *localBackendReference) string {
+	contract.Require(ref != nil, "ref")
+	return filepath.Join(b.StateDir(), tagsDir, ref.relPath()) + ".json"
+}
+
+// getStackTags returns the tags for a stack, or nil if none have been saved.
+func (b *localBackend) getStackTags(ctx context.Context,
+	ref *localBackendReference) (map[apitype.StackTagName]string, error) {
+
+	byts, err := b.bucket.ReadAll(ctx, b.tagsPath(ref))
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading tags for stack %s: %w", ref, err)
+	}
+
+	var tags map[apitype.StackTagName]string
+	if err := json.Unmarshal(byts, &tags); err != nil {
+		return nil, fmt.Errorf("parsing tags for stack %s: %w", ref, err)
+	}
+	return tags, nil
+}
+
+// saveStackTags replaces the tags for a stack.
+func (b *localBackend) saveStackTags(ctx context.Context,
+	ref *localBackendReference, tags map[apitype.StackTagName]string) error {
+
+	byts, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+	if err := b.bucket.WriteAll(ctx, b.tagsPath(ref), byts, nil); err != nil {
+		return fmt.Errorf("writing tags for stack %s: %w", ref, err)
+	}
+	return nil
+}
+
+// removeStackTags deletes the tags for a stack, if there are any.
+func (b *localBackend) removeStackTags(ctx context.Context, ref *localBackendReference) error {
+	err := b.bucket.Delete(ctx, b.tagsPath(ref))
+	if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return fmt.Errorf("deleting tags for stack %s: %w", ref, err)
+	}
+	return nil
+}
+
+// moveStackTags moves the tags for a stack to another stack, if there are any.
+func (b *localBackend) moveStackTags(ctx context.Context, from, to *localBackendReference) error {
+	tags, err := b.getStackTags(ctx, from)
+	if err != nil || tags == nil {
+		return err
+	}
+	if err := b.saveStackTags(ctx, to, tags); err != nil {
+		return err
+	}
+	return b.removeStackTags(ctx, from)
+}
@@ -73,6 +73,11 @@ func (c cloudBackendReference) Name() tokens.Name {
 	return c.name
 }
 
+// Project returns the name of the project the stack belongs to.
+func (c cloudBackendReference) Project() tokens.Name {
+	return tokens.Name(c.project)
+}
+
 // cloudStack is a cloud stack descriptor.
 type cloudStack struct {
 	// ref is the stack's unique name.
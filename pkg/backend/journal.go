@@ -0,0 +1,328 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/version"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// JournalPersister is implemented by snapshot persisters that can persist snapshots incrementally. Instead of saving
+// the entire snapshot after every mutation, the SnapshotManager appends an entry describing each step to a journal,
+// and only periodically compacts the journal by saving a full snapshot. If the process is interrupted, the state of
+// the stack can be recovered by replaying the journal with ReplayJournal.
+type JournalPersister interface {
+	SnapshotPersister
+
+	// BeginJournal starts a new journal of the steps applied to the given base snapshot, replacing any existing
+	// journal.
+	BeginJournal(base *deploy.Snapshot) error
+	// AppendJournalEntry durably appends an entry to the journal.
+	AppendJournalEntry(entry apitype.JournalEntryV1) error
+	// EndJournal discards the journal, once a full snapshot including all of its entries has been saved.
+	EndJournal() error
+	// JournalCompactionInterval returns the number of entries after which a full snapshot is saved.
+	JournalCompactionInterval() int
+}
+
+// snapshotJournal records the mutations performed by a SnapshotManager in the journal of a JournalPersister.
+type snapshotJournal struct {
+	persister JournalPersister
+	started   bool                    // true once the journal has been started for the current base snapshot
+	stale     bool                    // true if the base snapshot has been rebuilt in memory since the journal started
+	ids       map[*resource.State]int // the journal IDs of the resource states recorded so far
+	sequence  int64                   // the sequence number of the next entry
+	pending   int                     // the number of entries recorded since a full snapshot was last saved
+}
+
+// begin starts a new journal relative to the given base snapshot. Resource states in the base snapshot are
+// identified by their index; states produced by the update are assigned increasing IDs as they are recorded.
+func (j *snapshotJournal) begin(base *deploy.Snapshot) error {
+	if err := j.persister.BeginJournal(base); err != nil {
+		return fmt.Errorf("failed to begin journal: %w", err)
+	}
+
+	j.ids = make(map[*resource.State]int)
+	if base != nil {
+		for i, res := range base.Resources {
+			j.ids[res] = i
+		}
+	}
+	j.sequence, j.started, j.stale = 0, true, false
+	return nil
+}
+
+// restart starts a new journal relative to a snapshot that has just been saved, so that the journal only holds the
+// entries recorded since. The first produced resources of the snapshot were produced by the update, and must stay
+// ahead of the remaining base resources when the journal is replayed, so the new journal begins by recording each of
+// them as unchanged. Operations that are still in flight are recorded as begun rather than as pending operations of
+// the base, so that replaying the journal clears them once they complete.
+func (j *snapshotJournal) restart(saved *deploy.Snapshot, produced int, inflight []resource.Operation,
+	sm secrets.Manager) error {
+
+	isInflight := make(map[*resource.State]bool)
+	for _, op := range inflight {
+		isInflight[op.Resource] = true
+	}
+	var pending []resource.Operation
+	for _, op := range saved.PendingOperations {
+		if !isInflight[op.Resource] {
+			pending = append(pending, op)
+		}
+	}
+
+	base := deploy.NewSnapshot(saved.Manifest, saved.SecretsManager, saved.Resources, pending)
+	if err := j.begin(base); err != nil {
+		return err
+	}
+
+	for _, res := range saved.Resources[:produced] {
+		step := &journalStep{op: deploy.OpSame, old: res, new: res}
+		if err := j.append(engine.JournalEntry{Kind: engine.JournalEntrySuccess, Step: step}, sm); err != nil {
+			return err
+		}
+	}
+	for _, op := range inflight {
+		step := &journalStep{new: op.Resource}
+		switch op.Type {
+		case resource.OperationTypeCreating:
+			step.op = deploy.OpCreate
+		case resource.OperationTypeUpdating:
+			step.op = deploy.OpUpdate
+		case resource.OperationTypeReading:
+			step.op = deploy.OpRead
+		case resource.OperationTypeImporting:
+			step.op = deploy.OpImport
+		case resource.OperationTypeDeleting:
+			step.op, step.old, step.new = deploy.OpDelete, op.Resource, nil
+		default:
+			contract.Failf("unknown operation type: %v", op.Type)
+		}
+		if err := j.append(engine.JournalEntry{Kind: engine.JournalEntryBegin, Step: step}, sm); err != nil {
+			return err
+		}
+	}
+
+	// The entries recorded so far only restate the saved snapshot.
+	j.pending = 0
+	return nil
+}
+
+// append serializes and persists a single journal entry.
+func (j *snapshotJournal) append(entry engine.JournalEntry, sm secrets.Manager) error {
+	var enc config.Encrypter = config.NewPanicCrypter()
+	if sm != nil {
+		e, err := sm.Encrypter()
+		if err != nil {
+			return fmt.Errorf("getting encrypter for journal: %w", err)
+		}
+		enc = e
+	}
+
+	old, err := j.serializeState(entry.Step.Old(), enc)
+	if err != nil {
+		return err
+	}
+	new, err := j.serializeState(entry.Step.New(), enc)
+	if err != nil {
+		return err
+	}
+
+	serialized := apitype.JournalEntryV1{
+		Sequence: j.sequence,
+		Kind:     serializeJournalEntryKind(entry.Kind),
+		Op:       string(entry.Step.Op()),
+		Old:      old,
+		New:      new,
+	}
+	if err := j.persister.AppendJournalEntry(serialized); err != nil {
+		return fmt.Errorf("failed to append journal entry: %w", err)
+	}
+	j.sequence++
+	j.pending++
+	return nil
+}
+
+func (j *snapshotJournal) serializeState(state *resource.State,
+	enc config.Encrypter) (*apitype.JournalResourceV1, error) {
+
+	if state == nil {
+		return nil, nil
+	}
+
+	id, has := j.ids[state]
+	if !has {
+		id = len(j.ids)
+		j.ids[state] = id
+	}
+
+	res, err := stack.SerializeResource(state, enc, false /* showSecrets */)
+	if err != nil {
+		return nil, fmt.Errorf("serializing journal entry: %w", err)
+	}
+	return &apitype.JournalResourceV1{ID: id, State: res}, nil
+}
+
+func serializeJournalEntryKind(kind engine.JournalEntryKind) apitype.JournalEntryKind {
+	switch kind {
+	case engine.JournalEntryBegin:
+		return apitype.JournalEntryBegin
+	case engine.JournalEntrySuccess:
+		return apitype.JournalEntrySuccess
+	case engine.JournalEntryFailure:
+		return apitype.JournalEntryFailure
+	case engine.JournalEntryOutputs:
+		return apitype.JournalEntryOutputs
+	}
+
+	contract.Failf("unknown journal entry kind: %v", kind)
+	return ""
+}
+
+func deserializeJournalEntryKind(kind apitype.JournalEntryKind) (engine.JournalEntryKind, error) {
+	switch kind {
+	case apitype.JournalEntryBegin:
+		return engine.JournalEntryBegin, nil
+	case apitype.JournalEntrySuccess:
+		return engine.JournalEntrySuccess, nil
+	case apitype.JournalEntryFailure:
+		return engine.JournalEntryFailure, nil
+	case apitype.JournalEntryOutputs:
+		return engine.JournalEntryOutputs, nil
+	}
+	return 0, fmt.Errorf("unknown journal entry kind %q", kind)
+}
+
+// ReplayJournal recovers the state of a stack by replaying the entries of a journal over the base snapshot the
+// journal was started with. The base snapshot's resources are updated in place.
+func ReplayJournal(base *deploy.Snapshot, entries []apitype.JournalEntryV1) (*deploy.Snapshot, error) {
+	if base == nil {
+		base = deploy.NewSnapshot(deploy.Manifest{}, nil, nil, nil)
+	}
+
+	dec, enc := config.Decrypter(config.NewPanicCrypter()), config.Encrypter(config.NewPanicCrypter())
+	if base.SecretsManager != nil {
+		var err error
+		if dec, err = base.SecretsManager.Decrypter(); err != nil {
+			return nil, err
+		}
+		if enc, err = base.SecretsManager.Encrypter(); err != nil {
+			return nil, err
+		}
+	}
+
+	states := make(map[int]*resource.State)
+	for i, res := range base.Resources {
+		states[i] = res
+	}
+
+	// deserializeState returns the state with the given ID, updated to reflect the state recorded in the entry. States
+	// are updated in place, as the engine updates them during a deployment, so that every entry referring to the
+	// same state observes the same object.
+	deserializeState := func(res *apitype.JournalResourceV1) (*resource.State, error) {
+		if res == nil {
+			return nil, nil
+		}
+		state, err := stack.DeserializeResource(res.State, dec, enc)
+		if err != nil {
+			return nil, err
+		}
+		if existing, has := states[res.ID]; has {
+			*existing = *state
+			return existing, nil
+		}
+		states[res.ID] = state
+		return state, nil
+	}
+
+	journal := make(engine.JournalEntries, 0, len(entries))
+	for i, e := range entries {
+		if e.Sequence != int64(i) {
+			return nil, fmt.Errorf("journal entry %d is missing", i)
+		}
+		kind, err := deserializeJournalEntryKind(e.Kind)
+		if err != nil {
+			return nil, err
+		}
+		old, err := deserializeState(e.Old)
+		if err != nil {
+			return nil, fmt.Errorf("deserializing journal entry %d: %w", i, err)
+		}
+		new, err := deserializeState(e.New)
+		if err != nil {
+			return nil, fmt.Errorf("deserializing journal entry %d: %w", i, err)
+		}
+		journal = append(journal, engine.JournalEntry{
+			Kind: kind,
+			Step: &journalStep{op: display.StepOp(e.Op), old: old, new: new},
+		})
+	}
+
+	snap, err := journal.Snap(base)
+	if err != nil {
+		return nil, fmt.Errorf("replaying journal: %w", err)
+	}
+	snap.Manifest.Time = time.Now()
+	snap.Manifest.Version = version.Version
+	snap.Manifest.Magic = snap.Manifest.NewMagic()
+	return snap, nil
+}
+
+// journalStep is a step reconstructed from a journal entry. It carries just enough information to replay the entry;
+// it cannot be applied.
+type journalStep struct {
+	op  display.StepOp
+	old *resource.State
+	new *resource.State
+}
+
+var _ deploy.Step = (*journalStep)(nil)
+
+func (s *journalStep) Apply(preview bool) (resource.Status, deploy.StepCompleteFunc, error) {
+	contract.Failf("journal steps cannot be applied")
+	return resource.StatusOK, nil, nil
+}
+
+func (s *journalStep) Op() display.StepOp { return s.op }
+func (s *journalStep) URN() resource.URN  { return s.Res().URN }
+func (s *journalStep) Type() tokens.Type  { return s.Res().Type }
+func (s *journalStep) Provider() string   { return s.Res().Provider }
+func (s *journalStep) Old() *resource.State {
+	return s.old
+}
+func (s *journalStep) New() *resource.State {
+	return s.new
+}
+func (s *journalStep) Res() *resource.State {
+	if s.new != nil {
+		return s.new
+	}
+	return s.old
+}
+func (s *journalStep) Logical() bool                  { return true }
+func (s *journalStep) Deployment() *deploy.Deployment { return nil }
+func (s *journalStep) SourcePosition() string         { return "" }
@@ -0,0 +1,215 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+type MockJournalPersister struct {
+	MockStackPersister
+
+	Interval int
+	Base     *apitype.DeploymentV3
+	Entries  []apitype.JournalEntryV1
+	Ended    bool
+}
+
+func (m *MockJournalPersister) BeginJournal(base *deploy.Snapshot) error {
+	dep, err := stack.SerializeDeployment(base, m.SecretsManager(), false)
+	if err != nil {
+		return err
+	}
+	m.Base, m.Entries, m.Ended = dep, nil, false
+	return nil
+}
+
+func (m *MockJournalPersister) AppendJournalEntry(entry apitype.JournalEntryV1) error {
+	m.Entries = append(m.Entries, entry)
+	return nil
+}
+
+func (m *MockJournalPersister) EndJournal() error {
+	m.Ended = true
+	return nil
+}
+
+func (m *MockJournalPersister) JournalCompactionInterval() int {
+	return m.Interval
+}
+
+// Replay replays the journal recorded so far, as a new process recovering from an interrupted update would.
+func (m *MockJournalPersister) Replay(t *testing.T) *deploy.Snapshot {
+	base, err := stack.DeserializeDeploymentV3(*m.Base, stack.DefaultSecretsProvider)
+	require.NoError(t, err)
+	snap, err := ReplayJournal(base, m.Entries)
+	require.NoError(t, err)
+	return snap
+}
+
+func assertSameResources(t *testing.T, expected, actual []*resource.State) {
+	if !assert.Len(t, actual, len(expected)) {
+		return
+	}
+	for i := range expected {
+		assert.Equal(t, expected[i].URN, actual[i].URN)
+		assert.Equal(t, expected[i].Delete, actual[i].Delete)
+		assert.Equal(t, expected[i].Dependencies, actual[i].Dependencies)
+		assert.Equal(t, expected[i].Outputs, actual[i].Outputs)
+	}
+}
+
+func TestJournalReplay(t *testing.T) {
+	t.Parallel()
+
+	a := NewResource("a")
+	b := NewResource("b", a.URN)
+	c := NewResource("c", a.URN, b.URN)
+	d := NewResource("d", c.URN)
+	e := NewResource("e", c.URN)
+	snap := NewSnapshot([]*resource.State{a, b, c, d, e})
+
+	sp := &MockJournalPersister{Interval: 1000}
+	manager := NewSnapshotManager(sp, snap)
+
+	applyStep := func(step deploy.Step) {
+		mutation, err := manager.BeginMutation(step)
+		require.NoError(t, err)
+		require.NoError(t, mutation.End(step, true))
+	}
+
+	bPrime := NewResource(string(b.URN))
+	applyStep(deploy.NewSameStep(nil, MockRegisterResourceEvent{}, b, bPrime))
+
+	cPrime := NewResource(string(c.URN), bPrime.URN)
+	cPrime.Outputs["foo"] = resource.NewStringProperty("bar")
+	createReplacement := deploy.NewCreateReplacementStep(nil, MockRegisterResourceEvent{}, c, cPrime, nil, nil, nil, true)
+	replace := deploy.NewReplaceStep(nil, c, cPrime, nil, nil, nil, true)
+	mutation, err := manager.BeginMutation(createReplacement)
+	require.NoError(t, err)
+	// Applying the step marks the old resource for deletion after its mutation has begun. The journal must still
+	// observe this.
+	c.Delete = true
+	require.NoError(t, mutation.End(createReplacement, true))
+	applyStep(replace)
+
+	dPrime := NewResource(string(d.URN), cPrime.URN)
+	applyStep(deploy.NewUpdateStep(nil, MockRegisterResourceEvent{}, d, dPrime, nil, nil, nil, nil))
+	applyStep(deploy.NewDeleteStep(nil, nil, e))
+
+	// No full snapshot has been written, since the compaction interval was never reached.
+	assert.Empty(t, sp.SavedSnapshots)
+	assert.NotEmpty(t, sp.Entries)
+
+	// Replaying the journal before the update finishes must produce the same snapshot as finishing the update.
+	replayed := sp.Replay(t)
+
+	require.NoError(t, manager.Close())
+	assert.True(t, sp.Ended)
+	assertSameResources(t, sp.LastSnap().Resources, replayed.Resources)
+	assert.NoError(t, replayed.VerifyIntegrity())
+}
+
+func TestJournalCompaction(t *testing.T) {
+	t.Parallel()
+
+	snap := NewSnapshot(nil)
+	sp := &MockJournalPersister{Interval: 4}
+	manager := NewSnapshotManager(sp, snap)
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		step := deploy.NewCreateStep(nil, MockRegisterResourceEvent{}, NewResource(name))
+		mutation, err := manager.BeginMutation(step)
+		require.NoError(t, err)
+		require.NoError(t, mutation.End(step, true))
+	}
+
+	// A full snapshot was saved after every fourth entry, and the journal was restarted from it each time. The
+	// current journal restates the four resources in the last snapshot and then records the creation of the fifth.
+	assert.Len(t, sp.SavedSnapshots, 2)
+	assert.Len(t, sp.LastSnap().Resources, 4)
+	assert.Len(t, sp.Base.Resources, 4)
+	assert.Len(t, sp.Entries, 6)
+
+	assertSameResources(t, []*resource.State{
+		NewResource("a"), NewResource("b"), NewResource("c"), NewResource("d"), NewResource("e"),
+	}, sp.Replay(t).Resources)
+
+	require.NoError(t, manager.Close())
+	assert.Len(t, sp.SavedSnapshots, 3)
+	assert.Len(t, sp.LastSnap().Resources, 5)
+	assert.True(t, sp.Ended)
+}
+
+func TestJournalCompactionRestart(t *testing.T) {
+	t.Parallel()
+
+	a := NewResource("a")
+	b := NewResource("b", a.URN)
+	snap := NewSnapshot([]*resource.State{a, b})
+
+	sp := &MockJournalPersister{Interval: 3}
+	manager := NewSnapshotManager(sp, snap)
+
+	aPrime := NewResource(string(a.URN))
+	update := deploy.NewUpdateStep(nil, MockRegisterResourceEvent{}, a, aPrime, nil, nil, nil, nil)
+	mutation, err := manager.BeginMutation(update)
+	require.NoError(t, err)
+	require.NoError(t, mutation.End(update, true))
+
+	// The journal is compacted while the creation of x is in flight.
+	x := NewResource("x", aPrime.URN)
+	create := deploy.NewCreateStep(nil, MockRegisterResourceEvent{}, x)
+	createMutation, err := manager.BeginMutation(create)
+	require.NoError(t, err)
+	assert.Len(t, sp.SavedSnapshots, 1)
+	assert.Empty(t, sp.Base.PendingOperations)
+
+	replayed := sp.Replay(t)
+	assertSameResources(t, []*resource.State{aPrime, b}, replayed.Resources)
+	assert.Len(t, replayed.PendingOperations, 1)
+
+	// Once x has been created, it follows the resources produced before the journal was restarted, and its pending
+	// operation is cleared.
+	require.NoError(t, createMutation.End(create, true))
+	bPrime := NewResource(string(b.URN), aPrime.URN, x.URN)
+	update = deploy.NewUpdateStep(nil, MockRegisterResourceEvent{}, b, bPrime, nil, nil, nil, nil)
+	mutation, err = manager.BeginMutation(update)
+	require.NoError(t, err)
+	require.NoError(t, mutation.End(update, true))
+
+	replayed = sp.Replay(t)
+	assert.Empty(t, replayed.PendingOperations)
+	assert.NoError(t, replayed.VerifyIntegrity())
+
+	require.NoError(t, manager.Close())
+	assertSameResources(t, sp.LastSnap().Resources, replayed.Resources)
+	assertSameResources(t, []*resource.State{aPrime, x, bPrime}, replayed.Resources)
+}
+
+func TestReplayJournalMissingEntry(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReplayJournal(nil, []apitype.JournalEntryV1{{Sequence: 1, Kind: apitype.JournalEntryBegin}})
+	assert.ErrorContains(t, err, "journal entry 0 is missing")
+}
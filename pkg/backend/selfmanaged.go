@@ -0,0 +1,179 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	sdkDisplay "github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+// SelfManagedUpdate holds the parts of an update that are specific to a self-managed backend, i.e. one that runs the
+// engine locally and stores the stack's state itself.
+type SelfManagedUpdate struct {
+	// Backend is the backend that stores the stack.
+	Backend Backend
+	// StackRef is the backend's reference to the stack being updated.
+	StackRef StackReference
+	// NewUpdate creates the engine's description of the update.
+	NewUpdate func() (engine.UpdateInfo, error)
+	// SaveStackTags replaces the stack's tags.
+	SaveStackTags func(tags map[apitype.StackTagName]string) error
+	// Persister saves the stack's checkpoints as the update progresses.
+	Persister SnapshotPersister
+	// AddToHistory records the outcome of an update that is not a dry run.
+	AddToHistory func(info UpdateInfo) error
+}
+
+// RunSelfManagedUpdate performs the provided type of update on a stack stored by a self-managed backend, displaying
+// its progress and recording it in the stack's history.
+func RunSelfManagedUpdate(ctx context.Context, kind apitype.UpdateKind, stack Stack, op UpdateOperation,
+	opts ApplierOptions, events chan<- engine.Event,
+	u SelfManagedUpdate) (*deploy.Plan, sdkDisplay.ResourceChanges, result.Result) {
+
+	stackName := u.StackRef.Name()
+	actionLabel := ActionLabel(kind, opts.DryRun)
+
+	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch) {
+		// Print a banner so it's clear this is a self-managed deployment.
+		fmt.Printf(op.Opts.Display.Color.Colorize(
+			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, u.StackRef)
+	}
+
+	// Start the update.
+	update, err := u.NewUpdate()
+	if err != nil {
+		return nil, nil, result.FromError(err)
+	}
+
+	// We use this opportunity to refresh the stack's tags, to pick up any changes to the environment or Pulumi.yaml.
+	if !opts.DryRun {
+		tags, err := GetMergedStackTags(ctx, stack)
+		if err != nil {
+			return nil, nil, result.FromError(fmt.Errorf("getting stack tags: %w", err))
+		}
+		if err = u.SaveStackTags(tags); err != nil {
+			return nil, nil, result.FromError(err)
+		}
+	}
+
+	// Spawn a display loop to show events on the CLI.
+	displayEvents := make(chan engine.Event)
+	displayDone := make(chan bool)
+	go display.ShowEvents(
+		strings.ToLower(actionLabel), kind, stackName, op.Proj.Name,
+		displayEvents, displayDone, op.Opts.Display, opts.DryRun)
+
+	// Create a separate event channel for engine events that we'll pipe to both listening streams.
+	engineEvents := make(chan engine.Event)
+
+	scope := op.Scopes.NewScope(engineEvents, opts.DryRun)
+	eventsDone := make(chan bool)
+	go func() {
+		// Pull in all events from the engine and send them to the two listeners.
+		for e := range engineEvents {
+			displayEvents <- e
+
+			// If the caller also wants to see the events, stream them there also.
+			if events != nil {
+				events <- e
+			}
+		}
+
+		close(eventsDone)
+	}()
+
+	// Create the management machinery.
+	manager := NewSnapshotManager(u.Persister, update.GetTarget().Snapshot)
+	engineCtx := &engine.Context{
+		Cancel:          scope.Context(),
+		Events:          engineEvents,
+		SnapshotManager: manager,
+		BackendClient:   NewBackendClient(u.Backend),
+	}
+
+	// Perform the update
+	start := time.Now().Unix()
+	var plan *deploy.Plan
+	var changes sdkDisplay.ResourceChanges
+	var updateRes result.Result
+	switch kind {
+	case apitype.PreviewUpdate:
+		plan, changes, updateRes = engine.Update(update, engineCtx, op.Opts.Engine, true)
+	case apitype.UpdateUpdate:
+		_, changes, updateRes = engine.Update(update, engineCtx, op.Opts.Engine, opts.DryRun)
+	case apitype.ResourceImportUpdate:
+		_, changes, updateRes = engine.Import(update, engineCtx, op.Opts.Engine, op.Imports, opts.DryRun)
+	case apitype.RefreshUpdate:
+		_, changes, updateRes = engine.Refresh(update, engineCtx, op.Opts.Engine, opts.DryRun)
+	case apitype.DestroyUpdate:
+		_, changes, updateRes = engine.Destroy(update, engineCtx, op.Opts.Engine, opts.DryRun)
+	default:
+		contract.Failf("Unrecognized update kind: %s", kind)
+	}
+	end := time.Now().Unix()
+
+	// Wait for the display to finish showing all the events.
+	<-displayDone
+	scope.Close() // Don't take any cancellations anymore, we're shutting down.
+	close(engineEvents)
+	contract.IgnoreClose(manager)
+
+	// Make sure the goroutine writing to displayEvents and events has exited before proceeding.
+	<-eventsDone
+	close(displayEvents)
+
+	// Save update results.
+	backendUpdateResult := SucceededResult
+	if updateRes != nil {
+		backendUpdateResult = FailedResult
+	}
+	info := UpdateInfo{
+		Kind:            kind,
+		StartTime:       start,
+		Message:         op.M.Message,
+		Environment:     op.M.Environment,
+		Config:          update.GetTarget().Config,
+		Result:          backendUpdateResult,
+		EndTime:         end,
+		ResourceChanges: changes,
+	}
+
+	var saveErr error
+	if !opts.DryRun {
+		saveErr = u.AddToHistory(info)
+	}
+
+	if updateRes != nil {
+		// We swallow saveErr as it is less important than the updateErr.
+		return plan, changes, updateRes
+	}
+
+	if saveErr != nil {
+		return plan, changes, result.FromError(fmt.Errorf("saving update info: %w", saveErr))
+	}
+
+	return plan, changes, nil
+}
@@ -63,6 +63,7 @@ type SnapshotManager struct {
 	dones            map[*resource.State]bool // The set of resources that have been operated upon already by this plan
 	completeOps      map[*resource.State]bool // The set of resources that have completed their operation
 	doVerify         bool                     // If true, verify the snapshot before persisting it
+	journal          *snapshotJournal         // The journal used to persist mutations incrementally, if supported
 	mutationRequests chan<- mutationRequest   // The queue of mutation requests, to be retired serially by the manager
 	cancel           chan bool                // A channel used to request cancellation of any new mutation requests.
 	done             <-chan error             // A channel that sends a single result when the manager has shut down.
@@ -71,6 +72,7 @@ type SnapshotManager struct {
 var _ engine.SnapshotManager = (*SnapshotManager)(nil)
 
 type mutationRequest struct {
+	entry   *engine.JournalEntry
 	mutator func() bool
 	result  chan<- error
 }
@@ -95,12 +97,17 @@ func (sm *SnapshotManager) Close() error {
 // meaningful changes (see sameSnapshotMutation.mustWrite for details). Any elided writes
 // are flushed by the next non-elided write or the next call to Close.
 //
+// If the persister supports journaling, the given journal entry describing the mutation is
+// appended to the journal instead, and the snapshot is only written periodically (see
+// journalMutation for details). A nil entry indicates that the mutation has no effect on the
+// journal.
+//
 // You should never observe or mutate the global snapshot without using this function unless
 // you have a very good justification.
-func (sm *SnapshotManager) mutate(mutator func() bool) error {
+func (sm *SnapshotManager) mutate(entry *engine.JournalEntry, mutator func() bool) error {
 	result := make(chan error)
 	select {
-	case sm.mutationRequests <- mutationRequest{mutator: mutator, result: result}:
+	case sm.mutationRequests <- mutationRequest{entry: entry, mutator: mutator, result: result}:
 		return <-result
 	case <-sm.cancel:
 		return errors.New("snapshot manager closed")
@@ -119,7 +126,20 @@ func (sm *SnapshotManager) mutate(mutator func() bool) error {
 // Note that this is completely not thread-safe and defeats the purpose of having a `mutate` callback
 // entirely, but the hope is that this state of things will not be permament.
 func (sm *SnapshotManager) RegisterResourceOutputs(step deploy.Step) error {
-	return sm.mutate(func() bool { return true })
+	return sm.mutate(journalEntry(engine.JournalEntryOutputs, step), func() bool { return true })
+}
+
+// journalEntry returns a journal entry of the given kind for the given step.
+func journalEntry(kind engine.JournalEntryKind, step deploy.Step) *engine.JournalEntry {
+	return &engine.JournalEntry{Kind: kind, Step: step}
+}
+
+// endJournalEntry returns the journal entry recording the completion of the given step.
+func endJournalEntry(step deploy.Step, successful bool) *engine.JournalEntry {
+	if successful {
+		return journalEntry(engine.JournalEntrySuccess, step)
+	}
+	return journalEntry(engine.JournalEntryFailure, step)
 }
 
 // BeginMutation signals to the SnapshotManager that the engine intends to mutate the global snapshot
@@ -210,6 +230,12 @@ func (ssm *sameSnapshotMutation) mustWrite(step *deploy.SameStep) bool {
 		return true
 	}
 
+	// We need to persist the changes if DeletedWith has changed
+	if old.DeletedWith != new.DeletedWith {
+		logging.V(9).Infof("SnapshotManager: mustWrite() true because of DeletedWith")
+		return true
+	}
+
 	contract.Assert(old.ID == new.ID)
 
 	// If this resource's provider has changed, we must write the checkpoint. This can happen in scenarios involving
@@ -270,7 +296,13 @@ func (ssm *sameSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step.Op() == deploy.OpSame, "step.Op() == deploy.OpSame")
 	contract.Assert(successful)
 	logging.V(9).Infof("SnapshotManager: sameSnapshotMutation.End(..., %v)", successful)
-	return ssm.manager.mutate(func() bool {
+
+	// Skipped creates are never written to the checkpoint, so they are not journaled either.
+	var entry *engine.JournalEntry
+	if !step.(*deploy.SameStep).IsSkippedCreate() {
+		entry = endJournalEntry(step, successful)
+	}
+	return ssm.manager.mutate(entry, func() bool {
 		sameStep := step.(*deploy.SameStep)
 
 		ssm.manager.markDone(step.Old())
@@ -289,11 +321,13 @@ func (ssm *sameSnapshotMutation) End(step deploy.Step, successful bool) error {
 		// resource with new dependencies, outputs, parent, protection. etc.
 		//
 		// As such, we diff all of the non-input properties of the resource here and write the snapshot if we find any
-		// changes.
+		// changes. Any such changes also count as modifications of the resource.
 		if !ssm.mustWrite(sameStep) {
+			stampSame(step.Old(), step.New())
 			logging.V(9).Infof("SnapshotManager: sameSnapshotMutation.End() eliding write")
 			return false
 		}
+		stampModified(step.Old(), step.New())
 
 		logging.V(9).Infof("SnapshotManager: sameSnapshotMutation.End() not eliding write")
 		return true
@@ -302,7 +336,7 @@ func (ssm *sameSnapshotMutation) End(step deploy.Step, successful bool) error {
 
 func (sm *SnapshotManager) doCreate(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doCreate(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(journalEntry(engine.JournalEntryBegin, step), func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeCreating)
 		return true
 	})
@@ -320,7 +354,7 @@ type createSnapshotMutation struct {
 func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: createSnapshotMutation.End(..., %v)", successful)
-	return csm.manager.mutate(func() bool {
+	return csm.manager.mutate(endJournalEntry(step, successful), func() bool {
 		csm.manager.markOperationComplete(step.New())
 		if successful {
 			// There is some very subtle behind-the-scenes magic here that
@@ -332,6 +366,7 @@ func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error
 			// Since we are storing the base snapshot and all resources by reference
 			// (we have pointers to engine-allocated objects), this transparently
 			// "just works" for the SnapshotManager.
+			stampCreated(step.New())
 			csm.manager.markNew(step.New())
 
 			// If we had an old state that was marked as pending-replacement, mark its replacement as complete such
@@ -346,7 +381,7 @@ func (csm *createSnapshotMutation) End(step deploy.Step, successful bool) error
 
 func (sm *SnapshotManager) doUpdate(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doUpdate(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(journalEntry(engine.JournalEntryBegin, step), func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeUpdating)
 		return true
 	})
@@ -364,9 +399,10 @@ type updateSnapshotMutation struct {
 func (usm *updateSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: updateSnapshotMutation.End(..., %v)", successful)
-	return usm.manager.mutate(func() bool {
+	return usm.manager.mutate(endJournalEntry(step, successful), func() bool {
 		usm.manager.markOperationComplete(step.New())
 		if successful {
+			stampModified(step.Old(), step.New())
 			usm.manager.markDone(step.Old())
 			usm.manager.markNew(step.New())
 		}
@@ -376,7 +412,7 @@ func (usm *updateSnapshotMutation) End(step deploy.Step, successful bool) error
 
 func (sm *SnapshotManager) doDelete(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doDelete(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(journalEntry(engine.JournalEntryBegin, step), func() bool {
 		sm.markOperationPending(step.Old(), resource.OperationTypeDeleting)
 		return true
 	})
@@ -394,7 +430,7 @@ type deleteSnapshotMutation struct {
 func (dsm *deleteSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: deleteSnapshotMutation.End(..., %v)", successful)
-	return dsm.manager.mutate(func() bool {
+	return dsm.manager.mutate(endJournalEntry(step, successful), func() bool {
 		dsm.manager.markOperationComplete(step.Old())
 		if successful {
 			// Either old should not be protected or this is a replace
@@ -422,7 +458,7 @@ func (rsm *replaceSnapshotMutation) End(step deploy.Step, successful bool) error
 
 func (sm *SnapshotManager) doRead(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doRead(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(journalEntry(engine.JournalEntryBegin, step), func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeReading)
 		return true
 	})
@@ -440,11 +476,18 @@ type readSnapshotMutation struct {
 func (rsm *readSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	logging.V(9).Infof("SnapshotManager: readSnapshotMutation.End(..., %v)", successful)
-	return rsm.manager.mutate(func() bool {
+	return rsm.manager.mutate(endJournalEntry(step, successful), func() bool {
 		rsm.manager.markOperationComplete(step.New())
 		if successful {
 			if step.Old() != nil {
+				if step.Old().Outputs.DeepEquals(step.New().Outputs) {
+					stampSame(step.Old(), step.New())
+				} else {
+					stampModified(step.Old(), step.New())
+				}
 				rsm.manager.markDone(step.Old())
+			} else {
+				stampCreated(step.New())
 			}
 
 			rsm.manager.markNew(step.New())
@@ -461,9 +504,30 @@ func (rsm *refreshSnapshotMutation) End(step deploy.Step, successful bool) error
 	contract.Require(step != nil, "step != nil")
 	contract.Require(step.Op() == deploy.OpRefresh, "step.Op() == deploy.OpRefresh")
 	logging.V(9).Infof("SnapshotManager: refreshSnapshotMutation.End(..., %v)", successful)
-	return rsm.manager.mutate(func() bool {
-		// We always elide refreshes. The expectation is that all of these run before any actual mutations and that
-		// some other component will rewrite the base snapshot in-memory, so there's no action the snapshot
+	return rsm.manager.mutate(endJournalEntry(step, successful), func() bool {
+		// A refresh only modifies a resource's state if it finds that the resource has changed.
+		if successful && step.New() != nil && step.New() != step.Old() {
+			if step.(*deploy.RefreshStep).ResultOp() == deploy.OpUpdate {
+				stampModified(step.Old(), step.New())
+			} else {
+				stampSame(step.Old(), step.New())
+			}
+		}
+
+		// The refreshes of the resources registered by the program during a refresh that runs the program are
+		// recorded like any other step.
+		if refresh, ok := step.(*deploy.RefreshStep); ok && refresh.Persisted() {
+			if successful {
+				rsm.manager.markDone(step.Old())
+				if step.New() != nil {
+					rsm.manager.markNew(step.New())
+				}
+			}
+			return true
+		}
+
+		// Otherwise, we always elide refreshes. The expectation is that all of these run before any actual mutations
+		// and that some other component will rewrite the base snapshot in-memory, so there's no action the snapshot
 		// manager needs to take other than to remember that the base snapshot--and therefore the actual snapshot--may
 		// have changed.
 		return false
@@ -477,7 +541,7 @@ type removePendingReplaceSnapshotMutation struct {
 func (rsm *removePendingReplaceSnapshotMutation) End(step deploy.Step, successful bool) error {
 	contract.Require(step != nil, "step != nil")
 	contract.Require(step.Op() == deploy.OpRemovePendingReplace, "step.Op() == deploy.OpRemovePendingReplace")
-	return rsm.manager.mutate(func() bool {
+	return rsm.manager.mutate(endJournalEntry(step, successful), func() bool {
 		res := step.Old()
 		contract.Assert(res.PendingReplacement)
 		rsm.manager.markDone(res)
@@ -487,7 +551,7 @@ func (rsm *removePendingReplaceSnapshotMutation) End(step deploy.Step, successfu
 
 func (sm *SnapshotManager) doImport(step deploy.Step) (engine.SnapshotMutation, error) {
 	logging.V(9).Infof("SnapshotManager.doImport(%s)", step.URN())
-	err := sm.mutate(func() bool {
+	err := sm.mutate(journalEntry(engine.JournalEntryBegin, step), func() bool {
 		sm.markOperationPending(step.New(), resource.OperationTypeImporting)
 		return true
 	})
@@ -507,15 +571,33 @@ func (ism *importSnapshotMutation) End(step deploy.Step, successful bool) error
 	contract.Require(step.Op() == deploy.OpImport || step.Op() == deploy.OpImportReplacement,
 		"step.Op() == deploy.OpImport || step.Op() == deploy.OpImportReplacement")
 
-	return ism.manager.mutate(func() bool {
+	return ism.manager.mutate(endJournalEntry(step, successful), func() bool {
 		ism.manager.markOperationComplete(step.New())
 		if successful {
+			stampCreated(step.New())
 			ism.manager.markNew(step.New())
 		}
 		return true
 	})
 }
 
+// stampCreated records that the given resource state was created or imported just now.
+func stampCreated(new *resource.State) {
+	now := time.Now().UTC()
+	new.Created, new.Modified = &now, &now
+}
+
+// stampModified records that the given resource state was changed from the given old state just now.
+func stampModified(old, new *resource.State) {
+	now := time.Now().UTC()
+	new.Created, new.Modified = old.Created, &now
+}
+
+// stampSame records that the given resource state is unchanged from the given old state.
+func stampSame(old, new *resource.State) {
+	new.Created, new.Modified = old.Created, old.Modified
+}
+
 // markDone marks a resource as having been processed. Resources that have been marked
 // in this manner won't be persisted in the snapshot.
 func (sm *SnapshotManager) markDone(state *resource.State) {
@@ -593,12 +675,7 @@ func (sm *SnapshotManager) snap() *deploy.Snapshot {
 	}
 
 	// Record any pending operations, if there are any outstanding that have not completed yet.
-	var operations []resource.Operation
-	for _, op := range sm.operations {
-		if !sm.completeOps[op.Resource] {
-			operations = append(operations, op)
-		}
-	}
+	operations := sm.inflightOperations()
 
 	// Track pending create operations from the base snapshot
 	// and propagate them to the new snapshot: we don't want to clear pending CREATE operations
@@ -621,9 +698,24 @@ func (sm *SnapshotManager) snap() *deploy.Snapshot {
 	return deploy.NewSnapshot(manifest, sm.persister.SecretsManager(), resources, operations)
 }
 
+// inflightOperations returns the operations begun by this update that have not completed yet.
+func (sm *SnapshotManager) inflightOperations() []resource.Operation {
+	var operations []resource.Operation
+	for _, op := range sm.operations {
+		if !sm.completeOps[op.Resource] {
+			operations = append(operations, op)
+		}
+	}
+	return operations
+}
+
 // saveSnapshot persists the current snapshot and optionally verifies it afterwards.
 func (sm *SnapshotManager) saveSnapshot() error {
-	snap := sm.snap()
+	return sm.writeSnapshot(sm.snap())
+}
+
+// writeSnapshot persists the given snapshot and optionally verifies it afterwards.
+func (sm *SnapshotManager) writeSnapshot(snap *deploy.Snapshot) error {
 	if err := snap.NormalizeURNReferences(); err != nil {
 		return fmt.Errorf("failed to normalize URN references: %w", err)
 	}
@@ -638,8 +730,80 @@ func (sm *SnapshotManager) saveSnapshot() error {
 	return nil
 }
 
+// journalMutation applies a mutation and records its journal entry. Every entry is journaled, even
+// if the corresponding checkpoint write could have been elided, since replaying the journal must
+// observe every step. A full snapshot is saved whenever the journal has accumulated enough entries
+// since the last one, and the journal is then restarted relative to the saved snapshot so that it
+// doesn't grow without bound.
+//
+// The journal is relative to the base snapshot, so it is started lazily by the first journaled
+// mutation. Refreshes rebuild the base snapshot in memory rather than producing new resources, so
+// they are not journaled; once a refresh has happened, the rebuilt base snapshot is saved and the
+// journal is restarted relative to it.
+func (sm *SnapshotManager) journalMutation(request mutationRequest) (bool, error) {
+	j := sm.journal
+	mustWrite := request.mutator()
+
+	if request.entry == nil {
+		return mustWrite, nil
+	}
+	if request.entry.Step.Op() == deploy.OpRefresh {
+		// Refreshes are saved in full, as they would be without a journal.
+		j.stale = true
+		if mustWrite {
+			return false, sm.saveSnapshot()
+		}
+		return true, nil
+	}
+
+	if !j.started || j.stale {
+		if j.stale {
+			if err := sm.saveSnapshot(); err != nil {
+				return false, err
+			}
+		}
+		if err := j.begin(sm.baseSnapshot); err != nil {
+			return false, err
+		}
+		j.pending = 0
+	}
+
+	if err := j.append(*request.entry, sm.persister.SecretsManager()); err != nil {
+		return false, err
+	}
+
+	if j.pending >= j.persister.JournalCompactionInterval() {
+		logging.V(9).Infof("SnapshotManager: compacting journal after %d entries", j.pending)
+		snap := sm.snap()
+		if err := sm.writeSnapshot(snap); err != nil {
+			return false, err
+		}
+		err := j.restart(snap, len(sm.resources), sm.inflightOperations(), sm.persister.SecretsManager())
+		return false, err
+	}
+	return true, nil
+}
+
+// closeJournal saves a full snapshot including every journaled mutation and then discards the
+// journal.
+func (sm *SnapshotManager) closeJournal(hasElidedWrites bool) error {
+	j := sm.journal
+	if j.pending > 0 || hasElidedWrites {
+		if err := sm.saveSnapshot(); err != nil {
+			return err
+		}
+	}
+	if j.started {
+		if err := j.persister.EndJournal(); err != nil {
+			return fmt.Errorf("failed to end journal: %w", err)
+		}
+	}
+	return nil
+}
+
 // NewSnapshotManager creates a new SnapshotManager for the given stack name, using the given persister
-// and base snapshot.
+// and base snapshot. If the persister implements JournalPersister, mutations are persisted
+// incrementally using its journal.
 //
 // It is *very important* that the baseSnap pointer refers to the same Snapshot
 // given to the engine! The engine will mutate this object and correctness of the
@@ -657,6 +821,9 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 		cancel:           cancel,
 		done:             done,
 	}
+	if journal, ok := persister.(JournalPersister); ok {
+		manager.journal = &snapshotJournal{persister: journal}
+	}
 
 	go func() {
 		// True if we have elided writes since the last actual write.
@@ -668,7 +835,13 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 			select {
 			case request := <-mutationRequests:
 				var err error
-				if request.mutator() {
+				if manager.journal != nil {
+					// The journal is compacted into a full snapshot periodically, so any mutations
+					// recorded since then are only flushed to the snapshot on Close.
+					var journaled bool
+					journaled, err = manager.journalMutation(request)
+					hasElidedWrites = hasElidedWrites || journaled
+				} else if request.mutator() {
 					err = manager.saveSnapshot()
 					hasElidedWrites = false
 				} else {
@@ -682,7 +855,10 @@ func NewSnapshotManager(persister SnapshotPersister, baseSnap *deploy.Snapshot)
 
 		// If we still have elided writes once the channel has closed, flush the snapshot.
 		var err error
-		if hasElidedWrites {
+		if manager.journal != nil {
+			logging.V(9).Infof("SnapshotManager: flushing journal...")
+			err = manager.closeJournal(hasElidedWrites)
+		} else if hasElidedWrites {
 			logging.V(9).Infof("SnapshotManager: flushing elided writes...")
 			err = manager.saveSnapshot()
 		}
@@ -541,7 +541,7 @@ func TestDeletion(t *testing.T) {
 	})
 
 	manager, sp := MockSetup(t, snap)
-	step := deploy.NewDeleteStep(nil, resourceA)
+	step := deploy.NewDeleteStep(nil, nil, resourceA)
 	mutation, err := manager.BeginMutation(step)
 	if !assert.NoError(t, err) {
 		t.FailNow()
@@ -567,7 +567,7 @@ func TestFailedDelete(t *testing.T) {
 	})
 
 	manager, sp := MockSetup(t, snap)
-	step := deploy.NewDeleteStep(nil, resourceA)
+	step := deploy.NewDeleteStep(nil, nil, resourceA)
 	mutation, err := manager.BeginMutation(step)
 	if !assert.NoError(t, err) {
 		t.FailNow()
@@ -740,7 +740,7 @@ func TestRecordingDeleteSuccess(t *testing.T) {
 		resourceA,
 	})
 	manager, sp := MockSetup(t, snap)
-	step := deploy.NewDeleteStep(nil, resourceA)
+	step := deploy.NewDeleteStep(nil, nil, resourceA)
 	mutation, err := manager.BeginMutation(step)
 	if !assert.NoError(t, err) {
 		t.FailNow()
@@ -772,7 +772,7 @@ func TestRecordingDeleteFailure(t *testing.T) {
 		resourceA,
 	})
 	manager, sp := MockSetup(t, snap)
-	step := deploy.NewDeleteStep(nil, resourceA)
+	step := deploy.NewDeleteStep(nil, nil, resourceA)
 	mutation, err := manager.BeginMutation(step)
 	if !assert.NoError(t, err) {
 		t.FailNow()
@@ -984,3 +984,66 @@ func TestRegisterOutputs(t *testing.T) {
 	assert.Len(t, lastSnap.Resources, 1)
 	assert.Equal(t, resourceA.URN, lastSnap.Resources[0].URN)
 }
+
+func TestRecordingTimestamps(t *testing.T) {
+	t.Parallel()
+
+	// A successful create should record when the resource was created.
+	resourceA := NewResource("a")
+	manager, sp := MockSetup(t, NewSnapshot(nil))
+	step := deploy.NewCreateStep(nil, &MockRegisterResourceEvent{}, resourceA)
+	mutation, err := manager.BeginMutation(step)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	err = mutation.End(step, true /* successful */)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	created := sp.LastSnap().Resources[0]
+	if !assert.NotNil(t, created.Created) {
+		t.FailNow()
+	}
+	assert.Equal(t, created.Created, created.Modified)
+
+	// An identical same should leave both timestamps alone.
+	sameA := NewResource("a")
+	manager, sp = MockSetup(t, NewSnapshot([]*resource.State{created}))
+	same := deploy.NewSameStep(nil, nil, created, sameA)
+	mutation, err = manager.BeginMutation(same)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	err = mutation.End(same, true /* successful */)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	err = manager.Close()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, created.Created, sp.LastSnap().Resources[0].Created)
+	assert.Equal(t, created.Modified, sp.LastSnap().Resources[0].Modified)
+
+	// A successful update should keep the creation time, but record when the resource was modified.
+	updatedA := NewResource("a")
+	updatedA.Inputs["key"] = resource.NewStringProperty("new")
+	manager, sp = MockSetup(t, NewSnapshot([]*resource.State{sameA}))
+	update := deploy.NewUpdateStep(nil, &MockRegisterResourceEvent{}, sameA, updatedA, nil, nil, nil, nil)
+	mutation, err = manager.BeginMutation(update)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	err = mutation.End(update, true /* successful */)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	updated := sp.LastSnap().Resources[0]
+	assert.Equal(t, created.Created, updated.Created)
+	if assert.NotNil(t, updated.Modified) {
+		assert.False(t, updated.Modified.Before(*created.Modified))
+		assert.NotSame(t, created.Modified, updated.Modified)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// Lock acquires the lock on a stack. A stack's lock is a row in the locks table, so acquiring it is atomic: of any
+// number of processes trying to insert the row at the same time, only one succeeds.
+func (b *sqlBackend) Lock(ctx context.Context, stackRef backend.StackReference) error {
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return err
+	}
+
+	pid := os.Getpid()
+	var username string
+	if u, err := user.Current(); err == nil {
+		username = u.Username
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	_, insertErr := b.db.ExecContext(ctx, b.dialect.rebind(
+		`INSERT INTO pulumi_stack_locks (project, name, lock_id, pid, username, hostname, acquired_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		string(ref.project), string(ref.name), b.lockID, pid, username, hostname, time.Now().Unix())
+	if insertErr == nil {
+		return nil
+	}
+
+	// The insert most likely failed because the stack is already locked. Look up the lock to tell the user who
+	// holds it.
+	var lockPid int
+	var lockUser, lockHost string
+	var acquiredAt int64
+	err = b.db.QueryRowContext(ctx, b.dialect.rebind(
+		`SELECT pid, username, hostname, acquired_at FROM pulumi_stack_locks WHERE project = ? AND name = ?`),
+		string(ref.project), string(ref.name)).Scan(&lockPid, &lockUser, &lockHost, &acquiredAt)
+	if err != nil {
+		// The lock isn't there, so something else went wrong.
+		return fmt.Errorf("locking stack '%s': %w", ref, insertErr)
+	}
+	return fmt.Errorf("the stack is currently locked by %v@%v (pid %v) since %v. Either wait for the other "+
+		"process to end or run `pulumi cancel` to remove the lock.",
+		lockUser, lockHost, lockPid, time.Unix(acquiredAt, 0).Format(time.RFC3339))
+}
+
+func (b *sqlBackend) Unlock(ctx context.Context, stackRef backend.StackReference) {
+	ref, err := b.getReference(stackRef)
+	contract.AssertNoError(err)
+
+	_, err = b.db.ExecContext(ctx, b.dialect.rebind(
+		`DELETE FROM pulumi_stack_locks WHERE project = ? AND name = ? AND lock_id = ?`),
+		string(ref.project), string(ref.name), b.lockID)
+	if err != nil {
+		b.d.Errorf(
+			diag.Message("", "there was a problem releasing the lock on stack '%s', "+
+				"manual clean up may be required: %v"), ref, err)
+	}
+}
+
+// checkLockTx returns an error if this backend doesn't hold the lock on a stack, e.g. because the lock was removed
+// by `pulumi cancel`.
+func (b *sqlBackend) checkLockTx(ctx context.Context, tx *sql.Tx, ref *sqlBackendReference) error {
+	var lockID string
+	err := tx.QueryRowContext(ctx, b.dialect.rebind(
+		`SELECT lock_id FROM pulumi_stack_locks WHERE project = ? AND name = ?`),
+		string(ref.project), string(ref.name)).Scan(&lockID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || lockID != b.lockID {
+		return fmt.Errorf("the lock on stack '%s' is no longer held, perhaps because the update was canceled; "+
+			"refusing to save its state", ref)
+	}
+	return nil
+}
+
+func (b *sqlBackend) CancelCurrentUpdate(ctx context.Context, stackRef backend.StackReference) error {
+	ref, err := b.getReference(stackRef)
+	if err != nil {
+		return err
+	}
+
+	// Removing the lock prevents the process running the update from saving any more changes to the stack.
+	_, err = b.db.ExecContext(ctx, b.dialect.rebind(
+		`DELETE FROM pulumi_stack_locks WHERE project = ? AND name = ?`),
+		string(ref.project), string(ref.name))
+	return err
+}
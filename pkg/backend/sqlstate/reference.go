@@ -0,0 +1,93 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// organizationName is the only organization name accepted in fully qualified stack references. As with the filestate
+// backend, there is no notion of organizations, but we accept the same `organization/project/stack` form as the
+// service.
+const organizationName = "organization"
+
+// sqlBackendReference is a reference to a stack stored in a SQL database. Stacks are always scoped by project.
+type sqlBackendReference struct {
+	name    tokens.Name
+	project tokens.Name
+	b       *sqlBackend
+}
+
+func (r *sqlBackendReference) String() string {
+	// References to stacks in the current project are displayed using just the name.
+	if r.b != nil && r.b.currentProject != nil && string(r.project) == string(r.b.currentProject.Name) {
+		return string(r.name)
+	}
+	return r.FullyQualifiedName()
+}
+
+func (r *sqlBackendReference) Name() tokens.Name {
+	return r.name
+}
+
+// Project returns the name of the project the stack belongs to.
+func (r *sqlBackendReference) Project() tokens.Name {
+	return r.project
+}
+
+// FullyQualifiedName returns the `organization/project/stack` form of the reference.
+func (r *sqlBackendReference) FullyQualifiedName() string {
+	return fmt.Sprintf("%s/%s/%s", organizationName, r.project, r.name)
+}
+
+func (b *sqlBackend) parseStackReference(stackRef string) (*sqlBackendReference, error) {
+	var name, project string
+	split := strings.Split(stackRef, "/")
+	switch len(split) {
+	case 1:
+		name = split[0]
+	case 2:
+		if split[0] != organizationName {
+			return nil, fmt.Errorf("organizations are not supported by the SQL backend; "+
+				"use '%s/<project>/<stack>' to refer to a stack in another project", organizationName)
+		}
+		name = split[1]
+	case 3:
+		if split[0] != organizationName {
+			return nil, fmt.Errorf("organizations are not supported by the SQL backend; "+
+				"use '%s/<project>/<stack>' to refer to a stack in another project", organizationName)
+		}
+		project, name = split[1], split[2]
+	default:
+		return nil, fmt.Errorf("could not parse stack reference '%s'", stackRef)
+	}
+
+	if project == "" {
+		if b.currentProject == nil {
+			return nil, fmt.Errorf("no current project found; pass the fully qualified stack name "+
+				"(%s/<project>/<stack>)", organizationName)
+		}
+		project = string(b.currentProject.Name)
+	}
+
+	if !tokens.IsName(project) {
+		return nil, fmt.Errorf("project name '%s' is not valid", project)
+	}
+
+	return &sqlBackendReference{name: tokens.Name(name), project: tokens.Name(project), b: b}, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// dialect describes the differences between the SQL databases we support.
+type dialect struct {
+	// driver is the name of the database/sql driver to use.
+	driver string
+	// numberedParams is true if the database uses numbered query parameters ($1, $2, ...) rather than `?`.
+	numberedParams bool
+	// migrationLock is a statement that serializes concurrent migrations for the rest of a transaction, if the
+	// database's transactions do not already do so.
+	migrationLock string
+}
+
+var (
+	postgresDialect = dialect{
+		driver:         "postgres",
+		numberedParams: true,
+		migrationLock:  `SELECT pg_advisory_xact_lock(123649633119593)`, // "pulumi" as an integer
+	}
+	// SQLite transactions are started with _txlock=immediate, so they already exclude each other.
+	sqliteDialect = dialect{driver: "sqlite"}
+)
+
+// rebind rewrites a query written with `?` parameters to use the parameter syntax of the dialect. Queries must not
+// contain literal question marks.
+func (d dialect) rebind(query string) string {
+	if !d.numberedParams {
+		return query
+	}
+
+	var sb strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			sb.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// migrations are the statements that create the backend's schema, in order. The schema version recorded in the
+// database is the number of migrations that have been applied. Migrations must never be changed once released; add
+// new ones to the end instead. The statements must be valid for every supported dialect.
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS pulumi_stacks (
+		project TEXT NOT NULL,
+		name TEXT NOT NULL,
+		checkpoint TEXT NOT NULL,
+		resource_count INTEGER NOT NULL,
+		updated_at BIGINT NOT NULL,
+		PRIMARY KEY (project, name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS pulumi_stack_tags (
+		project TEXT NOT NULL,
+		name TEXT NOT NULL,
+		tag TEXT NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY (project, name, tag)
+	)`,
+	`CREATE TABLE IF NOT EXISTS pulumi_stack_history (
+		project TEXT NOT NULL,
+		name TEXT NOT NULL,
+		version INTEGER NOT NULL,
+		info TEXT NOT NULL,
+		checkpoint TEXT NOT NULL,
+		PRIMARY KEY (project, name, version)
+	)`,
+	`CREATE TABLE IF NOT EXISTS pulumi_stack_locks (
+		project TEXT NOT NULL,
+		name TEXT NOT NULL,
+		lock_id TEXT NOT NULL,
+		pid INTEGER NOT NULL,
+		username TEXT NOT NULL,
+		hostname TEXT NOT NULL,
+		acquired_at BIGINT NOT NULL,
+		PRIMARY KEY (project, name)
+	)`,
+}
+
+// migrate brings the schema of the database up to date. The whole migration, including the creation of the table
+// that records the schema version, happens in a single transaction so that concurrent CLIs cannot interleave.
+func (b *sqlBackend) migrate(ctx context.Context) error {
+	return b.inTx(ctx, func(tx *sql.Tx) error {
+		if b.dialect.migrationLock != "" {
+			if _, err := tx.ExecContext(ctx, b.dialect.migrationLock); err != nil {
+				return fmt.Errorf("locking the database schema: %w", err)
+			}
+		}
+
+		// The schema version table holds a single row, which the primary key's check constraint enforces.
+		if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS pulumi_schema_version (
+			id INTEGER NOT NULL PRIMARY KEY CHECK (id = 1),
+			version INTEGER NOT NULL
+		)`); err != nil {
+			return fmt.Errorf("creating schema version table: %w", err)
+		}
+
+		var version int
+		err := tx.QueryRowContext(ctx, `SELECT version FROM pulumi_schema_version WHERE id = 1`).Scan(&version)
+		switch {
+		case err == sql.ErrNoRows:
+			if _, err := tx.ExecContext(ctx, `INSERT INTO pulumi_schema_version (id, version) VALUES (1, 0)`); err != nil {
+				return err
+			}
+		case err != nil:
+			return fmt.Errorf("reading schema version: %w", err)
+		}
+
+		if version > len(migrations) {
+			return fmt.Errorf("the database schema (version %d) is newer than this version of the CLI supports "+
+				"(version %d); please upgrade the Pulumi CLI", version, len(migrations))
+		}
+
+		for i := version; i < len(migrations); i++ {
+			if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+				return fmt.Errorf("applying schema migration %d: %w", i+1, err)
+			}
+			logging.V(7).Infof("applied schema migration %d", i+1)
+		}
+		_, err = tx.ExecContext(ctx,
+			b.dialect.rebind(`UPDATE pulumi_schema_version SET version = ? WHERE id = 1`), len(migrations))
+		return err
+	})
+}
+
+// inTx runs a function in a transaction, which is committed if the function succeeds and rolled back otherwise.
+func (b *sqlBackend) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logging.V(5).Infof("error rolling back transaction: %v", rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
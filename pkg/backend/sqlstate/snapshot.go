@@ -0,0 +1,38 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"context"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+)
+
+// sqlSnapshotPersister is a simple SnapshotPersister implementation that persists snapshots to the database. Each
+// snapshot is written in a single transaction, so a checkpoint is never partially written.
+type sqlSnapshotPersister struct {
+	ref     *sqlBackendReference
+	backend *sqlBackend
+	sm      secrets.Manager
+}
+
+func (sp *sqlSnapshotPersister) SecretsManager() secrets.Manager {
+	return sp.sm
+}
+
+func (sp *sqlSnapshotPersister) Save(snapshot *deploy.Snapshot) error {
+	return sp.backend.saveStack(context.TODO(), sp.ref, snapshot, sp.sm)
+}
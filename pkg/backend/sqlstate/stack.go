@@ -0,0 +1,136 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"context"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/operations"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+// Stack is a stack stored in a SQL database. This simply adds some SQL-specific properties atop the standard backend
+// stack interface.
+type Stack interface {
+	backend.Stack
+	sqlState() // at the moment, no SQL specific info, so just use a marker function.
+}
+
+// sqlStack is a SQL stack descriptor.
+type sqlStack struct {
+	ref      backend.StackReference          // the stack's reference (qualified name).
+	snapshot *deploy.Snapshot                // a snapshot representing the latest deployment state.
+	tags     map[apitype.StackTagName]string // the stack's tags.
+	b        *sqlBackend                     // a pointer to the backend this stack belongs to.
+}
+
+func newStack(ref backend.StackReference, snapshot *deploy.Snapshot,
+	tags map[apitype.StackTagName]string, b *sqlBackend) Stack {
+	return &sqlStack{
+		ref:      ref,
+		snapshot: snapshot,
+		tags:     tags,
+		b:        b,
+	}
+}
+
+func (s *sqlStack) sqlState() {}
+
+func (s *sqlStack) Ref() backend.StackReference                            { return s.ref }
+func (s *sqlStack) Snapshot(ctx context.Context) (*deploy.Snapshot, error) { return s.snapshot, nil }
+func (s *sqlStack) Backend() backend.Backend                               { return s.b }
+func (s *sqlStack) Tags() map[apitype.StackTagName]string                  { return s.tags }
+
+func (s *sqlStack) Remove(ctx context.Context, force bool) (bool, error) {
+	return backend.RemoveStack(ctx, s, force)
+}
+
+func (s *sqlStack) Rename(ctx context.Context, newName tokens.QName) (backend.StackReference, error) {
+	return backend.RenameStack(ctx, s, newName)
+}
+
+func (s *sqlStack) Preview(
+	ctx context.Context,
+	op backend.UpdateOperation) (*deploy.Plan, display.ResourceChanges, result.Result) {
+
+	return backend.PreviewStack(ctx, s, op)
+}
+
+func (s *sqlStack) Update(ctx context.Context, op backend.UpdateOperation) (display.ResourceChanges, result.Result) {
+	return backend.UpdateStack(ctx, s, op)
+}
+
+func (s *sqlStack) Import(ctx context.Context, op backend.UpdateOperation,
+	imports []deploy.Import) (display.ResourceChanges, result.Result) {
+	return backend.ImportStack(ctx, s, op, imports)
+}
+
+func (s *sqlStack) Refresh(ctx context.Context, op backend.UpdateOperation) (display.ResourceChanges, result.Result) {
+	return backend.RefreshStack(ctx, s, op)
+}
+
+func (s *sqlStack) Destroy(ctx context.Context, op backend.UpdateOperation) (display.ResourceChanges, result.Result) {
+	return backend.DestroyStack(ctx, s, op)
+}
+
+func (s *sqlStack) Watch(ctx context.Context, op backend.UpdateOperation, paths []string) result.Result {
+	return backend.WatchStack(ctx, s, op, paths)
+}
+
+func (s *sqlStack) GetLogs(ctx context.Context, cfg backend.StackConfiguration,
+	query operations.LogQuery) ([]operations.LogEntry, error) {
+	return backend.GetStackLogs(ctx, s, cfg, query)
+}
+
+func (s *sqlStack) ExportDeployment(ctx context.Context) (*apitype.UntypedDeployment, error) {
+	return backend.ExportStackDeployment(ctx, s)
+}
+
+func (s *sqlStack) ImportDeployment(ctx context.Context, deployment *apitype.UntypedDeployment) error {
+	return backend.ImportStackDeployment(ctx, s, deployment)
+}
+
+type sqlStackSummary struct {
+	name          backend.StackReference
+	resourceCount int
+	updatedAt     int64
+}
+
+func newSQLStackSummary(name backend.StackReference, resourceCount int, updatedAt int64) sqlStackSummary {
+	return sqlStackSummary{name: name, resourceCount: resourceCount, updatedAt: updatedAt}
+}
+
+func (ss sqlStackSummary) Name() backend.StackReference {
+	return ss.name
+}
+
+func (ss sqlStackSummary) LastUpdate() *time.Time {
+	if ss.updatedAt == 0 {
+		return nil
+	}
+	t := time.Unix(ss.updatedAt, 0)
+	return &t
+}
+
+func (ss sqlStackSummary) ResourceCount() *int {
+	count := ss.resourceCount
+	return &count
+}
@@ -0,0 +1,439 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstate
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+type sqlQuery struct {
+	root string
+	proj *workspace.Project
+}
+
+func (q *sqlQuery) GetRoot() string {
+	return q.root
+}
+
+func (q *sqlQuery) GetProject() *workspace.Project {
+	return q.proj
+}
+
+// update is an implementation of engine.Update backed by a SQL database.
+type update struct {
+	root    string
+	proj    *workspace.Project
+	target  *deploy.Target
+	backend *sqlBackend
+}
+
+func (u *update) GetRoot() string {
+	return u.root
+}
+
+func (u *update) GetProject() *workspace.Project {
+	return u.proj
+}
+
+func (u *update) GetTarget() *deploy.Target {
+	return u.target
+}
+
+func (b *sqlBackend) newQuery(ctx context.Context,
+	op backend.QueryOperation) (engine.QueryInfo, error) {
+
+	return &sqlQuery{root: op.Root, proj: op.Proj}, nil
+}
+
+func (b *sqlBackend) newUpdate(ctx context.Context, ref *sqlBackendReference,
+	op backend.UpdateOperation) (*update, error) {
+
+	contract.Require(ref != nil, "ref")
+
+	// Construct the deployment target.
+	target, err := b.getTarget(ctx, ref, op.StackConfiguration.Config, op.StackConfiguration.Decrypter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Construct and return a new update.
+	return &update{
+		root:    op.Root,
+		proj:    op.Proj,
+		target:  target,
+		backend: b,
+	}, nil
+}
+
+func (b *sqlBackend) getTarget(ctx context.Context, ref *sqlBackendReference, cfg config.Map,
+	dec config.Decrypter) (*deploy.Target, error) {
+
+	snapshot, err := b.getStack(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &deploy.Target{
+		Name:      ref.Name(),
+		Config:    cfg,
+		Decrypter: dec,
+		Snapshot:  snapshot,
+	}, nil
+}
+
+// getStack loads the snapshot of a stack. It returns sql.ErrNoRows if the stack does not exist.
+func (b *sqlBackend) getStack(ctx context.Context, ref *sqlBackendReference) (*deploy.Snapshot, error) {
+	if ref == nil || ref.name == "" {
+		return nil, errors.New("invalid empty stack name")
+	}
+
+	var checkpoint string
+	err := b.db.QueryRowContext(ctx, b.dialect.rebind(
+		`SELECT checkpoint FROM pulumi_stacks WHERE project = ? AND name = ?`),
+		string(ref.project), string(ref.name)).Scan(&checkpoint)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpoint(encoding.JSON, []byte(checkpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	// Materialize an actual snapshot object.
+	snapshot, err := stack.DeserializeCheckpoint(chk)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the snapshot passes verification before returning it, to catch bugs early.
+	if !filestate.DisableIntegrityChecking {
+		if verifyerr := snapshot.VerifyIntegrity(); verifyerr != nil {
+			return nil, fmt.Errorf("%s: snapshot integrity failure; refusing to use it: %w", ref, verifyerr)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// marshalCheckpoint serializes a snapshot into the checkpoint stored in the database, and returns the number of
+// resources in it.
+func marshalCheckpoint(ref *sqlBackendReference, snap *deploy.Snapshot, sm secrets.Manager) (string, int, error) {
+	chk, err := stack.SerializeCheckpoint(ref.Name(), snap, sm, false /* showSecrets */)
+	if err != nil {
+		return "", 0, fmt.Errorf("serializing checkpoint: %w", err)
+	}
+	byts, err := encoding.JSON.Marshal(chk)
+	if err != nil {
+		return "", 0, fmt.Errorf("marshalling checkpoint: %w", err)
+	}
+	count := 0
+	if snap != nil {
+		count = len(snap.Resources)
+	}
+	return string(byts), count, nil
+}
+
+// createStack creates a new, empty stack with the given tags.
+func (b *sqlBackend) createStack(ctx context.Context, ref *sqlBackendReference,
+	tags map[apitype.StackTagName]string) error {
+
+	checkpoint, count, err := marshalCheckpoint(ref, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return b.inTx(ctx, func(tx *sql.Tx) error {
+		var exists int
+		err := tx.QueryRowContext(ctx, b.dialect.rebind(
+			`SELECT COUNT(*) FROM pulumi_stacks WHERE project = ? AND name = ?`),
+			string(ref.project), string(ref.name)).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists > 0 {
+			return &backend.StackAlreadyExistsError{StackName: string(ref.name)}
+		}
+
+		if _, err := tx.ExecContext(ctx, b.dialect.rebind(
+			`INSERT INTO pulumi_stacks (project, name, checkpoint, resource_count, updated_at) VALUES (?, ?, ?, ?, ?)`),
+			string(ref.project), string(ref.name), checkpoint, count, time.Now().Unix()); err != nil {
+			return fmt.Errorf("creating stack: %w", err)
+		}
+		return b.saveStackTagsTx(ctx, tx, ref, tags)
+	})
+}
+
+// saveStack saves the snapshot of a stack. The snapshot is only saved if this backend still holds the stack's lock,
+// which is checked in the same transaction that writes the snapshot.
+func (b *sqlBackend) saveStack(ctx context.Context, ref *sqlBackendReference, snap *deploy.Snapshot,
+	sm secrets.Manager) error {
+
+	checkpoint, count, err := marshalCheckpoint(ref, snap, sm)
+	if err != nil {
+		return err
+	}
+
+	err = b.inTx(ctx, func(tx *sql.Tx) error {
+		if err := b.checkLockTx(ctx, tx, ref); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, b.dialect.rebind(
+			`UPDATE pulumi_stacks SET checkpoint = ?, resource_count = ?, updated_at = ? WHERE project = ? AND name = ?`),
+			checkpoint, count, time.Now().Unix(), string(ref.project), string(ref.name))
+		if err != nil {
+			return fmt.Errorf("saving checkpoint: %w", err)
+		}
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			return fmt.Errorf("stack '%s' does not exist", ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	logging.V(7).Infof("Saved stack %s checkpoint", ref)
+
+	if !filestate.DisableIntegrityChecking && snap != nil {
+		// Finally, *after* writing the checkpoint, check the integrity. This is done afterwards so that we write
+		// out the checkpoint since it may contain resource state updates. But we will warn the user that the
+		// checkpoint is already written and might be bad.
+		if verifyerr := snap.VerifyIntegrity(); verifyerr != nil {
+			return fmt.Errorf("%s: snapshot integrity failure; it was already written, but is invalid: %w",
+				ref, verifyerr)
+		}
+	}
+	return nil
+}
+
+// removeStack removes a stack, along with its history and tags.
+func (b *sqlBackend) removeStack(ctx context.Context, ref *sqlBackendReference) error {
+	contract.Require(ref != nil, "ref")
+
+	return b.inTx(ctx, func(tx *sql.Tx) error {
+		for _, table := range []string{"pulumi_stacks", "pulumi_stack_history", "pulumi_stack_tags"} {
+			if _, err := tx.ExecContext(ctx, b.dialect.rebind(
+				`DELETE FROM `+table+` WHERE project = ? AND name = ?`),
+				string(ref.project), string(ref.name)); err != nil {
+				return fmt.Errorf("removing stack: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// renameStack moves a stack, along with its history and tags, to a new name, saving the given snapshot as its
+// checkpoint.
+func (b *sqlBackend) renameStack(ctx context.Context, oldRef, newRef *sqlBackendReference,
+	snap *deploy.Snapshot) error {
+
+	checkpoint, count, err := marshalCheckpoint(newRef, snap, nil)
+	if err != nil {
+		return err
+	}
+
+	return b.inTx(ctx, func(tx *sql.Tx) error {
+		if err := b.checkLockTx(ctx, tx, oldRef); err != nil {
+			return err
+		}
+
+		// Ensure the destination stack does not already exist.
+		var exists int
+		err := tx.QueryRowContext(ctx, b.dialect.rebind(
+			`SELECT COUNT(*) FROM pulumi_stacks WHERE project = ? AND name = ?`),
+			string(newRef.project), string(newRef.name)).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists > 0 {
+			return fmt.Errorf("a stack named %s already exists", newRef)
+		}
+
+		if _, err := tx.ExecContext(ctx, b.dialect.rebind(
+			`UPDATE pulumi_stacks SET project = ?, name = ?, checkpoint = ?, resource_count = ?, updated_at = ?
+			WHERE project = ? AND name = ?`),
+			string(newRef.project), string(newRef.name), checkpoint, count, time.Now().Unix(),
+			string(oldRef.project), string(oldRef.name)); err != nil {
+			return fmt.Errorf("renaming stack: %w", err)
+		}
+		for _, table := range []string{"pulumi_stack_history", "pulumi_stack_tags"} {
+			if _, err := tx.ExecContext(ctx, b.dialect.rebind(
+				`UPDATE `+table+` SET project = ?, name = ? WHERE project = ? AND name = ?`),
+				string(newRef.project), string(newRef.name),
+				string(oldRef.project), string(oldRef.name)); err != nil {
+				return fmt.Errorf("renaming stack: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// getHistory returns the update history of a stack. The first element of the result will be the most recent update
+// record.
+func (b *sqlBackend) getHistory(ctx context.Context, ref *sqlBackendReference,
+	pageSize int, page int) ([]backend.UpdateInfo, error) {
+
+	contract.Require(ref != nil, "ref")
+
+	query := `SELECT info FROM pulumi_stack_history WHERE project = ? AND name = ? ORDER BY version DESC`
+	args := []interface{}{string(ref.project), string(ref.name)}
+	if pageSize > 0 {
+		if page < 1 {
+			page = 1
+		}
+		query += ` LIMIT ? OFFSET ?`
+		args = append(args, pageSize, (page-1)*pageSize)
+	}
+
+	rows, err := b.db.QueryContext(ctx, b.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+	defer contract.IgnoreClose(rows)
+
+	var updates []backend.UpdateInfo
+	for rows.Next() {
+		var info string
+		if err := rows.Scan(&info); err != nil {
+			return nil, fmt.Errorf("reading history: %w", err)
+		}
+		var update backend.UpdateInfo
+		if err := json.Unmarshal([]byte(info), &update); err != nil {
+			return nil, fmt.Errorf("reading history: %w", err)
+		}
+		updates = append(updates, update)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+	return updates, nil
+}
+
+// getHistoryCheckpoint returns the checkpoint saved in the history of a stack for the update with the given version.
+func (b *sqlBackend) getHistoryCheckpoint(ctx context.Context, ref *sqlBackendReference,
+	version int) (*apitype.CheckpointV3, error) {
+
+	var checkpoint string
+	err := b.db.QueryRowContext(ctx, b.dialect.rebind(
+		`SELECT checkpoint FROM pulumi_stack_history WHERE project = ? AND name = ? AND version = ?`),
+		string(ref.project), string(ref.name), version).Scan(&checkpoint)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("version %d of stack %s was not found in its history", version, ref)
+		}
+		return nil, fmt.Errorf("reading checkpoint for version %d: %w", version, err)
+	}
+	return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(encoding.JSON, []byte(checkpoint))
+}
+
+// addToHistory saves the UpdateInfo along with a copy of the stack's current checkpoint. The update is numbered
+// after the last one in the history.
+func (b *sqlBackend) addToHistory(ctx context.Context, ref *sqlBackendReference, update backend.UpdateInfo) error {
+	contract.Require(ref != nil, "ref")
+
+	return b.inTx(ctx, func(tx *sql.Tx) error {
+		var version int
+		err := tx.QueryRowContext(ctx, b.dialect.rebind(
+			`SELECT COALESCE(MAX(version), 0) FROM pulumi_stack_history WHERE project = ? AND name = ?`),
+			string(ref.project), string(ref.name)).Scan(&version)
+		if err != nil {
+			return err
+		}
+		update.Version = version + 1
+
+		info, err := json.Marshal(&update)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, b.dialect.rebind(
+			`INSERT INTO pulumi_stack_history (project, name, version, info, checkpoint)
+			SELECT project, name, ?, ?, checkpoint FROM pulumi_stacks WHERE project = ? AND name = ?`),
+			update.Version, string(info), string(ref.project), string(ref.name))
+		return err
+	})
+}
+
+// getStackTags returns the tags for a stack.
+func (b *sqlBackend) getStackTags(ctx context.Context,
+	ref *sqlBackendReference) (map[apitype.StackTagName]string, error) {
+
+	rows, err := b.db.QueryContext(ctx, b.dialect.rebind(
+		`SELECT tag, value FROM pulumi_stack_tags WHERE project = ? AND name = ?`),
+		string(ref.project), string(ref.name))
+	if err != nil {
+		return nil, fmt.Errorf("reading tags for stack %s: %w", ref, err)
+	}
+	defer contract.IgnoreClose(rows)
+
+	tags := make(map[apitype.StackTagName]string)
+	for rows.Next() {
+		var tag, value string
+		if err := rows.Scan(&tag, &value); err != nil {
+			return nil, fmt.Errorf("reading tags for stack %s: %w", ref, err)
+		}
+		tags[tag] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading tags for stack %s: %w", ref, err)
+	}
+	return tags, nil
+}
+
+// saveStackTags replaces the tags for a stack.
+func (b *sqlBackend) saveStackTags(ctx context.Context,
+	ref *sqlBackendReference, tags map[apitype.StackTagName]string) error {
+
+	return b.inTx(ctx, func(tx *sql.Tx) error {
+		return b.saveStackTagsTx(ctx, tx, ref, tags)
+	})
+}
+
+func (b *sqlBackend) saveStackTagsTx(ctx context.Context, tx *sql.Tx,
+	ref *sqlBackendReference, tags map[apitype.StackTagName]string) error {
+
+	if _, err := tx.ExecContext(ctx, b.dialect.rebind(
+		`DELETE FROM pulumi_stack_tags WHERE project = ? AND name = ?`),
+		string(ref.project), string(ref.name)); err != nil {
+		return fmt.Errorf("writing tags for stack %s: %w", ref, err)
+	}
+	for tag, value := range tags {
+		if _, err := tx.ExecContext(ctx, b.dialect.rebind(
+			`INSERT INTO pulumi_stack_tags (project, name, tag, value) VALUES (?, ?, ?, ?)`),
+			string(ref.project), string(ref.name), tag, value); err != nil {
+			return fmt.Errorf("writing tags for stack %s: %w", ref, err)
+		}
+	}
+	return nil
+}
@@ -90,6 +90,10 @@ const (
 	ExecutionKind = "exec.kind"
 	// ExecutionAgent indicates the user agent of the updater for automated scenarios (GHA, Kubernetes Operator).
 	ExecutionAgent = "exec.agent"
+
+	// ProgramDependencyPrefix is the prefix of the keys that record the versions of the program's direct
+	// dependencies, as reported by its language plugin, e.g. "dependency.@pulumi/pulumi".
+	ProgramDependencyPrefix = "dependency."
 )
 
 // UpdateInfo describes a previous update.
@@ -15,17 +15,13 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"runtime"
 	"sort"
 	"strings"
@@ -37,13 +33,15 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/backend/state"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/version"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/executable"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/util/goversion"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/python"
 )
@@ -153,7 +151,7 @@ func getSummaryAbout(ctx context.Context, transitiveDependencies bool, selectedS
 		} else {
 			result.Runtime = &runtime
 		}
-		if deps, err := getProgramDependenciesAbout(ctx, proj, pwd, transitiveDependencies); err != nil {
+		if deps, err := getProgramDependenciesAbout(context.Background(), proj, pwd, transitiveDependencies); err != nil {
 			addError(err, "Failed to get information about the Pulumi program's plugins")
 		} else {
 			result.Dependencies = deps
@@ -432,395 +430,56 @@ type programDependencieAbout struct {
 	Version string `json:"version"`
 }
 
-type goModule struct {
-	Path     string
-	Version  string
-	Time     string
-	Indirect bool
-	Dir      string
-	GoMod    string
-	Main     bool
-}
-
-func getGoProgramDependencies(transitive bool) ([]programDependencieAbout, error) {
-	// go list -m ...
-	//
-	//Go has a --json flag, but it doesn't emit a single json object (which
-	//makes it invalid json).
-	ex, err := executable.FindExecutable("go")
-	if err != nil {
-		return nil, err
-	}
-	if err := goversion.CheckMinimumGoVersion(ex); err != nil {
-		return nil, err
-	}
-	cmdArgs := []string{"list", "--json", "-m", "..."}
-	cmd := exec.Command(ex, cmdArgs...)
-	var out []byte
-	if out, err = cmd.Output(); err != nil {
-		return nil, fmt.Errorf("Failed to get modules: %w", err)
-	}
-
-	dec := json.NewDecoder(bytes.NewReader(out))
-	parsed := []goModule{}
-	for {
-		var m goModule
-		if err := dec.Decode(&m); err != nil {
-			if err == io.EOF {
-				break
-			}
-			return nil, fmt.Errorf("Failed to parse \"%s %s\" output: %w", ex, strings.Join(cmdArgs, " "), err)
-		}
-		parsed = append(parsed, m)
-
-	}
-
-	result := []programDependencieAbout{}
-	for _, d := range parsed {
-		if (!d.Indirect || transitive) && !d.Main {
-			datum := programDependencieAbout{
-				Name:    d.Path,
-				Version: d.Version,
-			}
-			result = append(result, datum)
-		}
-	}
-	return result, nil
-}
-
-// Calls a python command as pulumi would. This means we need to accommodate for
-// a virtual environment if it exists.
-func callPythonCommand(ctx context.Context, proj *workspace.Project, root string, args ...string) (string, error) {
-	if proj == nil {
-		return "", errors.New("Project must not be nil")
-	}
-	options := proj.Runtime.Options()
-	if options == nil {
-		return callPythonCommandNoEnvironment(ctx, args...)
-	}
-	virtualEnv, exists := options["virtualenv"]
-	if !exists {
-		return callPythonCommandNoEnvironment(ctx, args...)
-	}
-	virtualEnvPath := virtualEnv.(string)
-	// We now know that a virtual environment exists.
-	if virtualEnv != "" && !filepath.IsAbs(virtualEnvPath) {
-		virtualEnvPath = filepath.Join(root, virtualEnvPath)
-	}
-	cmd := python.VirtualEnvCommand(virtualEnvPath, "python", args...)
-	result, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
-	return string(result), nil
-}
-
-// Call a python command in a runtime agnostic way. Call python from the path.
-// Do not use a virtual environment.
-func callPythonCommandNoEnvironment(ctx context.Context, args ...string) (string, error) {
-	cmd, err := python.Command(ctx, args...)
-	if err != nil {
-		return "", err
-	}
-
-	var result []byte
-	if result, err = cmd.Output(); err != nil {
-		return "", err
-	}
-	return string(result), nil
-}
-
-func getPythonProgramDependencies(ctx context.Context, proj *workspace.Project, rootDir string,
+// getProgramDependenciesAbout asks the project's language plugin for the packages that the program depends on.
+// getProgramDependenciesAbout asks the program's language host for the program's dependencies. If the given context
+// is done before the language host answers, the language host is shut down.
+func getProgramDependenciesAbout(cancelCtx context.Context, proj *workspace.Project, root string,
 	transitive bool) ([]programDependencieAbout, error) {
-	cmdArgs := []string{"-m", "pip", "list", "--format=json"}
-	if !transitive {
-		cmdArgs = append(cmdArgs, "--not-required")
-
-	}
-	out, err := callPythonCommand(ctx, proj, rootDir, cmdArgs...)
+	projinfo := &engine.Projinfo{Proj: proj, Root: root}
+	pwd, main, ctx, err := engine.ProjectInfoContext(projinfo, nil, nil, cmdutil.Diag(), cmdutil.Diag(), false, nil)
 	if err != nil {
 		return nil, err
 	}
-	var result []programDependencieAbout
-	err = json.Unmarshal([]byte(out), &result)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to parse \"python %s\" result: %w", strings.Join(cmdArgs, " "), err)
-	}
-
-	return result, nil
-}
+	defer ctx.Close()
 
-func getDotNetProgramDependencies(proj *workspace.Project, transitive bool) ([]programDependencieAbout, error) {
-	// dotnet list package
-
-	var err error
-	options := proj.Runtime.Options()
-	if options != nil {
-		if _, exists := options["binary"]; exists {
-			return nil, errors.New("Could not get dependencies because pulumi specifies a binary")
-		}
-	}
-	var ex string
-	var out []byte
-	ex, err = executable.FindExecutable("dotnet")
+	language := proj.Runtime.Name()
+	languagePlugin, err := ctx.Host.LanguageRuntime(language)
 	if err != nil {
-		return nil, err
-	}
-	cmdArgs := []string{"list", "package"}
-	if transitive {
-		cmdArgs = append(cmdArgs, "--include-transitive")
-	}
-	cmd := exec.Command(ex, cmdArgs...)
-	if out, err = cmd.Output(); err != nil {
-		return nil, fmt.Errorf("Failed to call \"%s\": %w", ex, err)
-	}
-	lines := strings.Split(strings.ReplaceAll(string(out), "\r\n", "\n"), "\n")
-	var packages []programDependencieAbout
-
-	for _, p := range lines {
-		p := strings.TrimSpace(p)
-		if strings.HasPrefix(p, ">") {
-			p = strings.TrimPrefix(p, "> ")
-			segments := strings.Split(p, " ")
-			var nameRequiredVersion []string
-			for _, s := range segments {
-				if s != "" {
-					nameRequiredVersion = append(nameRequiredVersion, s)
-				}
+		return nil, fmt.Errorf("failed to load language plugin %s: %w", language, err)
+	}
+
+	// Now that no more plugins will be loaded, close the plugin context if the operation is canceled, so that the
+	// language host doesn't outlive it.
+	if done := cancelCtx.Done(); done != nil {
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-done:
+				contract.IgnoreClose(ctx)
+			case <-stop:
 			}
-			var version int
-			if len(nameRequiredVersion) == 3 {
-				// Top level package => name required version
-				version = 2
-			} else if len(nameRequiredVersion) == 2 {
-				// Transitive package => name version
-				version = 1
-			} else {
-				return nil, fmt.Errorf("Failed to parse \"%s\"", p)
-			}
-			packages = append(packages, programDependencieAbout{
-				Name:    nameRequiredVersion[0],
-				Version: nameRequiredVersion[version],
-			})
-		}
-	}
-	return packages, nil
-}
-
-// The shape of a `yarn list --json`'s output.
-type yarnLock struct {
-	Type string       `json:"type"`
-	Data yarnLockData `json:"data"`
-}
-
-type yarnLockData struct {
-	Type  string         `json:"type"`
-	Trees []yarnLockTree `json:"trees"`
-}
-
-type yarnLockTree struct {
-	Name     string         `json:"name"`
-	Children []yarnLockTree `json:"children"`
-}
-
-func parseYarnLockFile(path string) ([]programDependencieAbout, error) {
-	ex, err := executable.FindExecutable("yarn")
-	if err != nil {
-		return nil, fmt.Errorf("Found %s but no yarn executable: %w", path, err)
+		}()
 	}
-	cmdArgs := []string{"list", "--json"}
-	cmd := exec.Command(ex, cmdArgs...)
-	out, err := cmd.Output()
+	deps, err := languagePlugin.GetProgramDependencies(plugin.ProgInfo{
+		Proj:    proj,
+		Pwd:     pwd,
+		Program: main,
+	}, transitive)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to run \"%s %s\": %w", ex, strings.Join(cmdArgs, " "), err)
-	}
-
-	var lock yarnLock
-	if err = json.Unmarshal(out, &lock); err != nil {
-		return nil, fmt.Errorf("Failed to parse\"%s %s\": %w", ex, strings.Join(cmdArgs, " "), err)
-	}
-	leafs := lock.Data.Trees
-
-	result := make([]programDependencieAbout, len(leafs))
-
-	// Has the form name@version
-	splitName := func(index int, nameVersion string) (string, string, error) {
-		if nameVersion == "" {
-			return "", "", fmt.Errorf("Expected \"name\" in dependency %d", index)
-		}
-		split := strings.LastIndex(nameVersion, "@")
-		if split == -1 {
-			return "", "", fmt.Errorf("Failed to parse name and version from %s", nameVersion)
-		}
-		return nameVersion[:split], nameVersion[split+1:], nil
+		return nil, fmt.Errorf("failed to get the program's dependencies: %w", err)
 	}
 
-	for i, v := range leafs {
-		name, version, err := splitName(i, v.Name)
-		if err != nil {
-			return nil, err
-		}
-
+	result := make([]programDependencieAbout, len(deps))
+	for i, dep := range deps {
 		result[i] = programDependencieAbout{
-			Name:    name,
-			Version: version,
-		}
-	}
-	return result, nil
-}
-
-// Describes the shape of `npm ls --json --depth=0`'s output.
-type npmFile struct {
-	Name            string                `json:"name"`
-	LockFileVersion int                   `json:"lockfileVersion"`
-	Requires        bool                  `json:"requires"`
-	Dependencies    map[string]npmPackage `json:"dependencies"`
-}
-
-// A package in npmFile.
-type npmPackage struct {
-	Version  string `json:"version"`
-	Resolved string `json:"resolved"`
-}
-
-func parseNpmLockFile(path string) ([]programDependencieAbout, error) {
-	ex, err := executable.FindExecutable("npm")
-	if err != nil {
-		return nil, fmt.Errorf("Found %s but not npm: %w", path, err)
-	}
-	cmdArgs := []string{"ls", "--json", "--depth=0"}
-	cmd := exec.Command(ex, cmdArgs...)
-	out, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf(`Failed to run "%s %s": %w`, ex, strings.Join(cmdArgs, " "), err)
-	}
-	file := npmFile{}
-	if err = json.Unmarshal(out, &file); err != nil {
-		return nil, fmt.Errorf(`Failed to parse \"%s %s": %w`, ex, strings.Join(cmdArgs, " "), err)
-	}
-	result := make([]programDependencieAbout, len(file.Dependencies))
-	var i int
-	for k, v := range file.Dependencies {
-		result[i].Name = k
-		result[i].Version = v.Version
-		i++
-	}
-	return result, nil
-}
-
-// The shape of package.json
-type packageJSON struct {
-	Name            string            `json:"name"`
-	Main            string            `json:"main"`
-	Dependencies    map[string]string `json:"dependencies"`
-	DevDependencies map[string]string `json:"devDependencies"`
-}
-
-// Intersect a list of packages with the contents of `package.json`. Returns
-// only packages that appear in both sets. `path` is used only for error handling.
-func crossCheckPackageJSONFile(path string, file []byte,
-	packages []programDependencieAbout) ([]programDependencieAbout, error) {
-
-	var body packageJSON
-	if err := json.Unmarshal(file, &body); err != nil {
-		return nil, fmt.Errorf("Could not parse %s: %w", path, err)
-	}
-	dependencies := make(map[string]string)
-	for k, v := range body.Dependencies {
-		dependencies[k] = v
-	}
-	for k, v := range body.DevDependencies {
-		dependencies[k] = v
-	}
-
-	// There should be 1 (& only 1) instantiated dependency for each
-	// dependency in package.json. We do this because we want to get the
-	// actual version (not the range) that exists in lock files.
-	result := make([]programDependencieAbout, len(dependencies))
-	i := 0
-	for _, v := range packages {
-		if _, exists := dependencies[v.Name]; exists {
-			result[i] = v
-			// Some direct dependencies are also transitive dependencies. We
-			// only want to grab them once.
-			delete(dependencies, v.Name)
-			i++
-		}
-	}
-	return result, nil
-}
-
-// We get the node dependencies. This requires either a yarn.lock file and the
-// yarn executable, a package-lock.json file and the npm executable. If
-// transitive is false, we also need the package.json file.
-//
-// If we find a yarn.lock file, we assume that yarn is used.
-// Only then do we look for a package-lock.json file.
-func getNodeProgramDependencies(rootDir string, transitive bool) ([]programDependencieAbout, error) {
-	// Neither "yarn list" or "npm ls" can describe what packages are required
-	//
-	// (direct dependencies). Only what packages they have installed (transitive
-	// dependencies). This means that to accurately report only direct
-	// dependencies, we need to also parse "package.json" and intersect it with
-	// reported dependencies.
-	var err error
-	yarnFile := filepath.Join(rootDir, "yarn.lock")
-	npmFile := filepath.Join(rootDir, "package-lock.json")
-	packageFile := filepath.Join(rootDir, "package.json")
-	var result []programDependencieAbout
-
-	if _, err = os.Stat(yarnFile); err == nil {
-		result, err = parseYarnLockFile(yarnFile)
-		if err != nil {
-			return nil, err
-		}
-	} else if _, err = os.Stat(npmFile); err == nil {
-		result, err = parseNpmLockFile(npmFile)
-		if err != nil {
-			return nil, err
+			Name:    dep.Name,
+			Version: dep.Version,
 		}
-	} else if os.IsNotExist(err) {
-		return nil, fmt.Errorf("Could not find either %s or %s", yarnFile, npmFile)
-	} else {
-		return nil, fmt.Errorf("Could not get node dependency data: %w", err)
-	}
-	if !transitive {
-		file, err := ioutil.ReadFile(packageFile)
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("Could not find %s. "+
-				"Please include this in your report and run "+
-				`pulumi about --transitive" to get a list of used packages`,
-				packageFile)
-		} else if err != nil {
-			return nil, fmt.Errorf("Could not read %s: %w", packageFile, err)
-		}
-		return crossCheckPackageJSONFile(packageFile, file, result)
 	}
 	return result, nil
 }
 
-func getProgramDependenciesAbout(ctx context.Context, proj *workspace.Project, root string,
-	transitive bool) ([]programDependencieAbout, error) {
-	language := proj.Runtime.Name()
-	switch language {
-	case langNodejs:
-		return getNodeProgramDependencies(root, transitive)
-	case langPython:
-		return getPythonProgramDependencies(ctx, proj, root, transitive)
-	case langGo:
-		return getGoProgramDependencies(transitive)
-	case langDotnet:
-		return getDotNetProgramDependencies(proj, transitive)
-	case langJava:
-		// TODO: Implement dependency fetcher for Java
-		return nil, nil
-	case langYAML:
-		return nil, nil
-	default:
-		return nil, fmt.Errorf("Unknown Language: %s", language)
-	}
-}
-
 func formatProgramDependenciesAbout(deps []programDependencieAbout) string {
 	if len(deps) == 0 {
 		return "No dependencies found\n"
@@ -28,13 +28,69 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/python"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
 type projectGeneratorFunc func(directory string, project workspace.Project, p *pcl.Program) error
 
+// languageRuntimeName returns the name of the language runtime plugin for the given `--language` value.
+func languageRuntimeName(language string) string {
+	switch language {
+	case "csharp", "c#":
+		return "dotnet"
+	case "typescript":
+		return "nodejs"
+	default:
+		return language
+	}
+}
+
+// builtinProjectGenerator returns the in-tree project generator for the given `--language` value, or nil if there
+// isn't one.
+func builtinProjectGenerator(language string) projectGeneratorFunc {
+	switch language {
+	case "csharp", "c#":
+		return dotnet.GenerateProject
+	case langGo:
+		return gogen.GenerateProject
+	case "typescript":
+		return nodejs.GenerateProject
+	case langPython:
+		return python.GenerateProject
+	case "java":
+		return javagen.GenerateProject
+	case "yaml": // nolint: goconst
+		return yamlgen.GenerateProject
+	default:
+		return nil
+	}
+}
+
+// pluginProjectGenerator returns a project generator that asks the language plugin for the given runtime to generate
+// the project. If the plugin cannot be loaded or does not implement code generation, the given built-in generator is
+// used instead. If there is no built-in generator for the runtime, builtin may be nil.
+func pluginProjectGenerator(ctx *plugin.Context, runtime string, builtin projectGeneratorFunc) projectGeneratorFunc {
+	return func(directory string, project workspace.Project, p *pcl.Program) error {
+		languagePlugin, err := ctx.Host.LanguageRuntime(runtime)
+		if err == nil {
+			err = languagePlugin.GenerateProject(directory, &project, p.Source())
+			if !isUnimplementedError(err) {
+				return err
+			}
+		}
+		if builtin == nil {
+			return fmt.Errorf("cannot generate programs for %q language: %w", runtime, err)
+		}
+		logging.V(7).Infof("using built-in %v project generator: %v", runtime, err)
+		return builtin(directory, project, p)
+	}
+}
+
 func newConvertCmd() *cobra.Command {
 	var outDir string
 	var language string
@@ -49,30 +105,20 @@ func newConvertCmd() *cobra.Command {
 			"The YAML program to convert will default to the manifest in the current working directory.\n" +
 			"You may also specify '-f' for the file path or '-d' for the directory path containing the manifests.\n",
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
-
-			var projectGenerator projectGeneratorFunc
-			switch language {
-			case "csharp", "c#":
-				projectGenerator = dotnet.GenerateProject
-			case langGo:
-				projectGenerator = gogen.GenerateProject
-			case "typescript":
-				projectGenerator = nodejs.GenerateProject
-			case langPython:
-				projectGenerator = python.GenerateProject
-			case "java":
-				projectGenerator = javagen.GenerateProject
-			case "yaml": // nolint: goconst
-				projectGenerator = yamlgen.GenerateProject
-			default:
-				return result.Errorf("cannot generate programs for %q language", language)
-			}
-
 			cwd, err := os.Getwd()
 			if err != nil {
 				return result.FromError(fmt.Errorf("could not resolve current working directory"))
 			}
 
+			sink := cmdutil.Diag()
+			pluginCtx, err := plugin.NewContext(sink, sink, nil, nil, cwd, nil, true, nil)
+			if err != nil {
+				return result.FromError(err)
+			}
+			defer contract.IgnoreClose(pluginCtx)
+			projectGenerator := pluginProjectGenerator(
+				pluginCtx, languageRuntimeName(language), builtinProjectGenerator(language))
+
 			if outDir != "." {
 				err := os.MkdirAll(outDir, 0755)
 				if err != nil {
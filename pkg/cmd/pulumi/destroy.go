@@ -44,6 +44,7 @@ func newDestroyCmd() *cobra.Command {
 	var diffDisplay bool
 	var eventLogPath string
 	var parallel int
+	var concurrencyLimits []string
 	var refresh string
 	var showConfig bool
 	var showReplacementSteps bool
@@ -54,6 +55,9 @@ func newDestroyCmd() *cobra.Command {
 	var yes bool
 	var targets *[]string
 	var targetDependents bool
+	var continueOnError bool
+	var excludes []string
+	var excludeDependents bool
 	var excludeProtected bool
 
 	var cmd = &cobra.Command{
@@ -132,7 +136,7 @@ func newDestroyCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			m, err := getUpdateMetadata(message, root, execKind, execAgent)
+			m, err := getUpdateMetadata(message, root, execKind, execAgent, false /* runsProgram */)
 			if err != nil {
 				return result.FromError(fmt.Errorf("gathering environment metadata: %w", err))
 			}
@@ -166,6 +170,10 @@ func newDestroyCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(err)
 			}
+			limits, err := getConcurrencyLimits(proj, concurrencyLimits)
+			if err != nil {
+				return result.FromError(err)
+			}
 
 			if targets != nil && len(*targets) > 0 && excludeProtected {
 				return result.FromError(errors.New("You cannot specify --target and --exclude-protected"))
@@ -191,10 +199,15 @@ func newDestroyCmd() *cobra.Command {
 
 			opts.Engine = engine.UpdateOptions{
 				Parallel:                  parallel,
+				ConcurrencyLimits:         limits,
+				RetryPolicy:               getRetryPolicy(proj),
 				Debug:                     debug,
 				Refresh:                   refreshOption,
 				DestroyTargets:            targetUrns,
 				TargetDependents:          targetDependents,
+				Excludes:                  expandExcludes(snap, excludes),
+				ExcludeDependents:         excludeDependents,
+				ContinueOnError:           continueOnError,
 				UseLegacyDiff:             useLegacyDiff(),
 				DisableProviderPreview:    disableProviderPreview(),
 				DisableResourceReferences: disableResourceReferences(),
@@ -245,6 +258,16 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows destroying of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().StringArrayVar(
+		&excludes, "exclude", []string{},
+		"Specify a resource URN to leave unchanged. Multiple resources can be specified using"+
+			" --exclude urn1 --exclude urn2. Wildcards (*, **) are also supported")
+	cmd.PersistentFlags().BoolVar(
+		&excludeDependents, "exclude-dependents", false,
+		"Leaves the resources that depend on the resources in the --exclude list unchanged as well")
+	cmd.PersistentFlags().BoolVar(
+		&continueOnError, "continue-on-error", false,
+		"Continue destroying resources that aren't depended on by a failed resource, and report all failures at the end")
 	cmd.PersistentFlags().BoolVar(&excludeProtected, "exclude-protected", false, "Do not destroy protected resources."+
 		" Destroy all other resources.")
 
@@ -258,6 +281,10 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVar(
+		&concurrencyLimits, "concurrency-limit", []string{},
+		"Allow at most N operations at once for a provider package or resource type, as KEY=N (e.g. azure-native=8). "+
+			"May be specified multiple times; overrides the project's concurrency options")
 	cmd.PersistentFlags().StringVarP(
 		&refresh, "refresh", "r", "",
 		"Refresh the state of the stack's resources before this update")
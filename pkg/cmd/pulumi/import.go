@@ -42,6 +42,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
 
 	javagen "github.com/pulumi/pulumi-java/pkg/codegen/java"
@@ -142,6 +143,11 @@ func parseImportFile(f importFile, protectResources bool) ([]deploy.Import, impo
 
 	imports := make([]deploy.Import, len(f.Resources))
 	for i, spec := range f.Resources {
+		if spec.Name == "" || strings.Contains(string(spec.Name), resource.URNNameDelimiter) {
+			return nil, nil, fmt.Errorf("the name '%v' for resource of type '%v' is not a valid resource name: "+
+				"names must be non-empty and must not contain '%v'", spec.Name, spec.Type, resource.URNNameDelimiter)
+		}
+
 		imp := deploy.Import{
 			Type:       spec.Type,
 			Name:       spec.Name,
@@ -205,8 +211,29 @@ func getCurrentDeploymentForStack(s backend.Stack) (*deploy.Snapshot, error) {
 
 type programGeneratorFunc func(p *pcl.Program) (map[string][]byte, hcl.Diagnostics, error)
 
+// pluginProgramGenerator returns a program generator that asks the language plugin for the given runtime to generate
+// the program. If the plugin cannot be loaded or does not implement code generation, the given built-in generator is
+// used instead. If there is no built-in generator for the runtime, builtin may be nil.
+func pluginProgramGenerator(ctx *plugin.Context, runtime string, builtin programGeneratorFunc) programGeneratorFunc {
+	return func(p *pcl.Program) (map[string][]byte, hcl.Diagnostics, error) {
+		languagePlugin, err := ctx.Host.LanguageRuntime(runtime)
+		if err == nil {
+			var files map[string][]byte
+			files, err = languagePlugin.GenerateProgram(p.Source())
+			if !isUnimplementedError(err) {
+				return files, nil, err
+			}
+		}
+		if builtin == nil {
+			return nil, nil, fmt.Errorf("cannot generate resource definitions for %v: %w", runtime, err)
+		}
+		logging.V(7).Infof("using built-in %v program generator: %v", runtime, err)
+		return builtin(p)
+	}
+}
+
 func generateImportedDefinitions(out io.Writer, stackName tokens.Name, projectName tokens.PackageName,
-	snap *deploy.Snapshot, programGenerator programGeneratorFunc, names importer.NameTable,
+	snap *deploy.Snapshot, runtime string, builtinGenerator programGeneratorFunc, names importer.NameTable,
 	imports []deploy.Import, protectResources bool) (bool, error) {
 
 	defer func() {
@@ -258,6 +285,8 @@ func generateImportedDefinitions(out io.Writer, stackName tokens.Name, projectNa
 	if err != nil {
 		return false, err
 	}
+	defer contract.IgnoreClose(ctx)
+	programGenerator := pluginProgramGenerator(ctx, runtime, builtinGenerator)
 	loader := schema.NewPluginLoader(ctx.Host)
 	return true, importer.GenerateLanguageDefinitions(out, loader, func(w io.Writer, p *pcl.Program) error {
 		files, _, err := programGenerator(p)
@@ -281,6 +310,9 @@ func newImportCmd() *cobra.Command {
 	var parentSpec string
 	var providerSpec string
 	var importFilePath string
+	var from string
+	var discover string
+	var filters []string
 	var outputFilePath string
 	var generateCode bool
 
@@ -359,10 +391,55 @@ func newImportCmd() *cobra.Command {
 			"that will be used for its import.\n" +
 			"Each resource may specify which input properties to import with;\n" +
 			"If a resource does not specify any properties the default behaviour is to\n" +
-			"import using all required properties.\n",
+			"import using all required properties.\n" +
+			"\n" +
+			"Resources managed by Terraform may be imported by passing `--from terraform` and\n" +
+			"the path to a Terraform state file. The provider that bridges each Terraform\n" +
+			"provider is asked for the Pulumi type of each resource in the state, and the\n" +
+			"resources are imported with their Terraform names and IDs.\n" +
+			"\n" +
+			"All of the live resources of a type may be imported by passing `--discover` and the\n" +
+			"type's token. The default provider for the type's package, configured using the\n" +
+			"stack's configuration, is asked to list the resources, optionally narrowed by\n" +
+			"provider-specific `--filter key=value` arguments.\n",
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			if len(filters) != 0 && discover == "" {
+				return result.Errorf("--filter may only be specified in conjunction with --discover")
+			}
+			discoverFilter, err := parseDiscoverFilters(filters)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			var importFile importFile
-			if importFilePath != "" {
+			var discoverType tokens.Type
+			if discover != "" {
+				if len(args) != 0 || from != "" || importFilePath != "" || parentSpec != "" || providerSpec != "" ||
+					len(properties) != 0 {
+					return result.Errorf("--discover may not be specified in conjunction with --from, an import file, " +
+						"or an inline resource")
+				}
+				if discoverType, err = tokens.ParseTypeToken(discover); err != nil {
+					return result.Errorf("invalid --discover type: %v", err)
+				}
+				// The resources to import are discovered once the stack's configuration has been loaded.
+			} else if from != "" {
+				if from != "terraform" {
+					return result.Errorf("unsupported import source '%v'; only 'terraform' is supported", from)
+				}
+				if len(args) != 1 {
+					return result.Errorf("the path to a Terraform state file must be specified with --from terraform")
+				}
+				if importFilePath != "" || parentSpec != "" || providerSpec != "" || len(properties) != 0 {
+					return result.Errorf("--from may not be specified in conjunction with an import file or an " +
+						"inline resource")
+				}
+				f, err := readTerraformImportFile(args[0])
+				if err != nil {
+					return result.FromError(fmt.Errorf("could not import from Terraform state: %w", err))
+				}
+				importFile = f
+			} else if importFilePath != "" {
 				if len(args) != 0 || parentSpec != "" || providerSpec != "" || len(properties) != 0 {
 					return result.Errorf("an inline resource may not be specified in conjunction with an import file")
 				}
@@ -397,9 +474,13 @@ func newImportCmd() *cobra.Command {
 				output = f
 			}
 
-			imports, nameTable, err := parseImportFile(importFile, protectResources)
-			if err != nil {
-				return result.FromError(err)
+			var imports []deploy.Import
+			var nameTable importer.NameTable
+			if discover == "" {
+				imports, nameTable, err = parseImportFile(importFile, protectResources)
+				if err != nil {
+					return result.FromError(err)
+				}
 			}
 
 			yes = yes || skipPreview || skipConfirmations()
@@ -454,22 +535,20 @@ func newImportCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			var programGenerator programGeneratorFunc
+			var builtinGenerator programGeneratorFunc
 			switch proj.Runtime.Name() {
 			case "dotnet":
-				programGenerator = dotnet.GenerateProgram
+				builtinGenerator = dotnet.GenerateProgram
 			case "go":
-				programGenerator = gogen.GenerateProgram
+				builtinGenerator = gogen.GenerateProgram
 			case "nodejs":
-				programGenerator = nodejs.GenerateProgram
+				builtinGenerator = nodejs.GenerateProgram
 			case "python":
-				programGenerator = python.GenerateProgram
+				builtinGenerator = python.GenerateProgram
 			case "java":
-				programGenerator = javagen.GenerateProgram
+				builtinGenerator = javagen.GenerateProgram
 			case "yaml":
-				programGenerator = yamlgen.GenerateProgram
-			default:
-				return result.Errorf("cannot generate resource definitions for %v", proj.Runtime.Name())
+				builtinGenerator = yamlgen.GenerateProgram
 			}
 
 			// Fetch the current stack.
@@ -478,7 +557,7 @@ func newImportCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			m, err := getUpdateMetadata(message, root, execKind, execAgent)
+			m, err := getUpdateMetadata(message, root, execKind, execAgent, false /* runsProgram */)
 			if err != nil {
 				return result.FromError(fmt.Errorf("gathering environment metadata: %w", err))
 			}
@@ -493,6 +572,18 @@ func newImportCmd() *cobra.Command {
 				return result.FromError(fmt.Errorf("getting stack configuration: %w", err))
 			}
 
+			if discover != "" {
+				f, err := discoverImportFile(
+					s.Ref().Name().Q(), proj.Name, discoverType, discoverFilter, cfg)
+				if err != nil {
+					return result.FromError(fmt.Errorf("could not discover resources to import: %w", err))
+				}
+				imports, nameTable, err = parseImportFile(f, protectResources)
+				if err != nil {
+					return result.FromError(err)
+				}
+			}
+
 			opts.Engine = engine.UpdateOptions{
 				Parallel:      parallel,
 				Debug:         debug,
@@ -516,8 +607,8 @@ func newImportCmd() *cobra.Command {
 				}
 
 				validImports, err := generateImportedDefinitions(
-					output, s.Ref().Name(), proj.Name, deployment, programGenerator, nameTable, imports,
-					protectResources)
+					output, s.Ref().Name(), proj.Name, deployment, proj.Runtime.Name(), builtinGenerator, nameTable,
+					imports, protectResources)
 				if err != nil {
 					if _, ok := err.(*importer.DiagnosticsError); ok {
 						err = fmt.Errorf("internal error: %w", err)
@@ -566,6 +657,12 @@ func newImportCmd() *cobra.Command {
 		&properties, "properties", nil, "The property names to use for the import in the format name1,name2")
 	cmd.PersistentFlags().StringVarP(
 		&importFilePath, "file", "f", "", "The path to a JSON-encoded file containing a list of resources to import")
+	cmd.PersistentFlags().StringVar(
+		&from, "from", "", "Import the resources described by another tool's state file. Supports 'terraform'")
+	cmd.PersistentFlags().StringVar(
+		&discover, "discover", "", "Import all of the live resources of the given type that its provider can list")
+	cmd.PersistentFlags().StringArrayVar(
+		&filters, "filter", nil, "A provider-specific filter for --discover in the format key=value")
 	cmd.PersistentFlags().StringVarP(
 		&outputFilePath, "out", "o", "", "The path to the file that will contain the generated resource declarations")
 	cmd.PersistentFlags().BoolVar(
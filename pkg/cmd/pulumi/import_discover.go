@@ -0,0 +1,155 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// parseDiscoverFilters parses the `key=value` filters passed to `pulumi import --discover`.
+func parseDiscoverFilters(filters []string) (map[string]string, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]string, len(filters))
+	for _, f := range filters {
+		equals := strings.Index(f, "=")
+		if equals <= 0 {
+			return nil, fmt.Errorf("filter '%v' must be of the form key=value", f)
+		}
+		result[f[:equals]] = f[equals+1:]
+	}
+	return result, nil
+}
+
+// makeDiscoveredImportFile builds an import file for the live resources of the given type, as listed by the given
+// provider. Each resource is named after the name suggested by the provider, or its ID if the provider has no
+// suggestion. Characters that are not valid in resource names (e.g. the colons in an ARN) are replaced with
+// underscores, and names that would otherwise collide are made unique with a numeric suffix.
+func makeDiscoveredImportFile(provider plugin.Provider, typ tokens.Type,
+	filter map[string]string) (importFile, error) {
+
+	listed, err := provider.List(typ, filter)
+	if err != nil {
+		if isUnimplementedError(err) {
+			return importFile{}, fmt.Errorf("the %v provider does not support listing resources", typ.Package())
+		}
+		return importFile{}, fmt.Errorf("could not list resources of type %v: %w", typ, err)
+	}
+
+	names := map[tokens.QName]bool{}
+	var resources []importSpec
+	for _, r := range listed {
+		if r.ID == "" {
+			continue
+		}
+
+		base := r.Name
+		if base == "" {
+			base = string(r.ID)
+		}
+		name := tokens.IntoQName(base)
+		for i := 2; names[name]; i++ {
+			name = tokens.IntoQName(fmt.Sprintf("%v-%d", base, i))
+		}
+		names[name] = true
+
+		resources = append(resources, importSpec{
+			Type: typ,
+			Name: name,
+			ID:   r.ID,
+		})
+	}
+
+	if len(resources) == 0 {
+		return importFile{}, fmt.Errorf("no resources of type %v were found", typ)
+	}
+	return importFile{Resources: resources}, nil
+}
+
+// configureDiscoveryProvider configures the provider used to list resources the same way the engine configures a
+// default provider: the stack's configuration for the package is checked by the provider, which fills in its defaults
+// and any configuration read from the environment, and the checked inputs are then used to configure it.
+func configureDiscoveryProvider(provider plugin.Provider, urn resource.URN, props resource.PropertyMap) error {
+	pkg := providers.GetProviderPackage(urn.Type())
+
+	inputs, failures, err := provider.CheckConfig(urn, nil, props, false)
+	if err != nil {
+		return fmt.Errorf("could not validate the configuration for the %v provider: %w", pkg, err)
+	}
+	if len(failures) != 0 {
+		reasons := make([]string, len(failures))
+		for i, f := range failures {
+			if f.Property == "" {
+				reasons[i] = f.Reason
+			} else {
+				reasons[i] = fmt.Sprintf("%v: %v", f.Property, f.Reason)
+			}
+		}
+		return fmt.Errorf("invalid configuration for the %v provider: %v", pkg, strings.Join(reasons, "; "))
+	}
+
+	if err = provider.Configure(inputs); err != nil {
+		return fmt.Errorf("could not configure the %v provider: %w", pkg, err)
+	}
+	return nil
+}
+
+// discoverImportFile builds an import file for the live resources of the given type. The resources are listed by the
+// default provider for the type's package, configured using the stack's configuration.
+func discoverImportFile(stackName tokens.QName, project tokens.PackageName, typ tokens.Type,
+	filter map[string]string, cfg backend.StackConfiguration) (importFile, error) {
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return importFile{}, err
+	}
+	sink := cmdutil.Diag()
+	ctx, err := plugin.NewContext(sink, sink, nil, nil, cwd, nil, true, nil)
+	if err != nil {
+		return importFile{}, err
+	}
+	defer contract.IgnoreClose(ctx)
+
+	pkg := typ.Package()
+	provider, err := ctx.Host.Provider(pkg, nil)
+	if err != nil {
+		return importFile{}, fmt.Errorf("could not load the %v provider: %w", pkg, err)
+	}
+
+	target := &deploy.Target{Config: cfg.Config, Decrypter: cfg.Decrypter}
+	props, err := target.GetPackageConfig(pkg)
+	if err != nil {
+		return importFile{}, fmt.Errorf("could not read the configuration for the %v provider: %w", pkg, err)
+	}
+	urn := resource.NewURN(stackName, project, "", providers.MakeProviderType(pkg), "default")
+	if err = configureDiscoveryProvider(provider, urn, props); err != nil {
+		return importFile{}, err
+	}
+
+	return makeDiscoveredImportFile(provider, typ, filter)
+}
@@ -0,0 +1,281 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
+)
+
+func TestParseDiscoverFilters(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		filters  []string
+		expected map[string]string
+		err      string
+	}{
+		{name: "none"},
+		{
+			name:     "single",
+			filters:  []string{"region=us-west-2"},
+			expected: map[string]string{"region": "us-west-2"},
+		},
+		{
+			name:     "value containing equals",
+			filters:  []string{"tag=env=prod", "name="},
+			expected: map[string]string{"tag": "env=prod", "name": ""},
+		},
+		{
+			name:     "last wins",
+			filters:  []string{"a=1", "a=2"},
+			expected: map[string]string{"a": "2"},
+		},
+		{
+			name:    "missing equals",
+			filters: []string{"region"},
+			err:     "filter 'region' must be of the form key=value",
+		},
+		{
+			name:    "missing key",
+			filters: []string{"=value"},
+			err:     "filter '=value' must be of the form key=value",
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := parseDiscoverFilters(c.filters)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestMakeDiscoveredImportFile(t *testing.T) {
+	t.Parallel()
+
+	typ := tokens.Type("pkgA:m:typA")
+
+	cases := []struct {
+		name     string
+		listed   []plugin.ListedResource
+		expected []importSpec
+	}{
+		{
+			name: "provider names",
+			listed: []plugin.ListedResource{
+				{ID: "id-1", Name: "first"},
+				{ID: "id-2", Name: "second"},
+			},
+			expected: []importSpec{
+				{Type: typ, Name: "first", ID: "id-1"},
+				{Type: typ, Name: "second", ID: "id-2"},
+			},
+		},
+		{
+			name: "IDs as names",
+			listed: []plugin.ListedResource{
+				{ID: "arn:aws:iam::123456789012:role/my-role"},
+				{ID: "i-0123456789abcdef0"},
+			},
+			expected: []importSpec{
+				{Type: typ, Name: "arn_aws_iam__123456789012_role/my-role", ID: "arn:aws:iam::123456789012:role/my-role"},
+				{Type: typ, Name: "i-0123456789abcdef0", ID: "i-0123456789abcdef0"},
+			},
+		},
+		{
+			name: "invalid provider names",
+			listed: []plugin.ListedResource{
+				{ID: "id-1", Name: "my bucket"},
+				{ID: "id-2", Name: "a::b"},
+			},
+			expected: []importSpec{
+				{Type: typ, Name: "my_bucket", ID: "id-1"},
+				{Type: typ, Name: "a__b", ID: "id-2"},
+			},
+		},
+		{
+			name: "collisions",
+			listed: []plugin.ListedResource{
+				{ID: "id-1", Name: "web"},
+				{ID: "id-2", Name: "web"},
+				{ID: "id-3", Name: "web-2"},
+				{ID: "id-4", Name: "web"},
+			},
+			expected: []importSpec{
+				{Type: typ, Name: "web", ID: "id-1"},
+				{Type: typ, Name: "web-2", ID: "id-2"},
+				{Type: typ, Name: "web-2-2", ID: "id-3"},
+				{Type: typ, Name: "web-3", ID: "id-4"},
+			},
+		},
+		{
+			name: "collisions after sanitizing",
+			listed: []plugin.ListedResource{
+				{ID: "a:b"},
+				{ID: "a_b"},
+			},
+			expected: []importSpec{
+				{Type: typ, Name: "a_b", ID: "a:b"},
+				{Type: typ, Name: "a_b-2", ID: "a_b"},
+			},
+		},
+		{
+			name: "empty IDs are skipped",
+			listed: []plugin.ListedResource{
+				{ID: "", Name: "ghost"},
+				{ID: "id-1", Name: "ghost"},
+			},
+			expected: []importSpec{
+				{Type: typ, Name: "ghost", ID: "id-1"},
+			},
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			var listedType tokens.Type
+			var listedFilter map[string]string
+			provider := &deploytest.Provider{
+				ListF: func(t tokens.Type, filter map[string]string) ([]plugin.ListedResource, error) {
+					listedType, listedFilter = t, filter
+					return c.listed, nil
+				},
+			}
+
+			filter := map[string]string{"region": "us-west-2"}
+			f, err := makeDiscoveredImportFile(provider, typ, filter)
+			require.NoError(t, err)
+			assert.Equal(t, typ, listedType)
+			assert.Equal(t, filter, listedFilter)
+			assert.Equal(t, c.expected, f.Resources)
+
+			// Every generated name must be accepted when the import file is parsed.
+			_, _, err = parseImportFile(f, false)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMakeDiscoveredImportFileErrors(t *testing.T) {
+	t.Parallel()
+
+	typ := tokens.Type("pkgA:m:typA")
+
+	unimplemented := &deploytest.Provider{
+		ListF: func(tokens.Type, map[string]string) ([]plugin.ListedResource, error) {
+			return nil, rpcerror.Convert(rpcerror.New(codes.Unimplemented, "List is not yet implemented"))
+		},
+	}
+	_, err := makeDiscoveredImportFile(unimplemented, typ, nil)
+	assert.EqualError(t, err, "the pkgA provider does not support listing resources")
+
+	empty := &deploytest.Provider{
+		ListF: func(tokens.Type, map[string]string) ([]plugin.ListedResource, error) {
+			return []plugin.ListedResource{{ID: "", Name: "ghost"}}, nil
+		},
+	}
+	_, err = makeDiscoveredImportFile(empty, typ, nil)
+	assert.EqualError(t, err, "no resources of type pkgA:m:typA were found")
+}
+
+func TestConfigureDiscoveryProvider(t *testing.T) {
+	t.Parallel()
+
+	urn := resource.NewURN("dev", "proj", "", providers.MakeProviderType("pkgA"), "default")
+
+	t.Run("checked config is used", func(t *testing.T) {
+		t.Parallel()
+
+		var checkedURN resource.URN
+		var configured resource.PropertyMap
+		provider := &deploytest.Provider{
+			CheckConfigF: func(urn resource.URN, olds, news resource.PropertyMap,
+				allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+				checkedURN = urn
+				inputs := news.Copy()
+				inputs["region"] = resource.NewStringProperty("us-east-1")
+				return inputs, nil, nil
+			},
+			ConfigureF: func(news resource.PropertyMap) error {
+				configured = news
+				return nil
+			},
+		}
+
+		props := resource.PropertyMap{"profile": resource.NewStringProperty("dev")}
+		err := configureDiscoveryProvider(provider, urn, props)
+		require.NoError(t, err)
+		assert.Equal(t, urn, checkedURN)
+		assert.Equal(t, resource.PropertyMap{
+			"profile": resource.NewStringProperty("dev"),
+			"region":  resource.NewStringProperty("us-east-1"),
+		}, configured)
+	})
+
+	t.Run("check failures", func(t *testing.T) {
+		t.Parallel()
+
+		provider := &deploytest.Provider{
+			CheckConfigF: func(urn resource.URN, olds, news resource.PropertyMap,
+				allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
+
+				return nil, []plugin.CheckFailure{
+					{Property: "region", Reason: "missing required configuration"},
+					{Reason: "no credentials"},
+				}, nil
+			},
+			ConfigureF: func(news resource.PropertyMap) error {
+				assert.Fail(t, "Configure should not be called")
+				return nil
+			},
+		}
+
+		err := configureDiscoveryProvider(provider, urn, resource.PropertyMap{})
+		assert.EqualError(t, err,
+			"invalid configuration for the pkgA provider: region: missing required configuration; no credentials")
+	})
+}
+
+func TestParseImportFileInvalidName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []tokens.QName{"", "a::b"} {
+		f := importFile{Resources: []importSpec{{Type: "pkgA:m:typA", Name: name, ID: "id"}}}
+		_, _, err := parseImportFile(f, false)
+		assert.Error(t, err, "name %q", name)
+	}
+}
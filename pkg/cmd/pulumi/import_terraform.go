@@ -0,0 +1,253 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// terraformMappingKey is the key passed to GetMapping to fetch a provider's mapping from Terraform.
+const terraformMappingKey = "terraform"
+
+// terraformProviderPackages maps the names of Terraform providers to the Pulumi packages that bridge them, for the
+// providers whose names differ between the two ecosystems. Other providers share their name with their package.
+var terraformProviderPackages = map[string]tokens.Package{
+	"azurerm":     "azure",
+	"google":      "gcp",
+	"google-beta": "gcp",
+}
+
+// terraformState is the subset of a Terraform state file (format version 4) that is needed to import the resources it
+// describes.
+type terraformState struct {
+	Version   int                      `json:"version"`
+	Resources []terraformStateResource `json:"resources"`
+}
+
+type terraformStateResource struct {
+	Module    string                   `json:"module,omitempty"`
+	Mode      string                   `json:"mode"`
+	Type      string                   `json:"type"`
+	Name      string                   `json:"name"`
+	Provider  string                   `json:"provider"`
+	Instances []terraformStateInstance `json:"instances"`
+}
+
+type terraformStateInstance struct {
+	IndexKey   interface{}            `json:"index_key,omitempty"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// terraformMapping is the subset of the mapping data returned by a bridged provider for the "terraform" key that is
+// needed to map Terraform resource types to Pulumi type tokens.
+type terraformMapping struct {
+	Resources map[string]struct {
+		Tok tokens.Type `json:"tok"`
+	} `json:"resources"`
+}
+
+// readTerraformState reads the Terraform state file at the given path.
+func readTerraformState(p string) (*terraformState, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer contract.IgnoreClose(f)
+
+	var state terraformState
+	if err = json.NewDecoder(f).Decode(&state); err != nil {
+		return nil, err
+	}
+	if state.Version != 4 {
+		return nil, fmt.Errorf("unsupported Terraform state version %v; only version 4 is supported", state.Version)
+	}
+	return &state, nil
+}
+
+// terraformProviderName returns the name of the Terraform provider that manages the given resource, e.g. "aws" for a
+// provider address of `provider["registry.terraform.io/hashicorp/aws"]`.
+func terraformProviderName(r terraformStateResource) string {
+	address := r.Provider
+	if start := strings.Index(address, `["`); start != -1 {
+		address = address[start+2:]
+		if end := strings.Index(address, `"]`); end != -1 {
+			address = address[:end]
+		}
+		return address[strings.LastIndex(address, "/")+1:]
+	}
+
+	// Fall back to the type's prefix, which names its provider by convention.
+	if underscore := strings.Index(r.Type, "_"); underscore != -1 {
+		return r.Type[:underscore]
+	}
+	return r.Type
+}
+
+// terraformResourceName returns the Pulumi name for an instance of the given resource. Resources in modules are
+// prefixed with the names of their modules, and resources with multiple instances are suffixed with their index.
+// Characters that are not valid in resource names, such as those in arbitrary index keys, are replaced with
+// underscores.
+func terraformResourceName(r terraformStateResource, instance terraformStateInstance) tokens.QName {
+	var components []string
+	for _, part := range strings.Split(r.Module, ".") {
+		if part != "" && part != "module" {
+			components = append(components, part)
+		}
+	}
+	components = append(components, r.Name)
+	if instance.IndexKey != nil {
+		components = append(components, fmt.Sprintf("%v", instance.IndexKey))
+	}
+	for i, c := range components {
+		components[i] = strings.ReplaceAll(string(tokens.IntoQName(c)), tokens.QNameDelimiter, "_")
+	}
+	return tokens.QName(strings.Join(components, "_"))
+}
+
+// getTerraformMapping asks the Pulumi provider that bridges the given Terraform provider for the mapping from
+// Terraform resource types to Pulumi type tokens.
+func getTerraformMapping(host plugin.Host, tfProvider string) (map[string]tokens.Type, error) {
+	pkg, ok := terraformProviderPackages[tfProvider]
+	if !ok {
+		pkg = tokens.Package(tfProvider)
+	}
+
+	provider, err := host.Provider(pkg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the %v provider: %w", pkg, err)
+	}
+	data, _, err := provider.GetMapping(terraformMappingKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not get the Terraform mapping for the %v provider: %w", pkg, err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("the %v provider does not have a Terraform mapping", pkg)
+	}
+
+	var mapping terraformMapping
+	if err = json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("could not parse the Terraform mapping for the %v provider: %w", pkg, err)
+	}
+
+	types := make(map[string]tokens.Type, len(mapping.Resources))
+	for tfType, info := range mapping.Resources {
+		if info.Tok != "" {
+			types[tfType] = info.Tok
+		}
+	}
+	return types, nil
+}
+
+// makeTerraformImportFile builds an import file for the managed resources in the given Terraform state, using the
+// providers loaded by the given host to map their types to Pulumi type tokens. Resources that cannot be mapped are
+// reported as warnings and left out of the import file. Resources whose names would otherwise collide are made unique
+// with a numeric suffix, which is also reported as a warning.
+func makeTerraformImportFile(host plugin.Host, sink diag.Sink, state *terraformState) (importFile, error) {
+	mappings := map[string]map[string]tokens.Type{}
+	failedProviders := map[string]bool{}
+
+	names := map[tokens.QName]bool{}
+	var resources []importSpec
+	for _, r := range state.Resources {
+		// Data sources are read, not managed, by Terraform, so there is nothing to import.
+		if r.Mode != "managed" {
+			continue
+		}
+
+		tfProvider := terraformProviderName(r)
+		if failedProviders[tfProvider] {
+			continue
+		}
+		types, ok := mappings[tfProvider]
+		if !ok {
+			m, err := getTerraformMapping(host, tfProvider)
+			if err != nil {
+				sink.Warningf(diag.Message("", "skipping resources managed by the Terraform %v provider: %v"),
+					tfProvider, err)
+				failedProviders[tfProvider] = true
+				continue
+			}
+			types, mappings[tfProvider] = m, m
+		}
+
+		typ, ok := types[r.Type]
+		if !ok {
+			sink.Warningf(diag.Message("", "skipping %v.%v: no Pulumi type corresponds to the Terraform type %v"),
+				r.Type, r.Name, r.Type)
+			continue
+		}
+
+		for _, instance := range r.Instances {
+			id, _ := instance.Attributes["id"].(string)
+			if id == "" {
+				sink.Warningf(diag.Message("", "skipping %v.%v: the resource has no ID"), r.Type, r.Name)
+				continue
+			}
+
+			base := terraformResourceName(r, instance)
+			name := base
+			for i := 2; names[name]; i++ {
+				name = tokens.QName(fmt.Sprintf("%v-%d", base, i))
+			}
+			if name != base {
+				sink.Warningf(diag.Message("", "importing %v.%v as %v: the name %v is already in use"),
+					r.Type, r.Name, name, base)
+			}
+			names[name] = true
+
+			resources = append(resources, importSpec{
+				Type: typ,
+				Name: name,
+				ID:   resource.ID(id),
+			})
+		}
+	}
+
+	if len(resources) == 0 {
+		return importFile{}, fmt.Errorf("no resources in the Terraform state could be imported")
+	}
+	return importFile{Resources: resources}, nil
+}
+
+// readTerraformImportFile builds an import file for the resources in the Terraform state file at the given path.
+func readTerraformImportFile(p string) (importFile, error) {
+	state, err := readTerraformState(p)
+	if err != nil {
+		return importFile{}, err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return importFile{}, err
+	}
+	sink := cmdutil.Diag()
+	ctx, err := plugin.NewContext(sink, sink, nil, nil, cwd, nil, true, nil)
+	if err != nil {
+		return importFile{}, err
+	}
+	defer contract.IgnoreClose(ctx)
+
+	return makeTerraformImportFile(ctx.Host, sink, state)
+}
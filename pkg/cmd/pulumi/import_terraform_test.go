@@ -0,0 +1,277 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+func TestTerraformProviderName(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		resource terraformStateResource
+		expected string
+	}{
+		{
+			name: "registry address",
+			resource: terraformStateResource{
+				Type:     "aws_instance",
+				Provider: `provider["registry.terraform.io/hashicorp/aws"]`,
+			},
+			expected: "aws",
+		},
+		{
+			name: "aliased provider in a module",
+			resource: terraformStateResource{
+				Type:     "google_compute_instance",
+				Provider: `module.vpc.provider["registry.terraform.io/hashicorp/google-beta"].west`,
+			},
+			expected: "google-beta",
+		},
+		{
+			name: "unqualified address",
+			resource: terraformStateResource{
+				Type:     "random_pet",
+				Provider: `provider["random"]`,
+			},
+			expected: "random",
+		},
+		{
+			name: "legacy address",
+			resource: terraformStateResource{
+				Type:     "azurerm_resource_group",
+				Provider: "provider.azurerm",
+			},
+			expected: "azurerm",
+		},
+		{
+			name:     "no address or prefix",
+			resource: terraformStateResource{Type: "thing"},
+			expected: "thing",
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, terraformProviderName(c.resource))
+		})
+	}
+}
+
+func TestTerraformResourceName(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		module   string
+		resource string
+		index    interface{}
+		expected tokens.QName
+	}{
+		{name: "root", resource: "web", expected: "web"},
+		{name: "module", module: "module.app", resource: "web", expected: "app_web"},
+		{name: "nested modules", module: "module.app.module.db", resource: "main", expected: "app_db_main"},
+		{name: "count index", resource: "web", index: float64(1), expected: "web_1"},
+		{name: "for_each key", resource: "web", index: "us-east-1", expected: "web_us-east-1"},
+		{name: "arbitrary key", resource: "web", index: "a::b c/d", expected: "web_a__b_c_d"},
+		{name: "indexed module", module: `module.app["blue"]`, resource: "web", expected: "app__blue___web"},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := terraformStateResource{Module: c.module, Name: c.resource}
+			actual := terraformResourceName(r, terraformStateInstance{IndexKey: c.index})
+			assert.Equal(t, c.expected, actual)
+			assert.True(t, tokens.IsQName(string(actual)), "%q is not a valid name", actual)
+		})
+	}
+}
+
+func TestMakeTerraformImportFile(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("aws", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				GetMappingF: func(key string) ([]byte, string, error) {
+					assert.Equal(t, terraformMappingKey, key)
+					return []byte(`{"resources": {
+						"aws_instance": {"tok": "aws:ec2/instance:Instance"},
+						"aws_s3_bucket": {"tok": "aws:s3/bucket:Bucket"}
+					}}`), "aws", nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+		deploytest.NewProviderLoader("gcp", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				GetMappingF: func(key string) ([]byte, string, error) {
+					return []byte(`{"resources": {"google_storage_bucket": {"tok": "gcp:storage/bucket:Bucket"}}}`),
+						"google", nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+		deploytest.NewProviderLoader("random", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}, deploytest.WithoutGrpc),
+	}
+	host := deploytest.NewPluginHost(nil, nil, nil, loaders...)
+
+	instance := func(id string, index interface{}) terraformStateInstance {
+		return terraformStateInstance{IndexKey: index, Attributes: map[string]interface{}{"id": id}}
+	}
+	awsProvider := `provider["registry.terraform.io/hashicorp/aws"]`
+
+	state := &terraformState{
+		Version: 4,
+		Resources: []terraformStateResource{
+			{
+				Mode:      "managed",
+				Type:      "aws_instance",
+				Name:      "web",
+				Provider:  awsProvider,
+				Instances: []terraformStateInstance{instance("i-1", float64(0)), instance("i-2", float64(1))},
+			},
+			{
+				Mode:      "data",
+				Type:      "aws_ami",
+				Name:      "ubuntu",
+				Provider:  awsProvider,
+				Instances: []terraformStateInstance{instance("ami-1", nil)},
+			},
+			{
+				Mode:      "managed",
+				Type:      "aws_s3_bucket",
+				Name:      "b_c",
+				Module:    "module.a",
+				Provider:  awsProvider,
+				Instances: []terraformStateInstance{instance("bucket-1", nil)},
+			},
+			{
+				Mode:      "managed",
+				Type:      "aws_s3_bucket",
+				Name:      "c",
+				Module:    "module.a_b",
+				Provider:  awsProvider,
+				Instances: []terraformStateInstance{instance("bucket-2", nil)},
+			},
+			{
+				Mode:      "managed",
+				Type:      "aws_s3_bucket",
+				Name:      "logs",
+				Provider:  awsProvider,
+				Instances: []terraformStateInstance{instance("bucket-3", "eu::west")},
+			},
+			{
+				Mode:      "managed",
+				Type:      "aws_vpc",
+				Name:      "main",
+				Provider:  awsProvider,
+				Instances: []terraformStateInstance{instance("vpc-1", nil)},
+			},
+			{
+				Mode:      "managed",
+				Type:      "aws_instance",
+				Name:      "pending",
+				Provider:  awsProvider,
+				Instances: []terraformStateInstance{instance("", nil)},
+			},
+			{
+				Mode:      "managed",
+				Type:      "google_storage_bucket",
+				Name:      "assets",
+				Provider:  `provider["registry.terraform.io/hashicorp/google"]`,
+				Instances: []terraformStateInstance{instance("assets-bucket", nil)},
+			},
+			{
+				Mode:      "managed",
+				Type:      "random_pet",
+				Name:      "name",
+				Provider:  `provider["registry.terraform.io/hashicorp/random"]`,
+				Instances: []terraformStateInstance{instance("pet-1", nil)},
+			},
+			{
+				Mode:      "managed",
+				Type:      "tls_private_key",
+				Name:      "key",
+				Provider:  `provider["registry.terraform.io/hashicorp/tls"]`,
+				Instances: []terraformStateInstance{instance("key-1", nil)},
+			},
+		},
+	}
+
+	var warnings bytes.Buffer
+	sink := diag.DefaultSink(&warnings, &warnings, diag.FormatOptions{Color: colors.Never})
+
+	f, err := makeTerraformImportFile(host, sink, state)
+	require.NoError(t, err)
+	assert.Equal(t, []importSpec{
+		{Type: "aws:ec2/instance:Instance", Name: "web_0", ID: "i-1"},
+		{Type: "aws:ec2/instance:Instance", Name: "web_1", ID: "i-2"},
+		{Type: "aws:s3/bucket:Bucket", Name: "a_b_c", ID: "bucket-1"},
+		{Type: "aws:s3/bucket:Bucket", Name: "a_b_c-2", ID: "bucket-2"},
+		{Type: "aws:s3/bucket:Bucket", Name: "logs_eu__west", ID: "bucket-3"},
+		{Type: "gcp:storage/bucket:Bucket", Name: "assets", ID: "assets-bucket"},
+	}, f.Resources)
+
+	// Every generated name must be accepted when the import file is parsed.
+	_, _, err = parseImportFile(f, false)
+	assert.NoError(t, err)
+
+	output := warnings.String()
+	assert.Contains(t, output, "importing aws_s3_bucket.c as a_b_c-2: the name a_b_c is already in use")
+	assert.Contains(t, output, "skipping aws_vpc.main: no Pulumi type corresponds to the Terraform type aws_vpc")
+	assert.Contains(t, output, "skipping aws_instance.pending: the resource has no ID")
+	assert.Contains(t, output, "skipping resources managed by the Terraform random provider: "+
+		"the random provider does not have a Terraform mapping")
+	assert.Contains(t, output, "skipping resources managed by the Terraform tls provider: "+
+		"could not load the tls provider")
+	assert.NotContains(t, output, "aws_ami")
+}
+
+func TestMakeTerraformImportFileNothingToImport(t *testing.T) {
+	t.Parallel()
+
+	host := deploytest.NewPluginHost(nil, nil, nil)
+	var warnings bytes.Buffer
+	sink := diag.DefaultSink(&warnings, &warnings, diag.FormatOptions{Color: colors.Never})
+
+	state := &terraformState{
+		Version: 4,
+		Resources: []terraformStateResource{{
+			Mode:     "data",
+			Type:     "aws_ami",
+			Name:     "ubuntu",
+			Provider: `provider["registry.terraform.io/hashicorp/aws"]`,
+		}},
+	}
+	_, err := makeTerraformImportFile(host, sink, state)
+	assert.EqualError(t, err, "no resources in the Terraform state could be imported")
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/backend/filestate"
 	"github.com/pulumi/pulumi/pkg/v3/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/v3/backend/sqlstate"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
@@ -139,6 +140,11 @@ func newLoginCmd() *cobra.Command {
 				if defaultOrg != "" {
 					return fmt.Errorf("unable to set default org for this type of backend")
 				}
+			} else if sqlstate.IsSQLStateBackendURL(cloudURL) {
+				be, err = sqlstate.Login(cmdutil.Diag(), cloudURL)
+				if defaultOrg != "" {
+					return fmt.Errorf("unable to set default org for this type of backend")
+				}
 			} else {
 				be, err = httpstate.Login(commandContext(), cmdutil.Diag(), cloudURL, displayOptions)
 				// if the user has specified a default org to associate with the backend
@@ -176,14 +182,14 @@ func newLoginCmd() *cobra.Command {
 
 func validateCloudBackendType(typ string) error {
 	kind := strings.SplitN(typ, ":", 2)[0]
-	supportedKinds := []string{"azblob", "gs", "s3", "file", "https", "http"}
+	supportedKinds := []string{"azblob", "gs", "s3", "file", "postgres", "postgresql", "sqlite", "https", "http"}
 	for _, supportedKind := range supportedKinds {
 		if kind == supportedKind {
 			return nil
 		}
 	}
 	return fmt.Errorf("unknown backend cloudUrl format '%s' (supported Url formats are: "+
-		"azblob://, gs://, s3://, file://, https:// and http://)",
+		"azblob://, gs://, s3://, file://, postgres://, sqlite://, https:// and http://)",
 		kind)
 
 }
@@ -30,6 +30,7 @@ import (
 	survey "gopkg.in/AlecAivazis/survey.v1"
 	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
 
+	yamlgen "github.com/pulumi/pulumi-yaml/pkg/pulumiyaml/codegen"
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/backend/state"
@@ -56,6 +57,7 @@ type newArgs struct {
 	force             bool
 	generateOnly      bool
 	interactive       bool
+	language          string
 	name              string
 	offline           bool
 	prompt            promptForValueFunc
@@ -231,8 +233,13 @@ func runNew(args newArgs) error {
 		}
 	}
 
-	// Actually copy the files.
-	if err = workspace.CopyTemplateFiles(template.Dir, cwd, args.force, args.name, args.description); err != nil {
+	// Actually copy the files, or generate them from the template if a different language was requested.
+	if args.language != "" {
+		err = generateTemplateProject(template, cwd, args)
+	} else {
+		err = workspace.CopyTemplateFiles(template.Dir, cwd, args.force, args.name, args.description)
+	}
+	if err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("template '%s' not found: %w", args.templateNameOrURL, err)
 		}
@@ -464,6 +471,9 @@ func newNewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&args.generateOnly, "generate-only", "g", false,
 		"Generate the project only; do not create a stack, save config, or install dependencies")
+	cmd.PersistentFlags().StringVar(
+		&args.language, "language", "",
+		"The language to generate the project in; templates written in YAML can be generated in any language")
 	cmd.PersistentFlags().StringVarP(
 		&args.name, "name", "n", "",
 		"The project name; if not specified, a prompt will request it")
@@ -483,6 +493,61 @@ func newNewCmd() *cobra.Command {
 	return cmd
 }
 
+// generateTemplateProject creates the project for the given template in the language requested by args, rather than
+// the language the template is written in. Templates in any other language than the requested one must be written in
+// YAML, so that they can be converted to PCL and handed to the requested language's plugin to generate the project.
+func generateTemplateProject(template workspace.Template, cwd string, args newArgs) error {
+	// Copy the template somewhere else first, so that its project name and description are filled in.
+	templateDir, err := ioutil.TempDir("", "pulumi-template-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		contract.IgnoreError(os.RemoveAll(templateDir))
+	}()
+	if err = workspace.CopyTemplateFiles(template.Dir, templateDir, true, args.name, args.description); err != nil {
+		return err
+	}
+
+	projectPath, err := workspace.DetectProjectPathFrom(templateDir)
+	if err != nil {
+		return err
+	}
+	templateProject, err := workspace.LoadProject(projectPath)
+	if err != nil {
+		return err
+	}
+
+	runtimeName := languageRuntimeName(args.language)
+	switch templateProject.Runtime.Name() {
+	case runtimeName:
+		return workspace.CopyTemplateFiles(template.Dir, cwd, args.force, args.name, args.description)
+	case "yaml":
+		// Generate the project below.
+	default:
+		return fmt.Errorf("template '%s' is written in %s, and only YAML templates can be generated in another "+
+			"language", template.Name, templateProject.Runtime.Name())
+	}
+
+	project, program, err := yamlgen.Eject(templateDir, nil)
+	if err != nil {
+		return fmt.Errorf("could not load the template's YAML program: %w", err)
+	}
+
+	sink := cmdutil.Diag()
+	pluginCtx, err := plugin.NewContext(sink, sink, nil, nil, cwd, nil, true, nil)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(pluginCtx)
+
+	generator := pluginProjectGenerator(pluginCtx, runtimeName, builtinProjectGenerator(args.language))
+	if err = generator(cwd, *project, program); err != nil {
+		return fmt.Errorf("could not generate the %s project: %w", args.language, err)
+	}
+	return nil
+}
+
 // errorIfNotEmptyDirectory returns an error if path is not empty.
 func errorIfNotEmptyDirectory(path string) error {
 	infos, err := ioutil.ReadDir(path)
@@ -24,6 +24,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -43,6 +44,7 @@ func newPreviewCmd() *cobra.Command {
 	var client string
 	var planFilePath string
 	var showSecrets bool
+	var detectDrift bool
 
 	// Flags for engine.UpdateOptions.
 	var jsonDisplay bool
@@ -51,6 +53,7 @@ func newPreviewCmd() *cobra.Command {
 	var diffDisplay bool
 	var eventLogPath string
 	var parallel int
+	var concurrencyLimits []string
 	var refresh string
 	var showConfig bool
 	var showReplacementSteps bool
@@ -62,6 +65,8 @@ func newPreviewCmd() *cobra.Command {
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var excludes []string
+	var excludeDependents bool
 
 	var cmd = &cobra.Command{
 		Use:        "preview",
@@ -78,13 +83,24 @@ func newPreviewCmd() *cobra.Command {
 			"actually take place.\n" +
 			"\n" +
 			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
-			"`--cwd` flag to use a different directory.",
+			"`--cwd` flag to use a different directory.\n" +
+			"\n" +
+			"With `--detect-drift`, the program is not run. Instead, the live state of each resource is\n" +
+			"read from its provider and compared against the stack's state, and a report of any drift\n" +
+			"is displayed. The command exits with an error if drift is detected.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			var displayType = display.DisplayProgress
 			if diffDisplay {
 				displayType = display.DisplayDiff
 			}
+			if detectDrift {
+				if planFilePath != "" || len(replaces) > 0 || len(targetReplaces) > 0 {
+					return result.FromError(
+						errors.New("--detect-drift cannot be used with --save-plan, --replace, or --target-replace"))
+				}
+				displayType = display.DisplayDrift
+			}
 
 			displayOpts := display.Options{
 				Color:                cmdutil.GetGlobalColorization(),
@@ -137,7 +153,7 @@ func newPreviewCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			m, err := getUpdateMetadata(message, root, execKind, execAgent)
+			m, err := getUpdateMetadata(message, root, execKind, execAgent, true /* runsProgram */)
 			if err != nil {
 				return result.FromError(fmt.Errorf("gathering environment metadata: %w", err))
 			}
@@ -152,6 +168,14 @@ func newPreviewCmd() *cobra.Command {
 				return result.FromError(fmt.Errorf("getting stack configuration: %w", err))
 			}
 
+			// The snapshot is only needed to expand the URN patterns in --exclude.
+			var snap *deploy.Snapshot
+			if len(excludes) > 0 {
+				if snap, err = s.Snapshot(commandContext()); err != nil {
+					return result.FromError(err)
+				}
+			}
+
 			targetURNs := []resource.URN{}
 			for _, t := range targets {
 				targetURNs = append(targetURNs, resource.URN(t))
@@ -171,11 +195,44 @@ func newPreviewCmd() *cobra.Command {
 			if err != nil {
 				return result.FromError(err)
 			}
+			limits, err := getConcurrencyLimits(proj, concurrencyLimits)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			if detectDrift {
+				return previewDrift(s, backend.UpdateOperation{
+					Proj: proj,
+					Root: root,
+					M:    m,
+					Opts: backend.UpdateOptions{
+						Engine: engine.UpdateOptions{
+							Parallel:                  parallel,
+							ConcurrencyLimits:         limits,
+							Debug:                     debug,
+							UseLegacyDiff:             useLegacyDiff(),
+							DisableProviderPreview:    disableProviderPreview(),
+							DisableResourceReferences: disableResourceReferences(),
+							DisableOutputValues:       disableOutputValues(),
+							RefreshTargets:            targetURNs,
+							Excludes:                  expandExcludes(snap, excludes),
+							ExcludeDependents:         excludeDependents,
+						},
+						Display:     displayOpts,
+						PreviewOnly: true,
+					},
+					StackConfiguration: cfg,
+					SecretsManager:     sm,
+					Scopes:             cancellationScopes,
+				})
+			}
 
 			opts := backend.UpdateOptions{
 				Engine: engine.UpdateOptions{
 					LocalPolicyPacks:          engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
 					Parallel:                  parallel,
+					ConcurrencyLimits:         limits,
+					RetryPolicy:               getRetryPolicy(proj),
 					Debug:                     debug,
 					Refresh:                   refreshOption,
 					ReplaceTargets:            replaceURNs,
@@ -185,6 +242,8 @@ func newPreviewCmd() *cobra.Command {
 					DisableOutputValues:       disableOutputValues(),
 					UpdateTargets:             targetURNs,
 					TargetDependents:          targetDependents,
+					Excludes:                  expandExcludes(snap, excludes),
+					ExcludeDependents:         excludeDependents,
 					ExperimentalPlans:         hasExperimentalCommands() || planFilePath != "",
 				},
 				Display: displayOpts,
@@ -253,6 +312,10 @@ func newPreviewCmd() *cobra.Command {
 	if !hasExperimentalCommands() {
 		contract.AssertNoError(cmd.PersistentFlags().MarkHidden("save-plan"))
 	}
+	cmd.PersistentFlags().BoolVar(
+		&detectDrift, "detect-drift", false,
+		"Read the live state of the stack's resources and report any drift from the recorded state "+
+			"instead of previewing the program")
 	cmd.Flags().BoolVarP(
 		&showSecrets, "show-secrets", "", false, "Emit secrets in plaintext in the plan file. Defaults to `false`")
 
@@ -278,6 +341,13 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows updating of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().StringArrayVar(
+		&excludes, "exclude", []string{},
+		"Specify a resource URN to leave unchanged. Multiple resources can be specified using"+
+			" --exclude urn1 --exclude urn2. Wildcards (*, **) are also supported")
+	cmd.PersistentFlags().BoolVar(
+		&excludeDependents, "exclude-dependents", false,
+		"Leaves the resources that depend on the resources in the --exclude list unchanged as well")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().StringSliceVar(
@@ -295,6 +365,10 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVar(
+		&concurrencyLimits, "concurrency-limit", []string{},
+		"Allow at most N operations at once for a provider package or resource type, as KEY=N (e.g. azure-native=8). "+
+			"May be specified multiple times; overrides the project's concurrency options")
 	cmd.PersistentFlags().StringVarP(
 		&refresh, "refresh", "r", "",
 		"Refresh the state of the stack's resources before this update")
@@ -337,3 +411,17 @@ func newPreviewCmd() *cobra.Command {
 
 	return cmd
 }
+
+// previewDrift runs a refresh preview that reads the live state of the stack's resources and reports any drift from
+// their recorded state. It returns an error if drift was detected so that scheduled checks can act on it.
+func previewDrift(s backend.Stack, op backend.UpdateOperation) result.Result {
+	changes, res := s.Refresh(commandContext(), op)
+	if res != nil {
+		return PrintEngineResult(res)
+	}
+
+	if drifted := changes[deploy.OpUpdate] + changes[deploy.OpDelete]; drifted > 0 {
+		return result.FromError(fmt.Errorf("drift detected in %d resources", drifted))
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
@@ -42,6 +43,7 @@ func newRefreshCmd() *cobra.Command {
 	var diffDisplay bool
 	var eventLogPath string
 	var parallel int
+	var concurrencyLimits []string
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
@@ -50,6 +52,9 @@ func newRefreshCmd() *cobra.Command {
 	var suppressPermalink string
 	var yes bool
 	var targets *[]string
+	var excludes []string
+	var excludeDependents bool
+	var runProgram bool
 
 	var cmd = &cobra.Command{
 		Use:   "refresh",
@@ -61,6 +66,10 @@ func newRefreshCmd() *cobra.Command {
 			"the program text isn't updated accordingly, subsequent updates may still appear to be out of\n" +
 			"synch with respect to the cloud provider's source of truth.\n" +
 			"\n" +
+			"By default, resources are refreshed using the providers and options recorded in the stack's state.\n" +
+			"Pass `--run-program` to run the program instead, and refresh the resources that it registers\n" +
+			"using the providers and options that it registers them with.\n" +
+			"\n" +
 			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
@@ -124,7 +133,7 @@ func newRefreshCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			m, err := getUpdateMetadata(message, root, execKind, execAgent)
+			m, err := getUpdateMetadata(message, root, execKind, execAgent, runProgram)
 			if err != nil {
 				return result.FromError(fmt.Errorf("gathering environment metadata: %w", err))
 			}
@@ -139,19 +148,36 @@ func newRefreshCmd() *cobra.Command {
 				return result.FromError(fmt.Errorf("getting stack configuration: %w", err))
 			}
 
+			// The snapshot is only needed to expand the URN patterns in --exclude.
+			var snap *deploy.Snapshot
+			if len(excludes) > 0 {
+				if snap, err = s.Snapshot(commandContext()); err != nil {
+					return result.FromError(err)
+				}
+			}
+
 			targetUrns := []resource.URN{}
 			for _, t := range *targets {
 				targetUrns = append(targetUrns, resource.URN(t))
 			}
 
+			limits, err := getConcurrencyLimits(proj, concurrencyLimits)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			opts.Engine = engine.UpdateOptions{
 				Parallel:                  parallel,
+				ConcurrencyLimits:         limits,
 				Debug:                     debug,
 				UseLegacyDiff:             useLegacyDiff(),
 				DisableProviderPreview:    disableProviderPreview(),
 				DisableResourceReferences: disableResourceReferences(),
 				DisableOutputValues:       disableOutputValues(),
 				RefreshTargets:            targetUrns,
+				Excludes:                  expandExcludes(snap, excludes),
+				ExcludeDependents:         excludeDependents,
+				RefreshProgram:            runProgram,
 			}
 
 			changes, res := s.Refresh(commandContext(), backend.UpdateOperation{
@@ -197,6 +223,16 @@ func newRefreshCmd() *cobra.Command {
 	targets = cmd.PersistentFlags().StringArrayP(
 		"target", "t", []string{},
 		"Specify a single resource URN to refresh. Multiple resource can be specified using: --target urn1 --target urn2")
+	cmd.PersistentFlags().StringArrayVar(
+		&excludes, "exclude", []string{},
+		"Specify a resource URN to leave unchanged. Multiple resources can be specified using"+
+			" --exclude urn1 --exclude urn2. Wildcards (*, **) are also supported")
+	cmd.PersistentFlags().BoolVar(
+		&excludeDependents, "exclude-dependents", false,
+		"Leaves the resources that depend on the resources in the --exclude list unchanged as well")
+	cmd.PersistentFlags().BoolVar(
+		&runProgram, "run-program", false,
+		"Run the program to determine the providers and options to refresh resources with")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().BoolVar(
@@ -208,6 +244,10 @@ func newRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVar(
+		&concurrencyLimits, "concurrency-limit", []string{},
+		"Allow at most N operations at once for a provider package or resource type, as KEY=N (e.g. azure-native=8). "+
+			"May be specified multiple times; overrides the project's concurrency options")
 	cmd.PersistentFlags().BoolVar(
 		&showReplacementSteps, "show-replacement-steps", false,
 		"Show detailed resource replacement creates and deletes instead of a single step")
@@ -168,7 +168,8 @@ func newStackCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(
 		&showIDs, "show-ids", "i", false, "Display each resource's provider-assigned unique ID")
 	cmd.Flags().BoolVarP(
-		&showURNs, "show-urns", "u", false, "Display each resource's Pulumi-assigned globally unique URN")
+		&showURNs, "show-urns", "u", false,
+		"Display each resource's Pulumi-assigned globally unique URN, and when it was created and last modified")
 	cmd.Flags().BoolVar(
 		&showSecrets, "show-secrets", false, "Display stack outputs which are marked as secret in plaintext")
 	cmd.Flags().BoolVar(
@@ -320,6 +321,12 @@ func renderResourceRow(res *resource.State, prefix, infoPrefix string, showURN,
 	// this on a single line, but this can get quite lengthy and so this formatting is better.
 	if showURN {
 		additionalInfo += fmt.Sprintf("    %sURN: %s\n", infoPrefix, res.URN)
+		if t := res.Created; t != nil {
+			additionalInfo += fmt.Sprintf("    %sCreated: %s (%v)\n", infoPrefix, humanize.Time(*t), *t)
+		}
+		if t := res.Modified; t != nil {
+			additionalInfo += fmt.Sprintf("    %sModified: %s (%v)\n", infoPrefix, humanize.Time(*t), *t)
+		}
 	}
 	if showID && res.ID != "" {
 		additionalInfo += fmt.Sprintf("    %sID: %s\n", infoPrefix, res.ID)
@@ -0,0 +1,128 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/backend/filestate"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+)
+
+func newStackHistoryPruneCmd() *cobra.Command {
+	var keep int
+	var maxAge time.Duration
+	var dryRun bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Args:  cmdutil.NoArgs,
+		Short: "Delete old history entries and checkpoint backups for a stack",
+		Long: "Delete old history entries and checkpoint backups for a stack.\n" +
+			"\n" +
+			"Self-managed backends keep a record and a copy of the checkpoint for every update, as well as\n" +
+			"a backup of the checkpoint for every change made outside of an update. This command deletes\n" +
+			"the ones that fall outside of the given retention policy: those beyond the most recent --keep\n" +
+			"updates, or older than --max-age (e.g. 720h). The stack's current checkpoint is never deleted.\n" +
+			"\n" +
+			"This command is only supported by self-managed backends.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			yes = yes || skipConfirmations()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			if keep == 0 && maxAge == 0 {
+				return errors.New("at least one of --keep or --max-age must be specified")
+			}
+			policy := filestate.HistoryRetentionPolicy{KeepCount: keep, MaxAge: maxAge}
+
+			// The --stack flag is inherited from `pulumi stack history`.
+			stack, err := cmd.Flags().GetString("stack")
+			if err != nil {
+				return err
+			}
+			s, err := requireStack(stack, false /*offerNew */, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			b, ok := s.Backend().(filestate.Backend)
+			if !ok {
+				return errors.New("pruning history is only supported by self-managed backends; " +
+					"the Pulumi Service manages the retention of update history itself")
+			}
+
+			// Always start with a dry run, so that we can show what is going to be deleted.
+			objects, err := b.PruneHistory(commandContext(), s.Ref(), policy, true /*dryRun*/)
+			if err != nil {
+				return fmt.Errorf("pruning history: %w", err)
+			}
+			if len(objects) == 0 {
+				fmt.Printf("No history to prune for stack '%s'.\n", s.Ref())
+				return nil
+			}
+
+			var total int64
+			for _, obj := range objects {
+				total += obj.Size
+			}
+			prefix := "This will delete"
+			if dryRun {
+				prefix = "Pruning would delete"
+			}
+			fmt.Print(opts.Color.Colorize(fmt.Sprintf("%s%s %d object(s) (%s) from the history of stack '%s':%s\n",
+				colors.SpecAttention, prefix, len(objects), humanize.Bytes(uint64(total)), s.Ref(), colors.Reset)))
+			for _, obj := range objects {
+				fmt.Printf("    %s (%s, %s)\n", obj.Key, humanize.Bytes(uint64(obj.Size)), humanize.Time(obj.Time))
+			}
+			if dryRun {
+				return nil
+			}
+
+			if !yes && !confirmPrompt("", s.Ref().String(), opts) {
+				fmt.Println("confirmation declined")
+				return nil
+			}
+			if _, err = b.PruneHistory(commandContext(), s.Ref(), policy, false /*dryRun*/); err != nil {
+				return fmt.Errorf("pruning history: %w", err)
+			}
+			fmt.Printf("Pruned the history of stack '%s'.\n", s.Ref())
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().IntVar(
+		&keep, "keep", 0,
+		"The number of most recent updates and backups to keep")
+	cmd.PersistentFlags().DurationVar(
+		&maxAge, "max-age", 0,
+		"Delete updates and backups older than this duration (e.g. 720h)")
+	cmd.PersistentFlags().BoolVar(
+		&dryRun, "dry-run", false,
+		"List what would be deleted without deleting anything")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with pruning anyway")
+
+	return cmd
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
@@ -48,6 +49,10 @@ troubleshooting a stack or when performing specific edits that otherwise would r
 	cmd.AddCommand(newStateDeleteCommand())
 	cmd.AddCommand(newStateUnprotectCommand())
 	cmd.AddCommand(newStateRenameCommand())
+	cmd.AddCommand(newStateRestoreCommand())
+	cmd.AddCommand(newStateMoveCommand())
+	cmd.AddCommand(newStateRepairCommand())
+	cmd.AddCommand(newStateUpgradeCommand())
 	return cmd
 }
 
@@ -140,20 +145,10 @@ func runTotalStateEdit(
 		return result.FromError(err)
 	}
 
-	if showPrompt && cmdutil.Interactive() {
-		confirm := false
-		surveycore.DisableColor = true
-		surveycore.QuestionIcon = ""
-		surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
-		prompt := opts.Color.Colorize(colors.Yellow + "warning" + colors.Reset + ": ")
-		prompt += "This command will edit your stack's state directly. Confirm?"
-		cmdutil.EndKeypadTransmitMode()
-		if err = survey.AskOne(&survey.Confirm{
-			Message: prompt,
-		}, &confirm, nil); err != nil || !confirm {
-			fmt.Println("confirmation declined")
-			return result.Bail()
-		}
+	if showPrompt && cmdutil.Interactive() && !confirmStateEdit(opts,
+		"This command will edit your stack's state directly. Confirm?") {
+		fmt.Println("confirmation declined")
+		return result.Bail()
 	}
 
 	// The `operation` callback will mutate `snap` in-place. In order to validate the correctness of the transformation
@@ -169,19 +164,41 @@ func runTotalStateEdit(
 		contract.AssertNoErrorf(snap.VerifyIntegrity(), "state edit produced an invalid snapshot")
 	}
 
+	// Once we've mutated the snapshot, import it back into the backend so that it can be persisted.
+	return result.WrapIfNonNil(saveSnapshot(s, snap))
+}
+
+// confirmStateEdit asks the user to confirm a direct edit to the state of a stack.
+func confirmStateEdit(opts display.Options, message string) bool {
+	confirm := false
+	surveycore.DisableColor = true
+	surveycore.QuestionIcon = ""
+	surveycore.SelectFocusIcon = opts.Color.Colorize(colors.BrightGreen + ">" + colors.Reset)
+	prompt := opts.Color.Colorize(colors.Yellow + "warning" + colors.Reset + ": ")
+	prompt += message
+	cmdutil.EndKeypadTransmitMode()
+	if err := survey.AskOne(&survey.Confirm{
+		Message: prompt,
+	}, &confirm, nil); err != nil {
+		return false
+	}
+	return confirm
+}
+
+// saveSnapshot replaces the state of the given stack with an edited snapshot.
+func saveSnapshot(s backend.Stack, snap *deploy.Snapshot) error {
 	sdep, err := stack.SerializeDeployment(snap, snap.SecretsManager, false /* showSecrets */)
 	if err != nil {
-		return result.FromError(fmt.Errorf("serializing deployment: %w", err))
+		return fmt.Errorf("serializing deployment: %w", err)
 	}
 
-	// Once we've mutated the snapshot, import it back into the backend so that it can be persisted.
 	bytes, err := json.Marshal(sdep)
 	if err != nil {
-		return result.FromError(err)
+		return err
 	}
 	dep := apitype.UntypedDeployment{
 		Version:    apitype.DeploymentSchemaVersionCurrent,
 		Deployment: bytes,
 	}
-	return result.WrapIfNonNil(s.ImportDeployment(commandContext(), &dep))
+	return s.ImportDeployment(commandContext(), &dep)
 }
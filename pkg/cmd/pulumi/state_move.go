@@ -0,0 +1,240 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
+	"github.com/pulumi/pulumi/pkg/v3/version"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+func newStateMoveCommand() *cobra.Command {
+	var sourceStackName, sourceBackendURL string
+	var destStackName, destBackendURL string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "move --dest <stack> <resource URN>...",
+		Short: "Moves resources from one stack's state to another's",
+		Long: `Moves resources from one stack's state to another's
+
+This command moves resources, along with all of their children, from the state of one stack to the state of
+another. The resources are specified by their Pulumi URNs (use ` + "`pulumi stack --show-urns`" + ` to get them).
+The URNs of the moved resources are rewritten to belong to the destination stack, and the providers they use are
+copied to the destination stack along with them. Resources that use a default provider switch to the destination
+stack's default provider for the same package and version, if it has one.
+
+Resources can't be moved if resources that remain in the source stack depend on them. Dependencies of the moved
+resources on resources that remain in the source stack are dropped, and moved resources whose parents remain in the
+source stack are parented to the destination stack instead.
+
+The stacks may belong to different backends: use --source-backend and --dest-backend to specify the URL of a
+backend other than the current one. Note that this only changes the state; no resources are created, updated or
+deleted. Move the corresponding code to the destination stack's program before running 'pulumi up' on either stack.
+
+Make sure that URNs are single-quoted to avoid having characters unexpectedly interpreted by the shell.
+
+Example:
+pulumi state move --source dev --dest network 'urn:pulumi:dev::demo::aws:ec2/vpc:Vpc::main'
+`,
+		Args: cmdutil.MinimumNArgs(1),
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			yes = yes || skipConfirmations()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			if destStackName == "" {
+				return result.Error("the destination stack must be specified with --dest")
+			}
+			var urns []resource.URN
+			for _, arg := range args {
+				urn := resource.URN(arg)
+				if !urn.IsValid() {
+					return result.Errorf("The provided input URN %q is not valid", arg)
+				}
+				urns = append(urns, urn)
+			}
+
+			source, err := requireStackInBackend(sourceBackendURL, sourceStackName, opts)
+			if err != nil {
+				return result.FromError(err)
+			}
+			dest, err := requireStackInBackend(destBackendURL, destStackName, opts)
+			if err != nil {
+				return result.FromError(err)
+			}
+			if source.Backend().URL() == dest.Backend().URL() && source.Ref().String() == dest.Ref().String() {
+				return result.Error("the source and destination stacks must be different")
+			}
+
+			sourceSnap, err := source.Snapshot(commandContext())
+			if err != nil {
+				return result.FromError(err)
+			}
+			if sourceSnap == nil {
+				return result.Errorf("stack '%s' has no resources to move", source.Ref())
+			}
+			destSnap, err := dest.Snapshot(commandContext())
+			if err != nil {
+				return result.FromError(err)
+			}
+			if destSnap == nil || destSnap.SecretsManager == nil {
+				// The moved resources are re-encrypted with the destination stack's secrets provider.
+				sm, err := getStackSecretsManager(dest)
+				if err != nil {
+					return result.FromError(err)
+				}
+				if destSnap == nil {
+					destSnap = deploy.NewSnapshot(deploy.Manifest{
+						Time:    time.Now(),
+						Version: version.Version,
+					}, sm, nil, nil)
+				}
+				destSnap.SecretsManager = sm
+			}
+
+			// In order to validate the moves, we verify the integrity of both snapshots before we make them. If the
+			// snapshots were valid before, we'll assert that they are still valid afterwards.
+			sourceIsAlreadyHosed := sourceSnap.VerifyIntegrity() != nil
+			destIsAlreadyHosed := destSnap.VerifyIntegrity() != nil
+
+			destProject := destinationProject(destSnap, dest.Ref(), urns[0])
+			moved, err := edit.MoveResources(sourceSnap, destSnap, urns, dest.Ref().Name().Q(), destProject)
+			if err != nil {
+				var depErr edit.ResourceHasDependentsError
+				if errors.As(err, &depErr) {
+					message := fmt.Sprintf("The resource %q can't be moved because the following resources, which "+
+						"are not being moved, depend on it:\n", depErr.Moved.URN)
+					for _, dependentResource := range depErr.Dependents {
+						depUrn := dependentResource.URN
+						message += fmt.Sprintf(" * %-15q (%s)\n", depUrn.Name(), depUrn)
+					}
+					message += "\nMove those resources as well, or remove their dependencies first."
+					return result.Error(message)
+				}
+				return result.FromError(err)
+			}
+			if !sourceIsAlreadyHosed {
+				contract.AssertNoErrorf(sourceSnap.VerifyIntegrity(), "state move produced an invalid source snapshot")
+			}
+			if !destIsAlreadyHosed {
+				contract.AssertNoErrorf(destSnap.VerifyIntegrity(), "state move produced an invalid dest snapshot")
+			}
+
+			fmt.Println(opts.Color.Colorize(fmt.Sprintf("%sResources to move to stack '%s':%s",
+				colors.SpecHeadline, dest.Ref(), colors.Reset)))
+			for _, res := range moved {
+				fmt.Println(opts.Color.Colorize("    " + deploy.Prefix(deploy.OpCreate, true) + string(res.URN) +
+					colors.Reset))
+			}
+
+			if !yes && cmdutil.Interactive() && !confirmStateEdit(opts,
+				"This command will edit the state of both stacks directly. Confirm?") {
+				fmt.Println("confirmation declined")
+				return result.Bail()
+			}
+
+			// Save the destination first, so that if saving the source fails the resources are left in both stacks,
+			// rather than in neither of them.
+			if err := saveSnapshot(dest, destSnap); err != nil {
+				return result.FromError(fmt.Errorf("saving the destination stack: %w", err))
+			}
+			if err := saveSnapshot(source, sourceSnap); err != nil {
+				return result.FromError(fmt.Errorf("saving the source stack (the moved resources are now in both "+
+					"stacks, and must be removed from the source stack with `pulumi state delete`): %w", err))
+			}
+
+			fmt.Printf("Moved %d resource(s) from stack '%s' to stack '%s'\n", len(moved), source.Ref(), dest.Ref())
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&sourceStackName, "source", "",
+		"The name of the stack to move resources from. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&sourceBackendURL, "source-backend", "",
+		"The URL of the source stack's backend. Defaults to the current backend")
+	cmd.PersistentFlags().StringVar(
+		&destStackName, "dest", "",
+		"The name of the stack to move resources to")
+	cmd.PersistentFlags().StringVar(
+		&destBackendURL, "dest-backend", "",
+		"The URL of the destination stack's backend. Defaults to the current backend")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	return cmd
+}
+
+// requireStackInBackend requires that a stack exists in the backend with the given URL, or in the current backend if
+// the URL is empty.
+func requireStackInBackend(backendURL, stackName string, opts display.Options) (backend.Stack, error) {
+	if backendURL == "" {
+		return requireStack(stackName, false, opts, false /*setCurrent*/)
+	}
+	if stackName == "" {
+		return nil, fmt.Errorf("a stack name must be given for the backend %s", backendURL)
+	}
+
+	b, err := backendForURL(backendURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	stackRef, err := b.ParseStackReference(stackName)
+	if err != nil {
+		return nil, err
+	}
+	s, err := b.GetStack(commandContext(), stackRef)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("no stack named '%s' found in %s", stackName, b.URL())
+	}
+	return s, nil
+}
+
+// projectReference is implemented by the references of stacks that are scoped by project.
+type projectReference interface {
+	Project() tokens.Name
+}
+
+// destinationProject returns the project that resources moved to the given snapshot belong to: that of its root stack
+// resource if it has one, or else that of the destination stack's reference. Stacks in self-managed backends that use
+// the legacy layout aren't scoped by project, in which case the resources keep the project of their URNs.
+func destinationProject(snap *deploy.Snapshot, ref backend.StackReference, urn resource.URN) tokens.PackageName {
+	for _, res := range snap.Resources {
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			return res.URN.Project()
+		}
+	}
+	if ref, ok := ref.(projectReference); ok && ref.Project() != "" {
+		return tokens.PackageName(ref.Project())
+	}
+	return urn.Project()
+}
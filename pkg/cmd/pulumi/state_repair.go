@@ -0,0 +1,168 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+func newStateRepairCommand() *cobra.Command {
+	var stackName string
+	var yes bool
+	var rebindProviders bool
+	var removeResources bool
+
+	cmd := &cobra.Command{
+		Use:   "repair",
+		Short: "Repairs problems with a stack's state",
+		Long: `Repairs problems with a stack's state
+
+This command checks a stack's state for problems, such as those left behind by an interrupted update, and repairs
+them one at a time. For each problem, the proposed fix is displayed and must be confirmed before it is made. The
+problems that can be repaired are:
+
+  - pending operations, which are cleared;
+  - resources that come before the resources they refer to, which are reordered;
+  - references to missing providers, which are switched to another provider for the same package if
+    --rebind-providers is passed, or if there is none, whose resources are removed from the state if
+    --remove-resources is passed;
+  - references to missing parents, which are replaced with the stack itself;
+  - dependencies on missing resources, which are removed;
+  - duplicate resources, all but one of which are marked for deletion.
+
+The state is loaded without checking its integrity, so --disable-integrity-checking is not needed to repair it.
+
+Note that this only changes the state; no resources are created, updated or deleted. Run 'pulumi refresh' afterwards
+to reconcile the repaired state with the actual resources.`,
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			yes = yes || skipConfirmations()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			if !yes && !cmdutil.Interactive() {
+				return result.Error("--yes must be passed in to proceed when running in non-interactive mode")
+			}
+			repairOpts := edit.RepairOptions{
+				RebindProviders: rebindProviders,
+				RemoveResources: removeResources,
+			}
+
+			// The self-managed backends refuse to load or replace snapshots that fail their integrity checks, which
+			// are the ones that need repairing, so the checks are disabled while the stack is loaded and saved.
+			var s backend.Stack
+			var snap *deploy.Snapshot
+			err := withoutIntegrityChecking(func() error {
+				stack, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+				if err != nil {
+					return err
+				}
+				s = stack
+				snap, err = s.Snapshot(commandContext())
+				return err
+			})
+			if err != nil {
+				return result.FromError(err)
+			}
+			if snap == nil {
+				fmt.Printf("Stack '%s' has no state to repair.\n", s.Ref())
+				return nil
+			}
+
+			var repaired int
+			var lastProblem string
+			for {
+				repair, err := edit.ProposeRepair(snap, repairOpts)
+				var notAllowed edit.RepairNotAllowedError
+				if errors.As(err, &notAllowed) {
+					flag := "--rebind-providers"
+					if notAllowed.Repair.RemovesResources() {
+						flag = "--remove-resources"
+					}
+					return result.Errorf("%s; pass %s to %s", notAllowed.Repair.Problem, flag, notAllowed.Repair.Fix)
+				}
+				if err != nil {
+					return result.FromError(err)
+				}
+				if repair == nil {
+					break
+				}
+				if repair.Problem == lastProblem {
+					return result.Errorf("repairing the problem did not fix it: %s", repair.Problem)
+				}
+				lastProblem = repair.Problem
+
+				fmt.Println(opts.Color.Colorize(colors.SpecHeadline + "Problem: " + colors.Reset + repair.Problem))
+				fmt.Println(opts.Color.Colorize(colors.SpecHeadline + "Fix: " + colors.Reset + repair.Fix))
+				if !yes && !confirmStateEdit(opts, "Apply this fix to the stack's state?") {
+					fmt.Println("confirmation declined")
+					break
+				}
+				repair.Apply(snap)
+				repaired++
+			}
+
+			if repaired == 0 {
+				if lastProblem == "" {
+					fmt.Printf("No problems were found with the state of stack '%s'.\n", s.Ref())
+				}
+				return nil
+			}
+			// The repaired snapshot is checked here instead.
+			if err := snap.VerifyIntegrity(); err != nil {
+				return result.Errorf("the repaired state is still invalid: %v", err)
+			}
+			if err := withoutIntegrityChecking(func() error { return saveSnapshot(s, snap) }); err != nil {
+				return result.FromError(err)
+			}
+			fmt.Printf("Repaired %d problem(s) with the state of stack '%s'.\n", repaired, s.Ref())
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+	cmd.Flags().BoolVar(
+		&rebindProviders, "rebind-providers", false,
+		"Allow resources that refer to missing providers to be switched to another provider for the same package")
+	cmd.Flags().BoolVar(
+		&removeResources, "remove-resources", false,
+		"Allow resources that can't be repaired any other way to be removed from the state")
+
+	return cmd
+}
+
+// withoutIntegrityChecking runs f with the self-managed backends' snapshot integrity checks disabled.
+func withoutIntegrityChecking(f func() error) error {
+	disabled := filestate.DisableIntegrityChecking
+	filestate.DisableIntegrityChecking = true
+	defer func() { filestate.DisableIntegrityChecking = disabled }()
+	return f()
+}
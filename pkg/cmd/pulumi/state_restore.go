@@ -0,0 +1,207 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+func newStateRestoreCommand() *cobra.Command {
+	var stackName string
+	var version string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore a stack's state to a previous version",
+		Long: `Restore a stack's state to a previous version
+
+This command replaces the resources in a stack's state with those recorded by a previous update. The versions of
+a stack's updates are listed by 'pulumi stack history'. Before the state is replaced, the resources that would be
+added, removed or changed are displayed.
+
+Note that this only changes the state; no resources are created, updated or deleted. Run 'pulumi refresh' afterwards
+to reconcile the restored state with the actual resources.`,
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			yes = yes || skipConfirmations()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			if version == "" {
+				return result.Error("the version to restore must be specified with --version")
+			}
+
+			s, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return result.FromError(err)
+			}
+			be := s.Backend()
+			specificExpBE, ok := be.(backend.SpecificDeploymentExporter)
+			if !ok {
+				return result.Errorf("the current backend (%s) does not provide the ability to export previous "+
+					"deployments", be.Name())
+			}
+
+			deployment, err := specificExpBE.ExportDeploymentForVersion(commandContext(), s, version)
+			if err != nil {
+				return result.FromError(err)
+			}
+			target, err := stack.DeserializeUntypedDeployment(deployment, stack.DefaultSecretsProvider)
+			if err != nil {
+				return result.FromError(checkDeploymentVersionError(err, s.Ref().Name().String()))
+			}
+			current, err := s.Snapshot(commandContext())
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			if printSnapshotDiff(opts, current, target) == 0 {
+				fmt.Printf("The resources of stack '%s' already match version %s.\n", s.Ref(), version)
+				return nil
+			}
+
+			res := runTotalStateEdit(s.Ref().String(), !yes, func(_ display.Options, snap *deploy.Snapshot) error {
+				if snap == nil {
+					return fmt.Errorf("stack '%s' has no state to restore", s.Ref())
+				}
+				// Keep the current manifest and secrets manager, so that the restored resources are re-encrypted
+				// with the stack's current secrets provider.
+				snap.Resources = target.Resources
+				snap.PendingOperations = nil
+				return nil
+			})
+			if res != nil {
+				return res
+			}
+			fmt.Printf("Restored stack '%s' to version %s.\n", s.Ref(), version)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&version, "version", "", "The version of the stack's state to restore")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
+
+	return cmd
+}
+
+// printSnapshotDiff prints the resources that differ between two snapshots, returning the number of differences.
+func printSnapshotDiff(opts display.Options, current, target *deploy.Snapshot) int {
+	indexByURN := func(snap *deploy.Snapshot) map[resource.URN]*resource.State {
+		index := make(map[resource.URN]*resource.State)
+		if snap != nil {
+			for _, res := range snap.Resources {
+				// Resources pending deletion share their URN with their replacement, which is the one we want.
+				if _, has := index[res.URN]; !has || !res.Delete {
+					index[res.URN] = res
+				}
+			}
+		}
+		return index
+	}
+	olds, news := indexByURN(current), indexByURN(target)
+
+	urns := make([]string, 0, len(olds)+len(news))
+	for urn := range olds {
+		urns = append(urns, string(urn))
+	}
+	for urn := range news {
+		if _, has := olds[urn]; !has {
+			urns = append(urns, string(urn))
+		}
+	}
+	sort.Strings(urns)
+
+	var lines []string
+	for _, u := range urns {
+		urn := resource.URN(u)
+		old, new := olds[urn], news[urn]
+		switch {
+		case old == nil:
+			lines = append(lines, deploy.Prefix(deploy.OpCreate, true)+u+colors.Reset)
+		case new == nil:
+			lines = append(lines, deploy.Prefix(deploy.OpDelete, true)+u+colors.Reset)
+		default:
+			if changes := resourceStateChanges(old, new); len(changes) > 0 {
+				lines = append(lines, fmt.Sprintf("%s%s%s (%s)", deploy.Prefix(deploy.OpUpdate, true), u, colors.Reset,
+					strings.Join(changes, ", ")))
+			}
+		}
+	}
+
+	if len(lines) > 0 {
+		fmt.Println(opts.Color.Colorize(colors.SpecHeadline + "Resource changes:" + colors.Reset))
+		for _, line := range lines {
+			fmt.Println(opts.Color.Colorize("    " + line))
+		}
+	}
+	return len(lines)
+}
+
+// resourceStateChanges returns the names of the parts of a resource's state that differ between two versions.
+func resourceStateChanges(old, new *resource.State) []string {
+	var changes []string
+	if old.ID != new.ID {
+		changes = append(changes, "id")
+	}
+	if !old.Inputs.DeepEquals(new.Inputs) {
+		changes = append(changes, "inputs")
+	}
+	if !old.Outputs.DeepEquals(new.Outputs) {
+		changes = append(changes, "outputs")
+	}
+	if old.Parent != new.Parent || old.Provider != new.Provider {
+		changes = append(changes, "parent or provider")
+	}
+	if !urnsEqual(old.Dependencies, new.Dependencies) {
+		changes = append(changes, "dependencies")
+	}
+	if old.Protect != new.Protect {
+		changes = append(changes, "protect")
+	}
+	if old.Delete != new.Delete || old.PendingReplacement != new.PendingReplacement {
+		changes = append(changes, "pending deletion")
+	}
+	return changes
+}
+
+func urnsEqual(a, b []resource.URN) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
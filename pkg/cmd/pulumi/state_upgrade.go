@@ -0,0 +1,83 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/backend/filestate"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+)
+
+func newStateUpgradeCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Migrate the current backend to the latest supported version",
+		Long: "Migrate the current backend to the latest supported version\n" +
+			"\n" +
+			"This only has an effect on self-managed backends whose stacks are not scoped by project.\n" +
+			"Such backends are migrated automatically when they are used, but a migration that was\n" +
+			"blocked, e.g. by a stack that was locked at the time, can be retried with this command.\n" +
+			"\n" +
+			"Every stack is moved into the project recorded in its resources or project tag. Stacks that\n" +
+			"have never been updated and don't record their project are left in place. All stacks are\n" +
+			"locked while they are moved, and other processes using an older version of the CLI will\n" +
+			"not see the migrated stacks.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			yes = yes || skipConfirmations()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			b, err := currentBackend(opts)
+			if err != nil {
+				return err
+			}
+			lb, ok := b.(filestate.Backend)
+			if !ok {
+				// Only the self-managed backends have anything to upgrade.
+				return nil
+			}
+
+			if !yes {
+				if !cmdutil.Interactive() {
+					return errors.New("--yes must be passed in to proceed when running in non-interactive mode")
+				}
+				if !confirmStateEdit(opts, "This will move every stack in the backend into its project, "+
+					"and older versions of the CLI will no longer be able to use them. Do you want to continue?") {
+					return errors.New("confirmation declined")
+				}
+			}
+
+			if err := lb.Upgrade(commandContext()); err != nil {
+				return fmt.Errorf("upgrading the backend: %w", err)
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with the upgrade anyway")
+
+	return cmd
+}
@@ -62,6 +62,7 @@ func newUpCmd() *cobra.Command {
 	var diffDisplay bool
 	var eventLogPath string
 	var parallel int
+	var concurrencyLimits []string
 	var refresh string
 	var showConfig bool
 	var showReplacementSteps bool
@@ -76,6 +77,9 @@ func newUpCmd() *cobra.Command {
 	var replaces []string
 	var targetReplaces []string
 	var targetDependents bool
+	var continueOnError bool
+	var excludes []string
+	var excludeDependents bool
 	var planFilePath string
 
 	// up implementation used when the source of the Pulumi program is in the current working directory.
@@ -95,7 +99,7 @@ func newUpCmd() *cobra.Command {
 			return result.FromError(err)
 		}
 
-		m, err := getUpdateMetadata(message, root, execKind, execAgent)
+		m, err := getUpdateMetadata(message, root, execKind, execAgent, true /* runsProgram */)
 		if err != nil {
 			return result.FromError(fmt.Errorf("gathering environment metadata: %w", err))
 		}
@@ -144,9 +148,15 @@ func newUpCmd() *cobra.Command {
 		if err != nil {
 			return result.FromError(err)
 		}
+		limits, err := getConcurrencyLimits(proj, concurrencyLimits)
+		if err != nil {
+			return result.FromError(err)
+		}
 		opts.Engine = engine.UpdateOptions{
 			LocalPolicyPacks:          engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
 			Parallel:                  parallel,
+			ConcurrencyLimits:         limits,
+			RetryPolicy:               getRetryPolicy(proj),
 			Debug:                     debug,
 			Refresh:                   refreshOption,
 			RefreshTargets:            targetURNs,
@@ -157,6 +167,9 @@ func newUpCmd() *cobra.Command {
 			DisableOutputValues:       disableOutputValues(),
 			UpdateTargets:             targetURNs,
 			TargetDependents:          targetDependents,
+			Excludes:                  expandExcludes(snap, excludes),
+			ExcludeDependents:         excludeDependents,
+			ContinueOnError:           continueOnError,
 			ExperimentalPlans:         hasExperimentalCommands() || planFilePath != "",
 		}
 
@@ -320,7 +333,7 @@ func newUpCmd() *cobra.Command {
 			return result.FromError(err)
 		}
 
-		m, err := getUpdateMetadata(message, root, execKind, execAgent)
+		m, err := getUpdateMetadata(message, root, execKind, execAgent, true /* runsProgram */)
 		if err != nil {
 			return result.FromError(fmt.Errorf("gathering environment metadata: %w", err))
 		}
@@ -339,12 +352,19 @@ func newUpCmd() *cobra.Command {
 		if err != nil {
 			return result.FromError(err)
 		}
+		limits, err := getConcurrencyLimits(proj, concurrencyLimits)
+		if err != nil {
+			return result.FromError(err)
+		}
 
 		opts.Engine = engine.UpdateOptions{
 			LocalPolicyPacks:  engine.MakeLocalPolicyPacks(policyPackPaths, policyPackConfigPaths),
 			Parallel:          parallel,
+			ConcurrencyLimits: limits,
+			RetryPolicy:       getRetryPolicy(proj),
 			Debug:             debug,
 			Refresh:           refreshOption,
+			ContinueOnError:   continueOnError,
 			ExperimentalPlans: hasExperimentalCommands() || planFilePath != "",
 		}
 
@@ -502,6 +522,16 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&targetDependents, "target-dependents", false,
 		"Allows updating of dependent targets discovered but not specified in --target list")
+	cmd.PersistentFlags().StringArrayVar(
+		&excludes, "exclude", []string{},
+		"Specify a resource URN to leave unchanged. Multiple resources can be specified using"+
+			" --exclude urn1 --exclude urn2. Wildcards (*, **) are also supported")
+	cmd.PersistentFlags().BoolVar(
+		&excludeDependents, "exclude-dependents", false,
+		"Leaves the resources that depend on the resources in the --exclude list unchanged as well")
+	cmd.PersistentFlags().BoolVar(
+		&continueOnError, "continue-on-error", false,
+		"Continue updating resources that don't depend on a failed resource, and report all failures at the end")
 
 	// Flags for engine.UpdateOptions.
 	cmd.PersistentFlags().StringSliceVar(
@@ -519,6 +549,10 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (1 for no parallelism). Defaults to unbounded.")
+	cmd.PersistentFlags().StringArrayVar(
+		&concurrencyLimits, "concurrency-limit", []string{},
+		"Allow at most N operations at once for a provider package or resource type, as KEY=N (e.g. azure-native=8). "+
+			"May be specified multiple times; overrides the project's concurrency options")
 	cmd.PersistentFlags().StringVarP(
 		&refresh, "refresh", "r", "",
 		"Refresh the state of the stack's resources before this update")
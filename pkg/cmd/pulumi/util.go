@@ -28,10 +28,12 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	multierror "github.com/hashicorp/go-multierror"
 	opentracing "github.com/opentracing/opentracing-go"
 
+	"google.golang.org/grpc/codes"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
 	git "gopkg.in/src-d/go-git.v4"
@@ -40,6 +42,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/backend/filestate"
 	"github.com/pulumi/pulumi/pkg/v3/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/v3/backend/sqlstate"
 	"github.com/pulumi/pulumi/pkg/v3/backend/state"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
@@ -50,12 +53,14 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/constant"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/ciutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/gitutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
@@ -67,6 +72,13 @@ func hasExperimentalCommands() bool {
 	return cmdutil.IsTruthy(os.Getenv("PULUMI_EXPERIMENTAL"))
 }
 
+// isUnimplementedError returns true if the given error was returned by a plugin that does not implement the RPC that
+// was called.
+func isUnimplementedError(err error) bool {
+	var rpcError *rpcerror.Error
+	return errors.As(err, &rpcError) && rpcError.Code() == codes.Unimplemented
+}
+
 func useLegacyDiff() bool {
 	return cmdutil.IsTruthy(os.Getenv("PULUMI_ENABLE_LEGACY_DIFF"))
 }
@@ -106,7 +118,7 @@ func isFilestateBackend(opts display.Options) (bool, error) {
 		return false, fmt.Errorf("could not get cloud url: %w", err)
 	}
 
-	return filestate.IsFileStateBackendURL(url), nil
+	return filestate.IsFileStateBackendURL(url) || sqlstate.IsSQLStateBackendURL(url), nil
 }
 
 func currentBackend(opts display.Options) (backend.Backend, error) {
@@ -118,10 +130,17 @@ func currentBackend(opts display.Options) (backend.Backend, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not get cloud url: %w", err)
 	}
+	return backendForURL(url, opts)
+}
 
+// backendForURL returns the backend with the given URL, which need not be the current backend.
+func backendForURL(url string, opts display.Options) (backend.Backend, error) {
 	if filestate.IsFileStateBackendURL(url) {
 		return filestate.New(cmdutil.Diag(), url)
 	}
+	if sqlstate.IsSQLStateBackendURL(url) {
+		return sqlstate.New(cmdutil.Diag(), url)
+	}
 	return httpstate.Login(commandContext(), cmdutil.Diag(), url, opts)
 }
 
@@ -156,6 +175,10 @@ func createSecretsManager(b backend.Backend, stackRef backend.StackReference, se
 		// The default when using the filestate backend is the passphrase secrets provider
 		secretsProvider = passphrase.Type
 	}
+	if _, ok := b.(sqlstate.Backend); ok && isDefaultSecretsProvider {
+		// As is the default when using the SQL backend
+		secretsProvider = passphrase.Type
+	}
 
 	if _, ok := b.(httpstate.Backend); ok && isDefaultSecretsProvider {
 		stack, err := state.CurrentStack(commandContext(), b)
@@ -553,13 +576,25 @@ func isGitWorkTreeDirty(repoRoot string) (bool, error) {
 }
 
 // getUpdateMetadata returns an UpdateMetadata object, with optional data about the environment
-// performing the update.
-func getUpdateMetadata(msg, root, execKind, execAgent string) (*backend.UpdateMetadata, error) {
+// performing the update. The versions of the program's dependencies are only included if the operation runs the
+// program, as detecting them requires starting its language host.
+func getUpdateMetadata(msg, root, execKind, execAgent string,
+	runsProgram bool) (*backend.UpdateMetadata, error) {
+
 	m := &backend.UpdateMetadata{
 		Message:     msg,
 		Environment: make(map[string]string),
 	}
 
+	// Detect the program's dependencies while gathering the rest. If that takes too long, the detection is canceled,
+	// which shuts down the language host it started.
+	var deps <-chan map[string]string
+	if runsProgram {
+		ctx, cancel := context.WithTimeout(context.Background(), programDependencyMetadataTimeout)
+		defer cancel()
+		deps = startProgramDependencyMetadata(ctx, root)
+	}
+
 	if err := addGitMetadata(root, m); err != nil {
 		logging.V(3).Infof("errors detecting git metadata: %s", err)
 	}
@@ -568,6 +603,12 @@ func getUpdateMetadata(msg, root, execKind, execAgent string) (*backend.UpdateMe
 
 	addExecutionMetadataToEnvironment(m.Environment, execKind, execAgent)
 
+	if deps != nil {
+		for k, v := range <-deps {
+			m.Environment[k] = v
+		}
+	}
+
 	return m, nil
 }
 
@@ -737,6 +778,61 @@ func addExecutionMetadataToEnvironment(env map[string]string, execKind, execAgen
 	}
 }
 
+// programDependencyMetadataTimeout bounds how long an operation waits for the program's dependencies to be detected
+// before going ahead without them.
+var programDependencyMetadataTimeout = 5 * time.Second
+
+// startProgramDependencyMetadata begins detecting the dependencies of the program in the given project directory in
+// the background. The returned channel receives the environment metadata for them once they have been detected, or
+// no metadata if the given context is done first.
+func startProgramDependencyMetadata(ctx context.Context, root string) <-chan map[string]string {
+	detected := make(chan map[string]string, 1)
+	go func() {
+		env := make(map[string]string)
+		if err := addProgramDependencyMetadataToEnvironment(ctx, root, env); err != nil {
+			logging.V(3).Infof("errors detecting program dependencies: %s", err)
+		}
+		detected <- env
+	}()
+
+	result := make(chan map[string]string, 1)
+	go func() {
+		select {
+		case env := <-detected:
+			result <- env
+		case <-ctx.Done():
+			logging.V(3).Infof("stopped detecting program dependencies: %v", ctx.Err())
+			result <- nil
+		}
+	}()
+	return result
+}
+
+// addProgramDependencyMetadataToEnvironment populates the environment metadata bag with the versions of the direct
+// dependencies of the program in the given project directory.
+func addProgramDependencyMetadataToEnvironment(ctx context.Context, root string, env map[string]string) error {
+	path, err := workspace.DetectProjectPathFrom(root)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return nil
+	}
+	proj, err := workspace.LoadProject(path)
+	if err != nil {
+		return err
+	}
+
+	deps, err := getProgramDependenciesAbout(ctx, proj, root, false /*transitive*/)
+	if err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		env[backend.ProgramDependencyPrefix+dep.Name] = dep.Version
+	}
+	return nil
+}
+
 type cancellationScope struct {
 	context *cancel.Context
 	sigint  chan os.Signal
@@ -869,6 +965,44 @@ func getRefreshOption(proj *workspace.Project, refresh string) (bool, error) {
 	return false, nil
 }
 
+// getConcurrencyLimits merges the concurrency limits in the project's options with those passed via
+// --concurrency-limit flags, which take precedence. Each flag has the form KEY=N, where KEY is a provider package
+// (e.g. "aws") or a resource type (e.g. "aws:s3/bucket:Bucket").
+func getConcurrencyLimits(proj *workspace.Project, flags []string) (map[string]int, error) {
+	limits := map[string]int{}
+	if proj.Options != nil {
+		for key, limit := range proj.Options.Concurrency {
+			limits[key] = limit
+		}
+	}
+
+	for _, flag := range flags {
+		eq := strings.LastIndex(flag, "=")
+		if eq <= 0 {
+			return nil, fmt.Errorf("invalid --concurrency-limit '%s': expected KEY=N", flag)
+		}
+		key, value := flag[:eq], flag[eq+1:]
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("invalid --concurrency-limit '%s': the limit must be a positive integer", flag)
+		}
+		limits[key] = limit
+	}
+
+	if len(limits) == 0 {
+		return nil, nil
+	}
+	return limits, nil
+}
+
+// getRetryPolicy returns the project's policy for retrying failed resource operations, if any.
+func getRetryPolicy(proj *workspace.Project) *resource.RetryPolicy {
+	if proj.Options == nil {
+		return nil
+	}
+	return proj.Options.Retry
+}
+
 func writePlan(path string, plan *deploy.Plan, enc config.Encrypter, showSecrets bool) error {
 	f, err := os.Create(path)
 	if err != nil {
@@ -947,3 +1081,17 @@ func log3rdPartySecretsProviderDecryptionEvent(ctx context.Context, backend back
 		}
 	}
 }
+
+// expandExcludes expands the wildcards in the URNs passed to --exclude against the resources in the snapshot. Unlike
+// targets, exclusions that match no resources aren't an error, as there is simply nothing to leave unchanged.
+func expandExcludes(snap *deploy.Snapshot, excludes []string) []resource.URN {
+	var urns []resource.URN
+	for _, e := range excludes {
+		if snap == nil {
+			urns = append(urns, resource.URN(e))
+			continue
+		}
+		urns = append(urns, snap.GlobUrn(resource.URN(e))...)
+	}
+	return urns
+}
@@ -100,7 +100,7 @@ func newWatchCmd() *cobra.Command {
 				return result.FromError(err)
 			}
 
-			m, err := getUpdateMetadata(message, root, execKind, "" /* execAgent */)
+			m, err := getUpdateMetadata(message, root, execKind, "" /* execAgent */, true /* runsProgram */)
 			if err != nil {
 				return result.FromError(fmt.Errorf("gathering environment metadata: %w", err))
 			}
@@ -64,3 +64,22 @@ func TestBindProgram(t *testing.T) {
 		}
 	}
 }
+
+func TestBindProgramSource(t *testing.T) {
+	t.Parallel()
+
+	source := map[string]string{
+		"config.pp":  "config greeting string {\n}\n",
+		"outputs.pp": "output message {\n\tvalue = \"${greeting}, world\"\n}\n",
+	}
+	program, diags, err := BindProgramSource(source)
+	assert.NoError(t, err)
+	assert.False(t, diags.HasErrors(), diags.Error())
+	assert.Len(t, program.Nodes, 2)
+	assert.Equal(t, source, program.Source())
+
+	program, diags, err = BindProgramSource(map[string]string{"main.pp": "output {"})
+	assert.NoError(t, err)
+	assert.True(t, diags.HasErrors())
+	assert.Nil(t, program)
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -91,6 +92,38 @@ type Program struct {
 	binder *binder
 }
 
+// Source returns the source text of the program's files, keyed by file name.
+func (p *Program) Source() map[string]string {
+	source := make(map[string]string, len(p.files))
+	for _, f := range p.files {
+		source[f.Name] = string(f.Bytes)
+	}
+	return source
+}
+
+// BindProgramSource parses and binds a program from the given source files, keyed by file name. This is the inverse of
+// Program.Source, and is used by language hosts to bind the programs they are asked to generate code for. If the source
+// cannot be parsed, the parser's diagnostics are returned with a nil program.
+func BindProgramSource(source map[string]string, opts ...BindOption) (*Program, hcl.Diagnostics, error) {
+	names := make([]string, 0, len(source))
+	for name := range source {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parser := syntax.NewParser()
+	for _, name := range names {
+		if err := parser.ParseFile(strings.NewReader(source[name]), name); err != nil {
+			return nil, nil, err
+		}
+	}
+	if parser.Diagnostics.HasErrors() {
+		return nil, parser.Diagnostics, nil
+	}
+
+	return BindProgram(parser.Files, opts...)
+}
+
 // NewDiagnosticWriter creates a new hcl.DiagnosticWriter for use with diagnostics generated by the program.
 func (p *Program) NewDiagnosticWriter(w io.Writer, width uint, color bool) hcl.DiagnosticWriter {
 	return syntax.NewDiagnosticWriter(w, p.files, width, color)
@@ -221,6 +221,7 @@ type runActions interface {
 
 	Changes() display.ResourceChanges
 	MaybeCorrupt() bool
+	FailedResources() []resource.URN
 }
 
 // run executes the deployment. It is primarily responsible for handling cancellation.
@@ -257,16 +258,22 @@ func (deployment *deployment) run(cancelCtx *Context, actions runActions, policy
 			Parallel:                  deployment.Options.Parallel,
 			Refresh:                   deployment.Options.Refresh,
 			RefreshOnly:               deployment.Options.isRefresh,
+			RefreshProgram:            deployment.Options.RefreshProgram,
 			RefreshTargets:            deployment.Options.RefreshTargets,
 			ReplaceTargets:            deployment.Options.ReplaceTargets,
 			DestroyTargets:            deployment.Options.DestroyTargets,
 			UpdateTargets:             deployment.Options.UpdateTargets,
 			TargetDependents:          deployment.Options.TargetDependents,
+			Excludes:                  deployment.Options.Excludes,
+			ExcludeDependents:         deployment.Options.ExcludeDependents,
 			TrustDependencies:         deployment.Options.trustDependencies,
 			UseLegacyDiff:             deployment.Options.UseLegacyDiff,
 			DisableResourceReferences: deployment.Options.DisableResourceReferences,
 			DisableOutputValues:       deployment.Options.DisableOutputValues,
 			ExperimentalPlans:         deployment.Options.UpdateOptions.ExperimentalPlans,
+			ContinueOnError:           deployment.Options.ContinueOnError,
+			ConcurrencyLimits:         deployment.Options.ConcurrencyLimits,
+			RetryPolicy:               deployment.Options.RetryPolicy,
 		}
 		newPlan, walkResult = deployment.Deployment.Execute(ctx, opts, preview)
 		close(done)
@@ -297,7 +304,8 @@ func (deployment *deployment) run(cancelCtx *Context, actions runActions, policy
 	changes := actions.Changes()
 
 	// Emit a summary event.
-	deployment.Options.Events.summaryEvent(preview, actions.MaybeCorrupt(), duration, changes, policyPacks)
+	deployment.Options.Events.summaryEvent(preview, actions.MaybeCorrupt(), duration, changes, policyPacks,
+		actions.FailedResources())
 
 	return newPlan, changes, res
 }
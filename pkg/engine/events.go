@@ -58,6 +58,8 @@ func NewEvent(typ EventType, payload interface{}) Event {
 		_, ok = payload.(ResourceOutputsEventPayload)
 	case ResourceOperationFailed:
 		_, ok = payload.(ResourceOperationFailedPayload)
+	case ResourceRetryEvent:
+		_, ok = payload.(ResourceRetryEventPayload)
 	case PolicyViolationEvent:
 		_, ok = payload.(PolicyViolationEventPayload)
 	default:
@@ -82,6 +84,7 @@ const (
 	ResourcePreEvent        EventType = "resource-pre"
 	ResourceOutputsEvent    EventType = "resource-outputs"
 	ResourceOperationFailed EventType = "resource-operationfailed"
+	ResourceRetryEvent      EventType = "resource-retry"
 	PolicyViolationEvent    EventType = "policy-violation"
 )
 
@@ -132,6 +135,7 @@ type SummaryEventPayload struct {
 	Duration        time.Duration           // the duration of the entire update operation (zero values for previews)
 	ResourceChanges display.ResourceChanges // count of changed resources, useful for reporting
 	PolicyPacks     map[string]string       // {policy-pack: version} for each policy pack applied
+	FailedResources []resource.URN          // the resources whose operations failed, if any
 }
 
 type ResourceOperationFailedPayload struct {
@@ -140,6 +144,16 @@ type ResourceOperationFailedPayload struct {
 	Steps    int
 }
 
+// ResourceRetryEventPayload is the payload for an event with type `resource-retry`, which reports that a failed
+// resource operation is about to be retried according to its retry policy.
+type ResourceRetryEventPayload struct {
+	Metadata    StepEventMetadata
+	Attempt     int           // the number of this retry, starting at 1.
+	MaxAttempts int           // the maximum number of retries the policy allows.
+	Delay       time.Duration // how long the engine waits before retrying.
+	Error       string        // the error that caused the operation to fail.
+}
+
 type ResourceOutputsEventPayload struct {
 	Metadata StepEventMetadata
 	Planning bool
@@ -154,17 +168,18 @@ type ResourcePreEventPayload struct {
 
 // StepEventMetadata contains the metadata associated with a step the engine is performing.
 type StepEventMetadata struct {
-	Op           display.StepOp                 // the operation performed by this step.
-	URN          resource.URN                   // the resource URN (for before and after).
-	Type         tokens.Type                    // the type affected by this step.
-	Old          *StepEventStateMetadata        // the state of the resource before performing this step.
-	New          *StepEventStateMetadata        // the state of the resource after performing this step.
-	Res          *StepEventStateMetadata        // the latest state for the resource that is known (worst case, old).
-	Keys         []resource.PropertyKey         // the keys causing replacement (only for CreateStep and ReplaceStep).
-	Diffs        []resource.PropertyKey         // the keys causing diffs
-	DetailedDiff map[string]plugin.PropertyDiff // the rich, structured diff
-	Logical      bool                           // true if this step represents a logical operation in the program.
-	Provider     string                         // the provider that performed this step.
+	Op             display.StepOp                 // the operation performed by this step.
+	URN            resource.URN                   // the resource URN (for before and after).
+	Type           tokens.Type                    // the type affected by this step.
+	Old            *StepEventStateMetadata        // the state of the resource before performing this step.
+	New            *StepEventStateMetadata        // the state of the resource after performing this step.
+	Res            *StepEventStateMetadata        // the latest state for the resource that is known (worst case, old).
+	Keys           []resource.PropertyKey         // the keys causing replacement (only for CreateStep and ReplaceStep).
+	Diffs          []resource.PropertyKey         // the keys causing diffs
+	DetailedDiff   map[string]plugin.PropertyDiff // the rich, structured diff
+	Logical        bool                           // true if this step represents a logical operation in the program.
+	Provider       string                         // the provider that performed this step.
+	SourcePosition string                         // the position in the program that registered this resource, if any.
 }
 
 // StepEventStateMetadata contains detailed metadata about a resource's state pertaining to a given step.
@@ -309,17 +324,18 @@ func makeStepEventMetadata(op display.StepOp, step deploy.Step, debug bool) Step
 	}
 
 	return StepEventMetadata{
-		Op:           op,
-		URN:          step.URN(),
-		Type:         step.Type(),
-		Keys:         keys,
-		Diffs:        diffs,
-		DetailedDiff: detailedDiff,
-		Old:          makeStepEventStateMetadata(step.Old(), debug),
-		New:          makeStepEventStateMetadata(step.New(), debug),
-		Res:          makeStepEventStateMetadata(step.Res(), debug),
-		Logical:      step.Logical(),
-		Provider:     step.Provider(),
+		Op:             op,
+		URN:            step.URN(),
+		Type:           step.Type(),
+		Keys:           keys,
+		Diffs:          diffs,
+		DetailedDiff:   detailedDiff,
+		Old:            makeStepEventStateMetadata(step.Old(), debug),
+		New:            makeStepEventStateMetadata(step.New(), debug),
+		Res:            makeStepEventStateMetadata(step.Res(), debug),
+		Logical:        step.Logical(),
+		Provider:       step.Provider(),
+		SourcePosition: step.SourcePosition(),
 	}
 }
 
@@ -361,6 +377,20 @@ func (e *eventEmitter) resourceOperationFailedEvent(
 	})
 }
 
+func (e *eventEmitter) resourceRetryEvent(
+	step deploy.Step, attempt, maxAttempts int, delay time.Duration, err error, debug bool) {
+
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	e.ch <- NewEvent(ResourceRetryEvent, ResourceRetryEventPayload{
+		Metadata:    makeStepEventMetadata(step.Op(), step, debug),
+		Attempt:     attempt,
+		MaxAttempts: maxAttempts,
+		Delay:       delay,
+		Error:       err.Error(),
+	})
+}
+
 func (e *eventEmitter) resourceOutputsEvent(op display.StepOp, step deploy.Step, planning bool, debug bool) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
@@ -401,7 +431,7 @@ func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 }
 
 func (e *eventEmitter) summaryEvent(preview, maybeCorrupt bool, duration time.Duration,
-	resourceChanges display.ResourceChanges, policyPacks map[string]string) {
+	resourceChanges display.ResourceChanges, policyPacks map[string]string, failedResources []resource.URN) {
 
 	contract.Requiref(e != nil, "e", "!= nil")
 
@@ -411,6 +441,7 @@ func (e *eventEmitter) summaryEvent(preview, maybeCorrupt bool, duration time.Du
 		Duration:        duration,
 		ResourceChanges: resourceChanges,
 		PolicyPacks:     policyPacks,
+		FailedResources: failedResources,
 	})
 }
 
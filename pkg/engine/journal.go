@@ -66,7 +66,10 @@ func (entries JournalEntries) Snap(base *deploy.Snapshot) (*deploy.Snapshot, err
 		if e.Kind == JournalEntrySuccess {
 			switch e.Step.Op() {
 			case deploy.OpSame, deploy.OpUpdate:
-				resources = append(resources, e.Step.New())
+				// Skipped creates are never written to the snapshot.
+				if same, ok := e.Step.(*deploy.SameStep); !ok || !same.IsSkippedCreate() {
+					resources = append(resources, e.Step.New())
+				}
 				dones[e.Step.Old()] = true
 			case deploy.OpCreate, deploy.OpCreateReplacement:
 				resources = append(resources, e.Step.New())
@@ -86,6 +89,14 @@ func (entries JournalEntries) Snap(base *deploy.Snapshot) (*deploy.Snapshot, err
 				}
 			case deploy.OpRemovePendingReplace:
 				dones[e.Step.Old()] = true
+			case deploy.OpRefresh:
+				// Most refreshes rewrite the base snapshot in memory, but some are recorded like any other step.
+				if e.Step.(*deploy.RefreshStep).Persisted() {
+					if e.Step.New() != nil {
+						resources = append(resources, e.Step.New())
+					}
+					dones[e.Step.Old()] = true
+				}
 			case deploy.OpImport, deploy.OpImportReplacement:
 				resources = append(resources, e.Step.New())
 				dones[e.Step.New()] = true
@@ -21,9 +21,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
@@ -32,6 +34,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	. "github.com/pulumi/pulumi/pkg/v3/engine"
@@ -885,6 +888,201 @@ func TestUpdatePartialFailure(t *testing.T) {
 	p.Run(t, old)
 }
 
+// Tests that an update that continues on error still creates the resources that are independent of a failed one, and
+// reports the failure in the summary. Resources that depend on the failed one, directly or not, are not created.
+func TestUpdateContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	var createdMutex sync.Mutex
+	var created []resource.URN
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					if urn.Name() == "resA" {
+						return "", nil, resource.StatusOK, errors.New("create failed")
+					}
+					createdMutex.Lock()
+					defer createdMutex.Unlock()
+					created = append(created, urn)
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	p := &TestPlan{}
+	resAURN := p.NewURN("pkgA:m:typA", "resA", "")
+	resBURN := p.NewURN("pkgA:m:typA", "resB", "")
+	resCURN := p.NewURN("pkgA:m:typA", "resC", "")
+	resDURN := p.NewURN("pkgA:m:typA", "resD", "")
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, mon *deploytest.ResourceMonitor) error {
+		_, _, _, errA := mon.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.Error(t, errA)
+
+		_, _, _, err := mon.RegisterResource("pkgA:m:typA", "resB", true)
+		assert.NoError(t, err)
+
+		// resC depends on resA, and resD on resC through one of its inputs.
+		_, _, _, err = mon.RegisterResource("pkgA:m:typA", "resC", true, deploytest.ResourceOptions{
+			Dependencies: []resource.URN{resAURN},
+		})
+		assert.Error(t, err)
+		_, _, _, err = mon.RegisterResource("pkgA:m:typA", "resD", true, deploytest.ResourceOptions{
+			Inputs:       resource.PropertyMap{"c": resource.NewStringProperty("value")},
+			PropertyDeps: map[resource.PropertyKey][]resource.URN{"c": {resCURN}},
+		})
+		assert.Error(t, err)
+		return errA
+	})
+
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options = UpdateOptions{Host: host, ContinueOnError: true}
+	p.Steps = []TestStep{{
+		Op:            Update,
+		ExpectFailure: true,
+		SkipPreview:   true,
+		Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+			evts []Event, res result.Result) result.Result {
+
+			assertIsErrorOrBailResult(t, res)
+
+			snap, err := entries.Snap(target.Snapshot)
+			require.NoError(t, err)
+			var urns []resource.URN
+			for _, res := range snap.Resources {
+				urns = append(urns, res.URN)
+			}
+			assert.Contains(t, urns, resBURN)
+			assert.NotContains(t, urns, resAURN)
+			assert.NotContains(t, urns, resCURN)
+			assert.NotContains(t, urns, resDURN)
+			assert.Equal(t, []resource.URN{resBURN}, created)
+
+			var summary *SummaryEventPayload
+			for _, evt := range evts {
+				if evt.Type == SummaryEvent {
+					payload := evt.Payload().(SummaryEventPayload)
+					summary = &payload
+				}
+			}
+			require.NotNil(t, summary)
+			assert.Equal(t, []resource.URN{resAURN}, summary.FailedResources)
+
+			return res
+		},
+	}}
+
+	p.Run(t, nil)
+}
+
+// Tests that a destroy that continues on error still deletes the resources that are independent of a resource that
+// failed to be deleted, but leaves the resources that it depends on, directly or not, in place.
+func TestDestroyContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	var deletedMutex sync.Mutex
+	var deleted []resource.URN
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+
+					if urn.Name() == "resC" {
+						return resource.StatusOK, errors.New("delete failed")
+					}
+					deletedMutex.Lock()
+					defer deletedMutex.Unlock()
+					deleted = append(deleted, urn)
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	p := &TestPlan{}
+	provURN := p.NewProviderURN("pkgA", "default", "")
+
+	resAURN := p.NewURN("pkgA:m:typA", "resA", "")
+	resBURN := p.NewURN("pkgA:m:typA", "resB", "")
+	resCURN := p.NewURN("pkgA:m:typA", "resC", "")
+	resDURN := p.NewURN("pkgA:m:typA", "resD", "")
+	newResource := func(urn resource.URN, deps ...resource.URN) *resource.State {
+		return &resource.State{
+			Type:         urn.Type(),
+			URN:          urn,
+			Custom:       true,
+			ID:           resource.ID(urn.Name() + "-id"),
+			Provider:     fmt.Sprintf("%v::provider-id", provURN),
+			Dependencies: deps,
+		}
+	}
+
+	// resC depends on resB, which depends on resA. resD is independent of them.
+	old := &deploy.Snapshot{
+		Resources: []*resource.State{
+			{
+				Type:    provURN.Type(),
+				URN:     provURN,
+				Custom:  true,
+				ID:      "provider-id",
+				Inputs:  resource.PropertyMap{},
+				Outputs: resource.PropertyMap{},
+			},
+			newResource(resAURN),
+			newResource(resBURN, resAURN),
+			newResource(resCURN, resBURN),
+			newResource(resDURN),
+		},
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, mon *deploytest.ResourceMonitor) error {
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options = UpdateOptions{Host: host, ContinueOnError: true}
+	p.Steps = []TestStep{{
+		Op:            Destroy,
+		ExpectFailure: true,
+		SkipPreview:   true,
+		Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+			evts []Event, res result.Result) result.Result {
+
+			assertIsErrorOrBailResult(t, res)
+
+			snap, err := entries.Snap(target.Snapshot)
+			require.NoError(t, err)
+			var urns []resource.URN
+			for _, res := range snap.Resources {
+				urns = append(urns, res.URN)
+			}
+			assert.Contains(t, urns, resAURN)
+			assert.Contains(t, urns, resBURN)
+			assert.Contains(t, urns, resCURN)
+			assert.NotContains(t, urns, resDURN)
+			assert.Equal(t, []resource.URN{resDURN}, deleted)
+
+			var summary *SummaryEventPayload
+			for _, evt := range evts {
+				if evt.Type == SummaryEvent {
+					payload := evt.Payload().(SummaryEventPayload)
+					summary = &payload
+				}
+			}
+			require.NotNil(t, summary)
+			assert.Equal(t, []resource.URN{resCURN}, summary.FailedResources)
+
+			return res
+		},
+	}}
+
+	p.Run(t, old)
+}
+
 // Tests that the StackReference resource works as intended,
 func TestStackReference(t *testing.T) {
 	t.Parallel()
@@ -2550,6 +2748,21 @@ func (ctx *updateContext) InstallDependencies(
 	return nil
 }
 
+func (ctx *updateContext) GenerateProgram(_ context.Context,
+	req *pulumirpc.GenerateProgramRequest) (*pulumirpc.GenerateProgramResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateProgram not implemented")
+}
+
+func (ctx *updateContext) GenerateProject(_ context.Context,
+	req *pulumirpc.GenerateProjectRequest) (*pulumirpc.GenerateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateProject not implemented")
+}
+
+func (ctx *updateContext) GetProgramDependencies(_ context.Context,
+	req *pulumirpc.GetProgramDependenciesRequest) (*pulumirpc.GetProgramDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProgramDependencies not implemented")
+}
+
 func TestLanguageClient(t *testing.T) {
 	t.Parallel()
 
@@ -4793,6 +5006,166 @@ func TestRetainOnDelete(t *testing.T) {
 	assert.Len(t, snap.Resources, 0)
 }
 
+func TestDeletedWith(t *testing.T) {
+	t.Parallel()
+
+	idCounter := 0
+	var deleted []resource.URN
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					resourceID := resource.ID(fmt.Sprintf("created-id-%d", idCounter))
+					idCounter = idCounter + 1
+					return resourceID, news, resource.StatusOK, nil
+				},
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+					deleted = append(deleted, urn)
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithoutGrpc),
+	}
+
+	createB, createC := true, true
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		urnA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+
+		if createB {
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, deploytest.ResourceOptions{
+				DeletedWith: urnA,
+			})
+			assert.NoError(t, err)
+		}
+		if createC {
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resC", true, deploytest.ResourceOptions{
+				DeletedWith: urnA,
+			})
+			assert.NoError(t, err)
+		}
+
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+
+	project := p.GetProject()
+
+	// Run an update to create the resources.
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.NotNil(t, snap)
+	assert.Len(t, snap.Resources, 4)
+	urnA := snap.Resources[1].URN
+	assert.Equal(t, urnA, snap.Resources[2].DeletedWith)
+
+	// Remove resB while resA remains: the provider must delete it.
+	createB = false
+	snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 3)
+	assert.Len(t, deleted, 1)
+	assert.Equal(t, tokens.QName("resB"), deleted[0].Name())
+
+	// Destroy the stack: resC is deleted along with resA, so the provider is only asked to delete resA.
+	deleted = nil
+	snap, res = TestOp(Destroy).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 0)
+	assert.Equal(t, []resource.URN{urnA}, deleted)
+}
+
+func TestDeletedWithReplacement(t *testing.T) {
+	t.Parallel()
+
+	// resB is deleted with resA and has an input that depends on it, so that a change to resA's key replaces both
+	// resources. Whether the replacement creates before deleting or deletes before creating (in which case resB is
+	// deleted as a dependent of resA), the provider should only be asked to delete the old resA.
+	for _, deleteBeforeReplace := range []bool{false, true} {
+		deleteBeforeReplace := deleteBeforeReplace
+		t.Run(fmt.Sprintf("deleteBeforeReplace=%v", deleteBeforeReplace), func(t *testing.T) {
+			t.Parallel()
+
+			idCounter := 0
+			var deleted []resource.URN
+
+			loaders := []*deploytest.ProviderLoader{
+				deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+					return &deploytest.Provider{
+						CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+							preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+							resourceID := resource.ID(fmt.Sprintf("created-id-%d", idCounter))
+							idCounter = idCounter + 1
+							return resourceID, news, resource.StatusOK, nil
+						},
+						DiffF: func(urn resource.URN, id resource.ID,
+							olds, news resource.PropertyMap, ignoreChanges []string) (plugin.DiffResult, error) {
+							if !olds["key"].DeepEquals(news["key"]) {
+								return plugin.DiffResult{ReplaceKeys: []resource.PropertyKey{"key"}}, nil
+							}
+							return plugin.DiffResult{}, nil
+						},
+						DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+							timeout float64) (resource.Status, error) {
+							deleted = append(deleted, urn)
+							return resource.StatusOK, nil
+						},
+					}, nil
+				}, deploytest.WithoutGrpc),
+			}
+
+			key := "value"
+			program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+				inputs := resource.PropertyMap{"key": resource.NewStringProperty(key)}
+
+				urnA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+					Inputs:              inputs,
+					DeleteBeforeReplace: &deleteBeforeReplace,
+				})
+				assert.NoError(t, err)
+
+				_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, deploytest.ResourceOptions{
+					Inputs:       inputs,
+					Dependencies: []resource.URN{urnA},
+					PropertyDeps: map[resource.PropertyKey][]resource.URN{"key": {urnA}},
+					DeletedWith:  urnA,
+				})
+				assert.NoError(t, err)
+
+				return nil
+			})
+			host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+			p := &TestPlan{
+				Options: UpdateOptions{Host: host},
+			}
+
+			project := p.GetProject()
+
+			// Run an update to create the resources.
+			snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+			assert.Nil(t, res)
+			assert.Len(t, snap.Resources, 3)
+			urnA := snap.Resources[1].URN
+
+			// Change the key to replace both resources: the old resB is deleted along with the old resA.
+			key = "changed"
+			snap, res = TestOp(Update).Run(project, p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+			assert.Nil(t, res)
+			assert.Len(t, snap.Resources, 3)
+			assert.Equal(t, []resource.URN{urnA}, deleted)
+		})
+	}
+}
+
 func TestInvalidGetIDReportsUserError(t *testing.T) {
 	t.Parallel()
 
@@ -5248,3 +5621,454 @@ func TestDefaultParents(t *testing.T) {
 	assert.Equal(t, snap.Resources[0].URN, snap.Resources[1].Parent)
 	assert.Equal(t, snap.Resources[0].URN, snap.Resources[2].Parent)
 }
+
+func TestSourcePosition(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			SourcePosition: &pulumirpc.SourcePosition{Uri: "file:///src/index.ts", Line: 12, Column: 3},
+		})
+		assert.NoError(t, err)
+
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps: []TestStep{{
+			Op: Update,
+			Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+				events []Event, res result.Result) result.Result {
+
+				positions := map[string]string{}
+				for _, entry := range entries {
+					if entry.Step.Op() == deploy.OpCreate {
+						positions[string(entry.Step.URN().Name())] = entry.Step.SourcePosition()
+					}
+				}
+				assert.Equal(t, map[string]string{
+					"default": "",
+					"resA":    filepath.FromSlash("/src/index.ts") + ":12:3",
+					"resB":    "",
+				}, positions)
+
+				for _, event := range events {
+					if event.Type == ResourcePreEvent {
+						payload := event.Payload().(ResourcePreEventPayload)
+						assert.Equal(t, positions[string(payload.Metadata.URN.Name())], payload.Metadata.SourcePosition)
+					}
+				}
+				return res
+			},
+		}},
+	}
+	p.Run(t, nil)
+}
+
+func TestConcurrencyLimits(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	active, maxActive := 0, 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					lock.Lock()
+					active++
+					if active > maxActive {
+						maxActive = active
+					}
+					lock.Unlock()
+
+					time.Sleep(10 * time.Millisecond)
+
+					lock.Lock()
+					active--
+					lock.Unlock()
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, _, _, err := monitor.RegisterResource("pkgA:m:typA", fmt.Sprintf("res%d", i), true)
+				assert.NoError(t, err)
+			}(i)
+		}
+		wg.Wait()
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{
+			Host:              host,
+			Parallel:          8,
+			ConcurrencyLimits: map[string]int{"pkgA": 2},
+		},
+	}
+	snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 9)
+	assert.LessOrEqual(t, maxActive, 2)
+}
+
+func TestThrottledStepsAreRetried(t *testing.T) {
+	t.Parallel()
+
+	creates := 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					creates++
+					if creates == 1 {
+						return "", nil, resource.StatusOK, rpcerror.New(codes.ResourceExhausted, "slow down")
+					}
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, 2, creates)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	creates := map[string]int{}
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					name := string(urn.Name())
+					creates[name]++
+					switch {
+					case name == "resA" && creates[name] == 1:
+						return "", nil, resource.StatusOK, errors.New("connection reset by peer")
+					case name == "resB":
+						return "", nil, resource.StatusOK, errors.New("bucket name already taken")
+					}
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			RetryPolicy: &resource.RetryPolicy{Attempts: 3, ErrorPatterns: []string{"connection reset"}},
+		})
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true)
+		assert.Error(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	// The stack-wide policy applies to resB, which sets no policy of its own.
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host, RetryPolicy: &resource.RetryPolicy{Attempts: 1}},
+	}
+	resA := p.NewURN("pkgA:m:typA", "resA", "")
+	snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(t, nil), p.Options, false, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, _ JournalEntries, events []Event, res result.Result) result.Result {
+			retries := map[resource.URN][]ResourceRetryEventPayload{}
+			for _, e := range events {
+				if e.Type == ResourceRetryEvent {
+					p := e.Payload().(ResourceRetryEventPayload)
+					retries[p.Metadata.URN] = append(retries[p.Metadata.URN], p)
+				}
+			}
+			assert.Len(t, retries, 2)
+			if assert.Len(t, retries[resA], 1) {
+				assert.Equal(t, 1, retries[resA][0].Attempt)
+				assert.Equal(t, 3, retries[resA][0].MaxAttempts)
+				assert.Contains(t, retries[resA][0].Error, "connection reset by peer")
+			}
+			return res
+		})
+	assert.NotNil(t, res)
+	assert.Equal(t, map[string]int{"resA": 2, "resB": 2}, creates)
+
+	// Only resA and its provider were created, and resA remembers its policy.
+	assert.Len(t, snap.Resources, 2)
+	assert.Equal(t, resA, snap.Resources[1].URN)
+	assert.Equal(t, &resource.RetryPolicy{Attempts: 3, ErrorPatterns: []string{"connection reset"}},
+		snap.Resources[1].RetryPolicy)
+
+}
+
+// TestRetryPolicyOverGRPC checks that failures reported by a provider over gRPC, whose outcome is unknown to the
+// engine, are retried only when the policy allows it.
+func TestRetryPolicyOverGRPC(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	creates := map[string]int{}
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					lock.Lock()
+					defer lock.Unlock()
+					name := string(urn.Name())
+					creates[name]++
+					if creates[name] == 1 {
+						return "", nil, resource.StatusOK, errors.New("connection reset by peer")
+					}
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}, deploytest.WithGrpc),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		// A matching error pattern allows the retry.
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			RetryPolicy: &resource.RetryPolicy{Attempts: 1, ErrorPatterns: []string{"connection reset"}},
+		})
+		assert.NoError(t, err)
+		// So does opting in to retrying failures with an unknown outcome.
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, deploytest.ResourceOptions{
+			RetryPolicy: &resource.RetryPolicy{Attempts: 1, RetryIfUnknown: true},
+		})
+		assert.NoError(t, err)
+		// Otherwise the failure is not retried, as the resource may have been created.
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resC", true, deploytest.ResourceOptions{
+			RetryPolicy: &resource.RetryPolicy{Attempts: 1},
+		})
+		assert.Error(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	_, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+	assert.Equal(t, map[string]int{"resA": 2, "resB": 2, "resC": 1}, creates)
+}
+
+func TestInvalidRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			RetryPolicy: &resource.RetryPolicy{Attempts: 3, Backoff: "soon"},
+		})
+		assert.ErrorContains(t, err, "invalid retry backoff 'soon'")
+		return err
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	_, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.NotNil(t, res)
+}
+
+func TestProjectTransformations(t *testing.T) {
+	t.Parallel()
+
+	var lock sync.Mutex
+	created := map[string]resource.PropertyMap{}
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					lock.Lock()
+					defer lock.Unlock()
+					created[string(urn.Name())] = news
+					return "created-id", news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		comp, _, _, err := monitor.RegisterResource("my:component:Comp", "team-comp", false)
+		assert.NoError(t, err)
+
+		// The child's tags are merged with the project's tags, and its other inputs are left alone.
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "team-resA", true, deploytest.ResourceOptions{
+			Parent: comp,
+			Inputs: resource.PropertyMap{
+				"tags": resource.NewObjectProperty(resource.PropertyMap{
+					"owner": resource.NewStringProperty("me"),
+					"env":   resource.NewStringProperty("dev"),
+				}),
+				"size": resource.NewNumberProperty(3),
+			},
+		})
+		assert.NoError(t, err)
+
+		// Types that do not match the tagging transformation are untouched.
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typB", "team-resB", true, deploytest.ResourceOptions{
+			Inputs: resource.PropertyMap{"size": resource.NewNumberProperty(1)},
+		})
+		assert.NoError(t, err)
+
+		// Names that do not match the naming pattern are rejected.
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typB", "resC", true)
+		assert.ErrorContains(t, err, "resource name 'resC' does not match the project's naming pattern '^team-'")
+
+		// Components are not transformed, as the transformations don't include them.
+		_, _, _, err = monitor.RegisterResource("my:component:Comp", "other-comp", false)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+	}
+	project := p.GetProject()
+	project.Options = &workspace.ProjectOptions{
+		Transformations: []workspace.ProjectTransformation{
+			{
+				Types: []string{"*:m:typA"},
+				Properties: map[string]interface{}{
+					"tags": map[interface{}]interface{}{"env": "prod", "cost-center": "1234"},
+				},
+			},
+			{NamePattern: "^team-"},
+		},
+	}
+
+	_, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Equal(t, map[string]resource.PropertyMap{
+		"team-resA": resource.NewPropertyMapFromMap(map[string]interface{}{
+			"tags": map[string]interface{}{"owner": "me", "env": "prod", "cost-center": "1234"},
+			"size": 3,
+		}),
+		"team-resB": resource.NewPropertyMapFromMap(map[string]interface{}{"size": 1}),
+	}, created)
+}
+
+func TestParallelDeletes(t *testing.T) {
+	t.Parallel()
+
+	// resS depends on resR, and resB depends on resA. resS is slow to delete, but must not hold up the deletes of resB
+	// and resA, which don't depend on it.
+	var lock sync.Mutex
+	var deleted []string
+	resADeleted := make(chan bool)
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DeleteF: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					timeout float64) (resource.Status, error) {
+
+					name := string(urn.Name())
+					switch name {
+					case "resS":
+						select {
+						case <-resADeleted:
+						case <-time.After(10 * time.Second):
+							return resource.StatusOK, errors.New("resA was not deleted while resS was deleting")
+						}
+					case "resA":
+						close(resADeleted)
+					}
+
+					lock.Lock()
+					defer lock.Unlock()
+					deleted = append(deleted, name)
+					return resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		resR, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resR", true)
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resS", true, deploytest.ResourceOptions{
+			Dependencies: []resource.URN{resR},
+		})
+		assert.NoError(t, err)
+		resA, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, deploytest.ResourceOptions{
+			Dependencies: []resource.URN{resA},
+		})
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host, Parallel: 10},
+	}
+	snap, res := TestOp(Update).Run(p.GetProject(), p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 5)
+
+	_, res = TestOp(Destroy).Run(p.GetProject(), p.GetTarget(t, snap), p.Options, false, p.BackendClient, nil)
+	assert.Nil(t, res)
+
+	// Each resource is still deleted after the resources that depend on it.
+	index := func(name string) int {
+		for i, n := range deleted {
+			if n == name {
+				return i
+			}
+		}
+		return -1
+	}
+	assert.Len(t, deleted, 4)
+	assert.Less(t, index("resB"), index("resA"))
+	assert.Less(t, index("resA"), index("resS"))
+	assert.Less(t, index("resS"), index("resR"))
+}
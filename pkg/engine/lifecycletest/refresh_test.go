@@ -916,3 +916,103 @@ func TestRefreshUpdateWithDeletedResource(t *testing.T) {
 	snap := p.Run(t, old)
 	assert.Equal(t, 0, len(snap.Resources))
 }
+
+// TestRefreshProgram validates that a refresh that runs the program reads the resources that the program registers
+// using the providers and options that the program registers them with, and reads the resources that it doesn't
+// register using the providers recorded in the snapshot.
+func TestRefreshProgram(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			var region resource.PropertyValue
+			return &deploytest.Provider{
+				ConfigureF: func(news resource.PropertyMap) error {
+					region = news["region"]
+					return nil
+				},
+				CreateF: func(urn resource.URN, news resource.PropertyMap, timeout float64,
+					preview bool) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					return resource.ID(urn.Name()), resource.PropertyMap{}, resource.StatusOK, nil
+				},
+				ReadF: func(urn resource.URN, id resource.ID,
+					inputs, state resource.PropertyMap) (plugin.ReadResult, resource.Status, error) {
+					return plugin.ReadResult{
+						Inputs:  inputs,
+						Outputs: resource.PropertyMap{"region": region},
+					}, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	region, refreshing := "a", false
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		provURN, provID, _, err := monitor.RegisterResource(providers.MakeProviderType("pkgA"), "provA", true,
+			deploytest.ResourceOptions{Inputs: resource.PropertyMap{"region": resource.NewStringProperty(region)}})
+		assert.NoError(t, err)
+
+		if provID == "" {
+			provID = providers.UnknownID
+		}
+
+		provRef, err := providers.NewReference(provURN, provID)
+		assert.NoError(t, err)
+
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resA", true, deploytest.ResourceOptions{
+			Provider: provRef.String(),
+			Protect:  refreshing,
+		})
+		assert.NoError(t, err)
+
+		if !refreshing {
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true, deploytest.ResourceOptions{
+				Provider: provRef.String(),
+			})
+			assert.NoError(t, err)
+		} else {
+			_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resC", true)
+			assert.NoError(t, err)
+		}
+
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{Host: host},
+		Steps:   []TestStep{{Op: Update}},
+	}
+
+	snap := p.Run(t, nil)
+	assert.Len(t, snap.Resources, 3)
+
+	// Change the provider's configuration, protect resA, stop registering resB, and start registering resC.
+	region, refreshing = "b", true
+	p.Options.RefreshProgram = true
+	p.Steps = []TestStep{{Op: Refresh}}
+	snap = p.Run(t, snap)
+
+	// resC should not have been created (though the default provider that it would use is), provA should have been
+	// reconfigured, resA should have been read using provA and protected, and resB should have been read using the
+	// provider recorded in the snapshot, which is also provA.
+	if !assert.Len(t, snap.Resources, 4) {
+		return
+	}
+	for _, res := range snap.Resources {
+		switch res.URN.Name() {
+		case "provA":
+			assert.Equal(t, resource.NewStringProperty("b"), res.Inputs["region"])
+		case "default":
+			assert.True(t, providers.IsProviderType(res.Type))
+		case "resA":
+			assert.True(t, res.Protect)
+			assert.Equal(t, resource.NewStringProperty("b"), res.Outputs["region"])
+		case "resB":
+			assert.False(t, res.Protect)
+			assert.Equal(t, resource.NewStringProperty("b"), res.Outputs["region"])
+		default:
+			assert.Failf(t, "unexpected resource", "%v", res.URN)
+		}
+	}
+}
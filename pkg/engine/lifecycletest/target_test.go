@@ -10,6 +10,7 @@ import (
 	. "github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
@@ -750,6 +751,128 @@ func generateParentedTestDependencyGraph(t *testing.T, p *TestPlan) (
 	return urns, old, program
 }
 
+func TestUpdateExclude(t *testing.T) {
+	t.Parallel()
+
+	//             A
+	//    _________|_________
+	//    B        C        D
+	//          ___|___  ___|___
+	//          E  F  G  H  I  J
+	//             |__|
+	//             K  L
+
+	// when excluding 'F' we expect only F to be left unchanged.
+	updateExcludedTargets(t, []string{"F"}, false /*excludeDependents*/, []string{"F"})
+
+	// when excluding 'F' with excludeDependents specified we expect F, K and L to be left unchanged.
+	updateExcludedTargets(t, []string{"F"}, true /*excludeDependents*/, []string{"F", "K", "L"})
+}
+
+func updateExcludedTargets(t *testing.T, excludes []string, excludeDependents bool, unchanged []string) {
+	p := &TestPlan{}
+
+	urns, old, program := generateComplexTestDependencyGraph(t, p)
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				DiffF: func(urn resource.URN, id resource.ID, olds, news resource.PropertyMap,
+					ignoreChanges []string) (plugin.DiffResult, error) {
+
+					// all resources will change.
+					return plugin.DiffResult{
+						Changes: plugin.DiffSome,
+					}, nil
+				},
+			}, nil
+		}),
+	}
+
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.ExcludeDependents = excludeDependents
+	for _, exclude := range excludes {
+		p.Options.Excludes = append(p.Options.Excludes, pickURN(t, urns, complexTestDependencyGraphNames, exclude))
+	}
+
+	p.Steps = []TestStep{{
+		Op: Update,
+		Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+			evts []Event, res result.Result) result.Result {
+
+			assert.Nil(t, res)
+
+			for _, entry := range entries {
+				urn := entry.Step.URN()
+				if providers.IsProviderType(urn.Type()) {
+					continue
+				}
+				if contains(unchanged, urn.Name().String()) {
+					assert.Equal(t, deploy.OpSame, entry.Step.Op(), "%v", urn)
+				} else {
+					assert.Equal(t, deploy.OpUpdate, entry.Step.Op(), "%v", urn)
+				}
+			}
+			return res
+		},
+	}}
+	p.Run(t, old)
+}
+
+func TestDestroyExclude(t *testing.T) {
+	t.Parallel()
+
+	// when excluding 'F' we expect F and the resources it depends on, A and C, to be left in place.
+	destroyExcludedTargets(t, []string{"F"}, false, /*excludeDependents*/
+		[]string{"B", "D", "E", "G", "H", "I", "J", "K", "L"})
+
+	// when excluding 'F' with excludeDependents specified we expect K and L, along with G, which they depend on, to be
+	// left in place as well.
+	destroyExcludedTargets(t, []string{"F"}, true, /*excludeDependents*/
+		[]string{"B", "D", "E", "H", "I", "J"})
+}
+
+func destroyExcludedTargets(t *testing.T, excludes []string, excludeDependents bool, expectDeleted []string) {
+	p := &TestPlan{}
+
+	urns, old, program := generateComplexTestDependencyGraph(t, p)
+
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+	}
+
+	p.Options.Host = deploytest.NewPluginHost(nil, nil, program, loaders...)
+	p.Options.ExcludeDependents = excludeDependents
+	for _, exclude := range excludes {
+		p.Options.Excludes = append(p.Options.Excludes, pickURN(t, urns, complexTestDependencyGraphNames, exclude))
+	}
+
+	p.Steps = []TestStep{{
+		Op: Destroy,
+		Validate: func(project workspace.Project, target deploy.Target, entries JournalEntries,
+			evts []Event, res result.Result) result.Result {
+
+			assert.Nil(t, res)
+
+			deleted := make(map[resource.URN]bool)
+			for _, entry := range entries {
+				assert.Equal(t, deploy.OpDelete, entry.Step.Op())
+				deleted[entry.Step.URN()] = true
+			}
+
+			expected := make(map[resource.URN]bool)
+			for _, name := range expectDeleted {
+				expected[pickURN(t, urns, complexTestDependencyGraphNames, name)] = true
+			}
+			assert.Equal(t, expected, deleted)
+			return res
+		},
+	}}
+	p.Run(t, old)
+}
+
 func TestDestroyTargetWithChildren(t *testing.T) {
 	t.Parallel()
 
@@ -50,12 +50,18 @@ func Refresh(
 	// Force opts.Refresh to true.
 	opts.Refresh = true
 
+	// A refresh that runs the program needs the same source as an update.
+	sourceFunc := newRefreshSource
+	if opts.RefreshProgram {
+		sourceFunc = newUpdateSource
+	}
+
 	logging.V(7).Infof("*** Starting Refresh(preview=%v) ***", dryRun)
 	defer logging.V(7).Infof("*** Refresh(preview=%v) complete ***", dryRun)
 
 	return update(ctx, info, deploymentOptions{
 		UpdateOptions: opts,
-		SourceFunc:    newRefreshSource,
+		SourceFunc:    sourceFunc,
 		Events:        emitter,
 		Diag:          newEventSink(emitter, false),
 		StatusDiag:    newEventSink(emitter, true),
@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	resourceanalyzer "github.com/pulumi/pulumi/pkg/v3/resource/analyzer"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
@@ -106,12 +107,22 @@ type UpdateOptions struct {
 	// the degree of parallelism for resource operations (<=1 for serial).
 	Parallel int
 
+	// limits on the number of concurrent resource operations for specific provider packages (e.g. "aws") or resource
+	// types (e.g. "aws:s3/bucket:Bucket"), within the overall degree of parallelism.
+	ConcurrencyLimits map[string]int
+
+	// the policy for retrying failed resource operations, used for resources that do not set their own.
+	RetryPolicy *resource.RetryPolicy
+
 	// true if debugging output it enabled
 	Debug bool
 
 	// true if the plan should refresh before executing.
 	Refresh bool
 
+	// true if a refresh should run the program to determine the providers and options to refresh resources with.
+	RefreshProgram bool
+
 	// Specific resources to refresh during a refresh operation.
 	RefreshTargets []resource.URN
 
@@ -128,6 +139,12 @@ type UpdateOptions struct {
 	// XXXTargets lists.
 	TargetDependents bool
 
+	// Specific resources to leave unchanged during an update, refresh or destroy operation.
+	Excludes []resource.URN
+
+	// true if the resources that depend on the resources in the Excludes list should be left unchanged too.
+	ExcludeDependents bool
+
 	// true if the engine should use legacy diffing behavior during an update.
 	UseLegacyDiff bool
 
@@ -151,6 +168,10 @@ type UpdateOptions struct {
 
 	// true if experimental plans should be generated.
 	ExperimentalPlans bool
+
+	// true if the engine should continue to execute steps that don't depend on a failed step, rather than canceling
+	// the deployment as soon as any step fails.
+	ContinueOnError bool
 }
 
 // HasChanges returns true if there are any non-same changes in the resulting summary.
@@ -492,6 +513,7 @@ type updateActions struct {
 	Opts    deploymentOptions
 
 	maybeCorrupt bool
+	failed       []resource.URN
 }
 
 func newUpdateActions(context *Context, u UpdateInfo, opts deploymentOptions) *updateActions {
@@ -540,6 +562,9 @@ func (acts *updateActions) OnResourceStepPost(
 		if status == resource.StatusUnknown {
 			acts.maybeCorrupt = true
 		}
+		acts.MapLock.Lock()
+		acts.failed = append(acts.failed, step.URN())
+		acts.MapLock.Unlock()
 
 		errorURN := resource.URN("")
 		if reportStep {
@@ -624,6 +649,14 @@ func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
 	return acts.Context.SnapshotManager.RegisterResourceOutputs(step)
 }
 
+func (acts *updateActions) OnResourceStepRetry(step deploy.Step, attempt, maxAttempts int, delay time.Duration,
+	err error) {
+
+	if shouldReportStep(step, acts.Opts) {
+		acts.Opts.Events.resourceRetryEvent(step, attempt, maxAttempts, delay, err, acts.Opts.Debug)
+	}
+}
+
 func (acts *updateActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic) {
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
@@ -632,6 +665,12 @@ func (acts *updateActions) MaybeCorrupt() bool {
 	return acts.maybeCorrupt
 }
 
+func (acts *updateActions) FailedResources() []resource.URN {
+	acts.MapLock.Lock()
+	defer acts.MapLock.Unlock()
+	return append([]resource.URN(nil), acts.failed...)
+}
+
 func (acts *updateActions) Changes() display.ResourceChanges {
 	return display.ResourceChanges(acts.Ops)
 }
@@ -741,6 +780,14 @@ func (acts *previewActions) OnResourceOutputs(step deploy.Step) error {
 	return nil
 }
 
+func (acts *previewActions) OnResourceStepRetry(step deploy.Step, attempt, maxAttempts int, delay time.Duration,
+	err error) {
+
+	if shouldReportStep(step, acts.Opts) {
+		acts.Opts.Events.resourceRetryEvent(step, attempt, maxAttempts, delay, err, acts.Opts.Debug)
+	}
+}
+
 func (acts *previewActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeDiagnostic) {
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
@@ -749,6 +796,10 @@ func (acts *previewActions) MaybeCorrupt() bool {
 	return false
 }
 
+func (acts *previewActions) FailedResources() []resource.URN {
+	return nil
+}
+
 func (acts *previewActions) Changes() display.ResourceChanges {
 	return display.ResourceChanges(acts.Ops)
 }
@@ -216,6 +216,14 @@ func (p *builtinProvider) GetPluginInfo() (workspace.PluginInfo, error) {
 	return workspace.PluginInfo{}, errors.New("the builtin provider does not report plugin info")
 }
 
+func (p *builtinProvider) GetMapping(key string) ([]byte, string, error) {
+	return nil, "", nil
+}
+
+func (p *builtinProvider) List(t tokens.Type, filter map[string]string) ([]plugin.ListedResource, error) {
+	return nil, fmt.Errorf("the builtin provider does not list resources of type %v", t)
+}
+
 func (p *builtinProvider) SignalCancellation() error {
 	p.cancel()
 	return nil
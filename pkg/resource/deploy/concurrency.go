@@ -0,0 +1,280 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
+)
+
+const (
+	// maxThrottledRetries is the number of times a step that was throttled by its provider is retried.
+	maxThrottledRetries = 5
+	// maxThrottledBackoff is the longest the step executor waits before retrying a throttled step.
+	maxThrottledBackoff = 30 * time.Second
+)
+
+// throttledBackoff returns how long to wait before retrying a step that has been throttled the given number of times.
+func throttledBackoff(attempt int) time.Duration {
+	backoff := time.Second << attempt
+	if backoff <= 0 || backoff > maxThrottledBackoff {
+		return maxThrottledBackoff
+	}
+	return backoff
+}
+
+// throttlingMessages are the (lowercased) fragments of error messages that providers commonly use to report that a
+// cloud API has rate limited a request.
+var throttlingMessages = []string{
+	"too many requests",
+	"toomanyrequests",
+	"throttl",
+	"rate exceeded",
+	"rate limit exceeded",
+	"ratelimitexceeded",
+	"statuscode=429",
+	"status code 429",
+	"error 429",
+}
+
+// isThrottlingError returns true if the given step error indicates that the provider was throttled.
+func isThrottlingError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var rpcErr *rpcerror.Error
+	if errors.As(err, &rpcErr) && rpcErr.Code() == codes.ResourceExhausted {
+		return true
+	}
+	if status.Code(err) == codes.ResourceExhausted {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range throttlingMessages {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// concurrencyLimit bounds the number of steps for a single provider package or resource type that may execute at
+// once. The limit starts out at its maximum, is halved each time a step is throttled, and grows back by one each time
+// a step succeeds.
+type concurrencyLimit struct {
+	lock    sync.Mutex
+	max     int           // the configured limit.
+	limit   int           // the current limit, which is lowered when steps are throttled.
+	active  int           // the number of steps currently holding the limit.
+	changed chan struct{} // closed and replaced whenever active or limit changes.
+}
+
+func newConcurrencyLimit(max int) *concurrencyLimit {
+	return &concurrencyLimit{max: max, limit: max, changed: make(chan struct{})}
+}
+
+// notify wakes any steps waiting on the limit. The lock must be held.
+func (l *concurrencyLimit) notify() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// acquire blocks until the limit allows another step to execute or the context is canceled.
+func (l *concurrencyLimit) acquire(ctx context.Context) error {
+	for {
+		l.lock.Lock()
+		if l.active < l.limit {
+			l.active++
+			l.lock.Unlock()
+			return nil
+		}
+		changed := l.changed
+		l.lock.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// tryAcquire acquires the limit if it allows another step to execute, without blocking.
+func (l *concurrencyLimit) tryAcquire() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.active < l.limit {
+		l.active++
+		return true
+	}
+	return false
+}
+
+// release signals that a step holding the limit has finished executing.
+func (l *concurrencyLimit) release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.active--
+	l.notify()
+}
+
+// throttled backs off after a step was throttled by halving the number of steps that may execute at once.
+func (l *concurrencyLimit) throttled() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	limit := l.limit
+	if l.active < limit {
+		limit = l.active
+	}
+	if limit /= 2; limit < 1 {
+		limit = 1
+	}
+	l.limit = limit
+}
+
+// succeeded allows one more step to execute at once, up to the configured limit.
+func (l *concurrencyLimit) succeeded() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.limit < l.max {
+		l.limit++
+		l.notify()
+	}
+}
+
+// current returns the number of steps that may currently execute at once.
+func (l *concurrencyLimit) current() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.limit
+}
+
+// acquireLimits blocks until all of the given limits have been acquired, in order, or the context is canceled. If the
+// context is canceled, none of the limits are held.
+func acquireLimits(ctx context.Context, limits []*concurrencyLimit) error {
+	for i, l := range limits {
+		if err := l.acquire(ctx); err != nil {
+			releaseLimits(limits[:i])
+			return err
+		}
+	}
+	return nil
+}
+
+// tryAcquireLimits acquires all of the given limits without blocking. If any of them does not allow another step to
+// execute, none of the limits are held and false is returned.
+func tryAcquireLimits(limits []*concurrencyLimit) bool {
+	for i, l := range limits {
+		if !l.tryAcquire() {
+			releaseLimits(limits[:i])
+			return false
+		}
+	}
+	return true
+}
+
+// releaseLimits releases all of the given limits.
+func releaseLimits(limits []*concurrencyLimit) {
+	for _, l := range limits {
+		l.release()
+	}
+}
+
+// concurrencyLimits manages the concurrency limits for each provider package and resource type in a deployment.
+type concurrencyLimits struct {
+	configured map[string]int // the limits configured by the user, keyed by package name or type token.
+	fallback   int            // the limit for packages that have no configured limit.
+
+	lock   sync.Mutex
+	limits map[string]*concurrencyLimit // the limits in use, keyed by package name or type token.
+}
+
+func newConcurrencyLimits(opts Options) *concurrencyLimits {
+	fallback := opts.DegreeOfParallelism()
+	if opts.InfiniteParallelism() {
+		fallback = math.MaxInt32
+	}
+	return &concurrencyLimits{
+		configured: opts.ConcurrencyLimits,
+		fallback:   fallback,
+		limits:     make(map[string]*concurrencyLimit),
+	}
+}
+
+// get returns the limit for the given key, creating it with the given maximum if necessary.
+func (c *concurrencyLimits) get(key string, max int) *concurrencyLimit {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	l, ok := c.limits[key]
+	if !ok {
+		l = newConcurrencyLimit(max)
+		c.limits[key] = l
+	}
+	return l
+}
+
+// forStep returns the limits that the given step must hold while it executes, in the order in which they must be
+// acquired. Every package has a limit so that throttled providers can be backed off, but types are only limited if
+// the user has asked for it. Steps that do not call their provider are not limited.
+func (c *concurrencyLimits) forStep(step Step) []*concurrencyLimit {
+	switch step.Op() {
+	case OpSame, OpReplace, OpRemovePendingReplace:
+		return nil
+	}
+	if res := step.Res(); res == nil || !res.Custom {
+		return nil
+	}
+
+	typ := step.Type()
+	pkg := packageOf(typ)
+
+	var limits []*concurrencyLimit
+	if max, ok := c.configured[string(typ)]; ok {
+		limits = append(limits, c.get(string(typ), max))
+	}
+	max, ok := c.configured[string(pkg)]
+	if !ok {
+		max = c.fallback
+	}
+	return append(limits, c.get(string(pkg), max))
+}
+
+// packageOf returns the provider package whose limit governs the given type.
+func packageOf(typ tokens.Type) tokens.Package {
+	if providers.IsProviderType(typ) {
+		return providers.GetProviderPackage(typ)
+	}
+	// Split the token by hand rather than using typ.Package(), which asserts that the type is well-formed.
+	return tokens.Package(strings.SplitN(string(typ), tokens.TokenDelimiter, 2)[0])
+}
@@ -0,0 +1,184 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
+)
+
+func TestIsThrottlingError(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, isThrottlingError(nil))
+	assert.False(t, isThrottlingError(errors.New("bucket name already taken")))
+	assert.True(t, isThrottlingError(rpcerror.New(codes.ResourceExhausted, "slow down")))
+	assert.True(t, isThrottlingError(rpcerror.Convert(rpcerror.New(codes.ResourceExhausted, "slow down"))))
+	assert.True(t, isThrottlingError(fmt.Errorf("creating: %w",
+		rpcerror.Convert(rpcerror.New(codes.ResourceExhausted, "slow down")))))
+	assert.True(t, isThrottlingError(errors.New("ThrottlingException: Rate exceeded")))
+	assert.True(t, isThrottlingError(errors.New("azure: StatusCode=429 -- Original Error: Too Many Requests")))
+	assert.False(t, isThrottlingError(rpcerror.Convert(rpcerror.New(codes.Unknown, "boom"))))
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	l := newConcurrencyLimit(4)
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, l.acquire(ctx))
+	}
+
+	// The fifth step must wait until another releases the limit.
+	acquired := make(chan error)
+	go func() { acquired <- l.acquire(ctx) }()
+	select {
+	case <-acquired:
+		t.Fatal("acquired more than the limit")
+	case <-time.After(10 * time.Millisecond):
+	}
+	l.release()
+	assert.NoError(t, <-acquired)
+
+	// Throttling halves the number of active steps, and successes grow the limit back.
+	l.throttled()
+	assert.Equal(t, 2, l.current())
+	l.throttled()
+	assert.Equal(t, 1, l.current())
+	l.throttled()
+	assert.Equal(t, 1, l.current())
+	for i := 0; i < 5; i++ {
+		l.succeeded()
+	}
+	assert.Equal(t, 4, l.current())
+
+	// Waiting steps give up if the deployment is canceled.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, l.acquire(canceled), context.Canceled)
+}
+
+func TestConcurrencyLimitsForStep(t *testing.T) {
+	t.Parallel()
+
+	limits := newConcurrencyLimits(Options{
+		Parallel: 16,
+		ConcurrencyLimits: map[string]int{
+			"pkgA":            2,
+			"pkgA:m:typSlow":  1,
+			"pkgB:index:typB": 3,
+		},
+	})
+
+	maxes := func(step Step) []int {
+		var result []int
+		for _, l := range limits.forStep(step) {
+			result = append(result, l.max)
+		}
+		return result
+	}
+
+	create := func(typ string) Step {
+		urn := resource.URN(fmt.Sprintf("urn:pulumi:stack::project::%s::name", typ))
+		return &CreateStep{new: &resource.State{Type: urn.Type(), URN: urn, Custom: true}}
+	}
+
+	assert.Equal(t, []int{2}, maxes(create("pkgA:m:typA")))
+	assert.Equal(t, []int{1, 2}, maxes(create("pkgA:m:typSlow")))
+	assert.Equal(t, []int{2}, maxes(create("pulumi:providers:pkgA")))
+	assert.Equal(t, []int{3, 16}, maxes(create("pkgB:index:typB")))
+	assert.Equal(t, []int{16}, maxes(create("pkgC:index:typC")))
+	assert.Equal(t, []int{16}, maxes(create("pkgD:malformed")))
+
+	// Steps that do not call their provider are not limited.
+	urn := resource.URN("urn:pulumi:stack::project::pkgA:m:typA::name")
+	state := &resource.State{Type: urn.Type(), URN: urn, Custom: true}
+	assert.Empty(t, limits.forStep(&SameStep{old: state, new: state}))
+	component := &resource.State{Type: "pkgA:m:component", URN: "urn:pulumi:stack::project::pkgA:m:component::name"}
+	assert.Empty(t, limits.forStep(&CreateStep{new: component}))
+
+	// Limits are shared by all steps with the same package.
+	assert.Same(t, limits.forStep(create("pkgA:m:typA"))[0], limits.forStep(create("pkgA:m:typSlow"))[1])
+}
+
+// blockingStep is a create step that blocks until the test releases it, so that tests can control which steps are
+// executing at once.
+type blockingStep struct {
+	*CreateStep
+	started chan<- resource.URN
+	release <-chan struct{}
+}
+
+func (s *blockingStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
+	s.started <- s.URN()
+	<-s.release
+	return resource.StatusOK, nil, nil
+}
+
+func TestStepExecutorParksLimitedSteps(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deployment := &Deployment{goals: &goalMap{}, news: &resourceMap{}}
+	opts := Options{Parallel: 2, ConcurrencyLimits: map[string]int{"pkgA": 1}}
+	se := newStepExecutor(ctx, cancel, deployment, opts, false, false)
+
+	started, release := make(chan resource.URN), make(chan struct{})
+	create := func(typ, name string) chain {
+		urn := resource.URN(fmt.Sprintf("urn:pulumi:stack::project::%s::%s", typ, name))
+		step := &CreateStep{new: &resource.State{Type: urn.Type(), URN: urn, Custom: true}}
+		return chain{&blockingStep{CreateStep: step, started: started, release: release}}
+	}
+	expectStarted := func(name string) {
+		select {
+		case urn := <-started:
+			assert.Equal(t, name, string(urn.Name()))
+		case <-time.After(10 * time.Second):
+			t.Fatalf("step %v did not start", name)
+		}
+	}
+
+	// The second pkgA step must wait for the first, but it must not hold on to a worker while it does so, or the pkgB
+	// step would never get one.
+	a1 := se.ExecuteSerial(create("pkgA:m:typ", "a1"))
+	expectStarted("a1")
+	a2 := se.ExecuteSerial(create("pkgA:m:typ", "a2"))
+	b := se.ExecuteSerial(create("pkgB:m:typ", "b"))
+	expectStarted("b")
+
+	release <- struct{}{}
+	release <- struct{}{}
+	expectStarted("a2")
+	release <- struct{}{}
+
+	se.SignalCompletion()
+	se.WaitForCompletion()
+	for _, token := range []completionToken{a1, a2, b} {
+		token.Wait(ctx)
+	}
+	assert.False(t, se.Errored())
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"time"
 
 	uuid "github.com/gofrs/uuid"
 
@@ -50,20 +51,26 @@ type BackendClient interface {
 
 // Options controls the deployment process.
 type Options struct {
-	Events                    Events         // an optional events callback interface.
-	Parallel                  int            // the degree of parallelism for resource operations (<=1 for serial).
-	Refresh                   bool           // whether or not to refresh before executing the deployment.
-	RefreshOnly               bool           // whether or not to exit after refreshing.
-	RefreshTargets            []resource.URN // The specific resources to refresh during a refresh op.
-	ReplaceTargets            []resource.URN // Specific resources to replace.
-	DestroyTargets            []resource.URN // Specific resources to destroy.
-	UpdateTargets             []resource.URN // Specific resources to update.
-	TargetDependents          bool           // true if we're allowing things to proceed, even with unspecified targets
-	TrustDependencies         bool           // whether or not to trust the resource dependency graph.
-	UseLegacyDiff             bool           // whether or not to use legacy diffing behavior.
-	DisableResourceReferences bool           // true to disable resource reference support.
-	DisableOutputValues       bool           // true to disable output value support.
-	ExperimentalPlans         bool           // true to enable experimental plan support.
+	Events                    Events                // an optional events callback interface.
+	Parallel                  int                   // the degree of parallelism for resource operations (<=1 for serial).
+	Refresh                   bool                  // whether or not to refresh before executing the deployment.
+	RefreshOnly               bool                  // whether or not to exit after refreshing.
+	RefreshProgram            bool                  // whether or not to run the program to determine how to refresh.
+	RefreshTargets            []resource.URN        // The specific resources to refresh during a refresh op.
+	ReplaceTargets            []resource.URN        // Specific resources to replace.
+	DestroyTargets            []resource.URN        // Specific resources to destroy.
+	UpdateTargets             []resource.URN        // Specific resources to update.
+	TargetDependents          bool                  // true if we're allowing things to proceed, even with unspecified targets
+	Excludes                  []resource.URN        // Specific resources to leave unchanged.
+	ExcludeDependents         bool                  // true to also leave the dependents of excluded resources unchanged.
+	TrustDependencies         bool                  // whether or not to trust the resource dependency graph.
+	UseLegacyDiff             bool                  // whether or not to use legacy diffing behavior.
+	DisableResourceReferences bool                  // true to disable resource reference support.
+	DisableOutputValues       bool                  // true to disable output value support.
+	ExperimentalPlans         bool                  // true to enable experimental plan support.
+	ContinueOnError           bool                  // true to continue with independent steps after a step fails.
+	ConcurrencyLimits         map[string]int        // per-package or per-type limits on concurrent resource operations.
+	RetryPolicy               *resource.RetryPolicy // how failed resource operations are retried, if not set on the resource.
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -85,6 +92,7 @@ type StepExecutorEvents interface {
 	OnResourceStepPre(step Step) (interface{}, error)
 	OnResourceStepPost(ctx interface{}, step Step, status resource.Status, err error) error
 	OnResourceOutputs(step Step) error
+	OnResourceStepRetry(step Step, attempt, maxAttempts int, delay time.Duration, err error)
 }
 
 // PolicyEvents is an interface that can be used to hook policy events.
@@ -57,6 +57,17 @@ func createTargetMap(targets []resource.URN) map[resource.URN]bool {
 	return targetMap
 }
 
+// createExcludeMap returns the set of resources to leave unchanged, or nil if no resources were excluded. If the
+// dependents of the excluded resources are to be left unchanged as well, the set includes the resources in the base
+// snapshot that depend on them. Dependents that are new to the deployment are excluded as they are registered.
+func createExcludeMap(prev *Snapshot, opts Options) map[resource.URN]bool {
+	excludes := createTargetMap(opts.Excludes)
+	if excludes == nil || !opts.ExcludeDependents || prev == nil {
+		return excludes
+	}
+	return transitiveDependents(prev.Resources, excludes)
+}
+
 // checkTargets validates that all the targets passed in refer to existing resources.  Diagnostics
 // are generated for any target that cannot be found.  The target must either have existed in the stack
 // prior to running the operation, or it must be the urn for a resource that was created.
@@ -168,8 +179,9 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 		return ex.importResources(callerCtx, opts, preview)
 	}
 
-	// Before doing anything else, optionally refresh each resource in the base checkpoint.
-	if opts.Refresh {
+	// Before doing anything else, optionally refresh each resource in the base checkpoint. A refresh that runs the
+	// program instead refreshes each resource as the program registers it.
+	if opts.Refresh && !opts.RefreshProgram {
 		if res := ex.refresh(callerCtx, opts, preview); res != nil {
 			return nil, res
 		}
@@ -196,6 +208,11 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 	if res := ex.checkTargets(opts.DestroyTargets, OpDelete); res != nil {
 		return nil, res
 	}
+	if opts.RefreshProgram {
+		if res := ex.checkTargets(opts.RefreshTargets, OpRefresh); res != nil {
+			return nil, res
+		}
+	}
 
 	if (updateTargetsOpt != nil || replaceTargetsOpt != nil) && destroyTargetsOpt != nil {
 		contract.Failf("Should not be possible to have both .DestroyTargets and .UpdateTargets or .ReplaceTargets")
@@ -208,11 +225,15 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 	}
 
 	// Set up a step generator for this deployment.
-	ex.stepGen = newStepGenerator(ex.deployment, opts, updateTargetsOpt, replaceTargetsOpt)
+	ex.stepGen = newStepGenerator(ex.deployment, opts, updateTargetsOpt, replaceTargetsOpt,
+		createExcludeMap(ex.deployment.prev, opts))
 
-	// Retire any pending deletes that are currently present in this deployment.
-	if res := ex.retirePendingDeletes(callerCtx, opts, preview); res != nil {
-		return nil, res
+	// Retire any pending deletes that are currently present in this deployment. A refresh never deletes anything, so
+	// a refresh that runs the program refreshes these resources instead.
+	if !opts.RefreshProgram {
+		if res := ex.retirePendingDeletes(callerCtx, opts, preview); res != nil {
+			return nil, res
+		}
 	}
 
 	// Derive a cancellable context for this deployment. We will only cancel this context if some piece of the
@@ -220,7 +241,7 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 	ctx, cancel := context.WithCancel(callerCtx)
 
 	// Set up a step generator and executor for this deployment.
-	ex.stepExec = newStepExecutor(ctx, cancel, ex.deployment, opts, preview, false)
+	ex.stepExec = newStepExecutor(ctx, cancel, ex.deployment, opts, preview, opts.ContinueOnError)
 
 	// We iterate the source in its own goroutine because iteration is blocking and we want the main loop to be able to
 	// respond to cancellation requests promptly.
@@ -264,14 +285,24 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 					if !event.Result.IsBail() {
 						ex.reportError("", event.Result.Error())
 					}
-					cancel()
+					if opts.ContinueOnError {
+						// Let the steps that are already executing run to completion.
+						ex.stepExec.SignalCompletion()
+					} else {
+						cancel()
+					}
 
 					// We reported any errors above.  So we can just bail now.
 					return false, result.Bail()
 				}
 
 				if event.Event == nil {
-					res := ex.performDeletes(ctx, updateTargetsOpt, destroyTargetsOpt)
+					var res result.Result
+					if opts.RefreshProgram {
+						res = ex.performRefreshes(ctx)
+					} else {
+						res = ex.performDeletes(ctx, updateTargetsOpt, destroyTargetsOpt)
+					}
 					if res != nil {
 						if resErr := res.Error(); resErr != nil {
 							logging.V(4).Infof("deploymentExecutor.Execute(...): error performing deletes: %v", resErr)
@@ -287,6 +318,9 @@ func (ex *deploymentExecutor) Execute(callerCtx context.Context, opts Options, p
 						logging.V(4).Infof("deploymentExecutor.Execute(...): error handling event: %v", resErr)
 						ex.reportError(ex.deployment.generateEventURN(event.Event), resErr)
 					}
+					if opts.ContinueOnError && ex.stepExec.failEvent(event.Event) {
+						continue
+					}
 					cancel()
 					return false, result.Bail()
 				}
@@ -405,21 +439,19 @@ func (ex *deploymentExecutor) performDeletes(
 		return res
 	}
 
-	deletes := ex.stepGen.ScheduleDeletes(deleteSteps)
+	// ScheduleDeletes tells us which deletes must complete before each delete may begin. Each delete
+	// starts as soon as the deletes of the resources that depend on it have completed, so a slow
+	// delete only holds up the resources that it depends on.
+	schedule := ex.stepGen.ScheduleDeletes(deleteSteps)
 
-	// ScheduleDeletes gives us a list of lists of steps. Each list of steps can safely be executed
-	// in parallel, but each list must execute completes before the next list can safely begin
-	// executing.
-	//
-	// This is not "true" delete parallelism, since there may be resources that could safely begin
-	// deleting but we won't until the previous set of deletes fully completes. This approximation
-	// is conservative, but correct.
-	for _, antichain := range deletes {
-		logging.V(4).Infof("deploymentExecutor.Execute(...): beginning delete antichain")
-		tok := ex.stepExec.ExecuteParallel(antichain)
-		tok.Wait(ctx)
-		logging.V(4).Infof("deploymentExecutor.Execute(...): antichain complete")
+	var skip func(Step) bool
+	if ex.stepExec.continueOnError {
+		skip = ex.skipDeleteOfDependency
 	}
+	logging.V(4).Infof("deploymentExecutor.Execute(...): beginning deletes")
+	tok := ex.stepExec.ExecuteDeletes(deleteSteps, schedule, skip)
+	tok.Wait(ctx)
+	logging.V(4).Infof("deploymentExecutor.Execute(...): deletes complete")
 
 	// After executing targeted deletes, we may now have resources that depend on the resource that
 	// were deleted.  Go through and clean things up accordingly for them.
@@ -435,6 +467,76 @@ func (ex *deploymentExecutor) performDeletes(
 	return nil
 }
 
+// performRefreshes finishes a refresh that runs the program by refreshing the resources that the program didn't
+// register, and then removing the resources that the refresh found to have been deleted from the base snapshot.
+func (ex *deploymentExecutor) performRefreshes(ctx context.Context) result.Result {
+	defer func() {
+		// We're done here - signal completion so that the step executor knows to terminate.
+		ex.stepExec.SignalCompletion()
+	}()
+
+	prev := ex.deployment.prev
+	if prev == nil || len(prev.Resources) == 0 {
+		return nil
+	}
+
+	logging.V(7).Infof("performRefreshes(...): beginning")
+
+	steps, resourceToStep := ex.stepGen.GenerateRefreshes()
+	tok := ex.stepExec.ExecuteParallel(steps)
+	tok.Wait(ctx)
+
+	ex.rebuildBaseState(resourceToStep, true /*refresh*/)
+	return nil
+}
+
+// failedDependency returns the URN of a resource whose step has failed that the resource with the given goal depends
+// on, if there is one. When continuing after errors, such resources are skipped rather than operated on without the
+// resources that they depend on.
+func (ex *deploymentExecutor) failedDependency(goal *resource.Goal) resource.URN {
+	failed := ex.stepExec.Failed()
+	if len(failed) == 0 {
+		return ""
+	}
+	failedSet := make(map[resource.URN]bool, len(failed))
+	for _, urn := range failed {
+		failedSet[urn] = true
+	}
+
+	deps := append([]resource.URN{goal.Parent, goal.DeletedWith}, goal.Dependencies...)
+	for _, propertyDeps := range goal.PropertyDependencies {
+		deps = append(deps, propertyDeps...)
+	}
+	if goal.Provider != "" {
+		if ref, err := providers.ParseReference(goal.Provider); err == nil {
+			deps = append(deps, ref.URN())
+		}
+	}
+	for _, dep := range deps {
+		if dep != "" && failedSet[dep] {
+			return dep
+		}
+	}
+	return ""
+}
+
+// skipDeleteOfDependency returns true if the given delete step must be skipped because its resource failed, or a
+// resource whose step has failed depends on the resource it would delete. When continuing after errors, the resources
+// that failed to be deleted, updated or replaced are left in place, and so must everything that they depend on.
+func (ex *deploymentExecutor) skipDeleteOfDependency(step Step) bool {
+	for _, urn := range ex.stepExec.Failed() {
+		if urn == step.URN() {
+			return true
+		}
+		if old, has := ex.deployment.olds[urn]; has && ex.deployment.depGraph.TransitiveDependenciesOf(old)[step.Old()] {
+			ex.deployment.Diag().Warningf(diag.RawMessage(step.URN(),
+				"skipping deletion, as resources that failed to update depend on this resource"))
+			return true
+		}
+	}
+	return false
+}
+
 // handleSingleEvent handles a single source event. For all incoming events, it produces a chain that needs
 // to be executed and schedules the chain for execution.
 func (ex *deploymentExecutor) handleSingleEvent(event SourceEvent) result.Result {
@@ -445,6 +547,15 @@ func (ex *deploymentExecutor) handleSingleEvent(event SourceEvent) result.Result
 	switch e := event.(type) {
 	case RegisterResourceEvent:
 		logging.V(4).Infof("deploymentExecutor.handleSingleEvent(...): received RegisterResourceEvent")
+		if ex.stepExec.continueOnError {
+			if failed := ex.failedDependency(e.Goal()); failed != "" {
+				urn := ex.deployment.generateEventURN(e)
+				ex.deployment.Diag().Warningf(diag.RawMessage(urn,
+					fmt.Sprintf("skipping, as this resource depends on %v, which failed", failed)))
+				ex.stepExec.failEvent(e)
+				return nil
+			}
+		}
 		steps, res = ex.stepGen.GenerateSteps(e)
 	case ReadResourceEvent:
 		logging.V(4).Infof("deploymentExecutor.handleSingleEvent(...): received ReadResourceEvent")
@@ -480,16 +591,13 @@ func (ex *deploymentExecutor) retirePendingDeletes(callerCtx context.Context, op
 	ctx, cancel := context.WithCancel(callerCtx)
 
 	stepExec := newStepExecutor(ctx, cancel, ex.deployment, opts, preview, false)
-	antichains := ex.stepGen.ScheduleDeletes(steps)
+	schedule := ex.stepGen.ScheduleDeletes(steps)
 	// Submit the deletes for execution and wait for them all to retire.
-	for _, antichain := range antichains {
-		for _, step := range antichain {
-			ex.deployment.Ctx().StatusDiag.Infof(diag.RawMessage(step.URN(), "completing deletion from previous update"))
-		}
-
-		tok := stepExec.ExecuteParallel(antichain)
-		tok.Wait(ctx)
+	for _, step := range steps {
+		ex.deployment.Ctx().StatusDiag.Infof(diag.RawMessage(step.URN(), "completing deletion from previous update"))
 	}
+	tok := stepExec.ExecuteDeletes(steps, schedule, nil)
+	tok.Wait(ctx)
 
 	stepExec.SignalCompletion()
 	stepExec.WaitForCompletion()
@@ -562,12 +670,13 @@ func (ex *deploymentExecutor) refresh(callerCtx context.Context, opts Options, p
 
 	// If the user did not provide any --target's, create a refresh step for each resource in the
 	// old snapshot.  If they did provider --target's then only create refresh steps for those
-	// specific targets.
+	// specific targets. Resources that were excluded with --exclude are never refreshed.
 	steps := []Step{}
 	resourceToStep := map[*resource.State]Step{}
 	targetMapOpt := createTargetMap(opts.RefreshTargets)
+	excludeMapOpt := createExcludeMap(prev, opts)
 	for _, res := range prev.Resources {
-		if targetMapOpt == nil || targetMapOpt[res.URN] {
+		if (targetMapOpt == nil || targetMapOpt[res.URN]) && !excludeMapOpt[res.URN] {
 			step := NewRefreshStep(ex.deployment, res, nil)
 			steps = append(steps, step)
 			resourceToStep[res] = step
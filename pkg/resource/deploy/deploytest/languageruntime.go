@@ -16,6 +16,7 @@ package deploytest
 
 import (
 	"context"
+	"errors"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
@@ -69,3 +70,17 @@ func (p *languageRuntime) GetPluginInfo() (workspace.PluginInfo, error) {
 func (p *languageRuntime) InstallDependencies(directory string) error {
 	return nil
 }
+
+func (p *languageRuntime) GenerateProgram(program map[string]string) (map[string][]byte, error) {
+	return nil, errors.New("GenerateProgram is not supported")
+}
+
+func (p *languageRuntime) GenerateProject(directory string, project *workspace.Project,
+	program map[string]string) error {
+	return errors.New("GenerateProject is not supported")
+}
+
+func (p *languageRuntime) GetProgramDependencies(
+	info plugin.ProgInfo, transitiveDependencies bool) ([]plugin.DependencyInfo, error) {
+	return nil, nil
+}
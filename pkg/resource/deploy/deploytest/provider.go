@@ -38,6 +38,10 @@ type Provider struct {
 
 	GetSchemaF func(version int) ([]byte, error)
 
+	GetMappingF func(key string) ([]byte, string, error)
+
+	ListF func(t tokens.Type, filter map[string]string) ([]plugin.ListedResource, error)
+
 	CheckConfigF func(urn resource.URN, olds,
 		news resource.PropertyMap, allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error)
 	DiffConfigF func(urn resource.URN, olds, news resource.PropertyMap,
@@ -97,6 +101,20 @@ func (prov *Provider) GetSchema(version int) ([]byte, error) {
 	return prov.GetSchemaF(version)
 }
 
+func (prov *Provider) GetMapping(key string) ([]byte, string, error) {
+	if prov.GetMappingF == nil {
+		return nil, "", nil
+	}
+	return prov.GetMappingF(key)
+}
+
+func (prov *Provider) List(t tokens.Type, filter map[string]string) ([]plugin.ListedResource, error) {
+	if prov.ListF == nil {
+		return nil, nil
+	}
+	return prov.ListF(t, filter)
+}
+
 func (prov *Provider) CheckConfig(urn resource.URN, olds,
 	news resource.PropertyMap, allowUnknowns bool) (resource.PropertyMap, []plugin.CheckFailure, error) {
 	if prov.CheckConfigF == nil {
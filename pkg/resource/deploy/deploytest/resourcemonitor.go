@@ -100,10 +100,13 @@ type ResourceOptions struct {
 	ImportID                resource.ID
 	CustomTimeouts          *resource.CustomTimeouts
 	RetainOnDelete          bool
+	DeletedWith             resource.URN
 	SupportsPartialValues   *bool
 	Remote                  bool
 	Providers               map[string]string
 	AdditionalSecretOutputs []resource.PropertyKey
+	SourcePosition          *pulumirpc.SourcePosition
+	RetryPolicy             *resource.RetryPolicy
 
 	DisableSecrets            bool
 	DisableResourceReferences bool
@@ -160,6 +163,16 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		timeouts.Delete = prepareTestTimeout(opts.CustomTimeouts.Delete)
 	}
 
+	var retryPolicy *pulumirpc.RegisterResourceRequest_RetryPolicy
+	if opts.RetryPolicy != nil {
+		retryPolicy = &pulumirpc.RegisterResourceRequest_RetryPolicy{
+			Attempts:       int32(opts.RetryPolicy.Attempts),
+			Backoff:        opts.RetryPolicy.Backoff,
+			ErrorPatterns:  opts.RetryPolicy.ErrorPatterns,
+			RetryIfUnknown: opts.RetryPolicy.RetryIfUnknown,
+		}
+	}
+
 	deleteBeforeReplace := false
 	if opts.DeleteBeforeReplace != nil {
 		deleteBeforeReplace = *opts.DeleteBeforeReplace
@@ -197,7 +210,10 @@ func (rm *ResourceMonitor) RegisterResource(t tokens.Type, name string, custom b
 		Providers:                  opts.Providers,
 		PluginDownloadURL:          opts.PluginDownloadURL,
 		RetainOnDelete:             opts.RetainOnDelete,
+		DeletedWith:                string(opts.DeletedWith),
 		AdditionalSecretOutputs:    additionalSecretOutputs,
+		SourcePosition:             opts.SourcePosition,
+		RetryPolicy:                retryPolicy,
 	}
 
 	// submit request
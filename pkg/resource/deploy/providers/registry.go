@@ -471,6 +471,18 @@ func (r *Registry) GetPluginInfo() (workspace.PluginInfo, error) {
 	return workspace.PluginInfo{}, errors.New("the provider registry does not report plugin info")
 }
 
+func (r *Registry) GetMapping(key string) ([]byte, string, error) {
+	contract.Fail()
+
+	return nil, "", errors.New("the provider registry has no mappings")
+}
+
+func (r *Registry) List(t tokens.Type, filter map[string]string) ([]plugin.ListedResource, error) {
+	contract.Fail()
+
+	return nil, errors.New("the provider registry does not list resources")
+}
+
 func (r *Registry) SignalCancellation() error {
 	// At the moment there isn't anything reasonable we can do here. In the future, it might be nice to plumb
 	// cancellation through the plugin loader and cancel any outstanding load requests here.
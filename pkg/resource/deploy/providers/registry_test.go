@@ -96,6 +96,12 @@ type testProvider struct {
 func (prov *testProvider) SignalCancellation() error {
 	return nil
 }
+func (prov *testProvider) GetMapping(key string) ([]byte, string, error) {
+	return nil, "", nil
+}
+func (prov *testProvider) List(t tokens.Type, filter map[string]string) ([]plugin.ListedResource, error) {
+	return nil, nil
+}
 func (prov *testProvider) Close() error {
 	return nil
 }
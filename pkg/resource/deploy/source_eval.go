@@ -19,6 +19,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -328,7 +330,7 @@ func (d *defaultProviders) newRegisterDefaultProviderEvent(
 		goal: resource.NewGoal(
 			providers.MakeProviderType(req.Package()),
 			req.Name(), true, inputs, "", false, nil, "", nil, nil, nil,
-			nil, nil, nil, "", nil, nil, false),
+			nil, nil, nil, "", nil, nil, false, "", "", nil),
 		done: done,
 	}
 	return event, done, nil
@@ -384,6 +386,10 @@ func (d *defaultProviders) handleRequest(req providers.ProviderRequest) (provide
 		return providers.Reference{}, context.Canceled
 	}
 
+	if result.Failed {
+		return providers.Reference{}, fmt.Errorf("default provider for package %s failed", req)
+	}
+
 	logging.V(5).Infof("registered default provider for package %s: %s", req, result.State.URN)
 
 	id := result.State.ID
@@ -481,6 +487,8 @@ type resmon struct {
 	done                      chan error                         // a channel that resolves when the server completes.
 	disableResourceReferences bool                               // true if resource references are disabled.
 	disableOutputValues       bool                               // true if output values are disabled.
+	pwd                       string                             // the program's working directory.
+	transformations           []projectTransformation            // the project's resource transformations.
 }
 
 var _ SourceResourceMonitor = (*resmon)(nil)
@@ -503,6 +511,12 @@ func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *reg
 		cancel:              cancel,
 	}
 
+	// Compile the project's resource transformations.
+	transformations, err := newProjectTransformations(src.runinfo.Proj)
+	if err != nil {
+		return nil, err
+	}
+
 	// New up an engine RPC server.
 	resmon := &resmon{
 		diagostics:                src.plugctx.Diag,
@@ -514,6 +528,8 @@ func newResourceMonitor(src *evalSource, provs ProviderSource, regChan chan *reg
 		cancel:                    cancel,
 		disableResourceReferences: opts.DisableResourceReferences,
 		disableOutputValues:       opts.DisableOutputValues,
+		pwd:                       src.runinfo.Pwd,
+		transformations:           transformations,
 	}
 
 	// Fire up a gRPC server and start listening for incomings.
@@ -908,6 +924,9 @@ func (rm *resmon) ReadResource(ctx context.Context,
 	}
 
 	contract.Assert(result != nil)
+	if result.Failed {
+		return nil, rpcerror.New(codes.Unknown, fmt.Sprintf("reading resource %s failed", result.State.URN))
+	}
 	marshaled, err := plugin.MarshalProperties(result.State.Outputs, plugin.MarshalOptions{
 		Label:         label,
 		KeepUnknowns:  true,
@@ -940,6 +959,7 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	id := resource.ID(req.GetImportId())
 	customTimeouts := req.GetCustomTimeouts()
 	retainOnDelete := req.GetRetainOnDelete()
+	deletedWith := resource.URN(req.GetDeletedWith())
 
 	// Custom resources must have a three-part type so that we can 1) identify if they are providers and 2) retrieve the
 	// provider responsible for managing a particular resource (based on the type's Package).
@@ -1029,6 +1049,14 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 			}
 		}
 	}
+	if !providers.IsProviderType(t) {
+		// Apply the project's transformations before the goal is built, so that they affect every resource the
+		// program registers, including the children of components.
+		props, err = applyProjectTransformations(rm.transformations, t, name, custom, props)
+		if err != nil {
+			return nil, rpcerror.New(codes.InvalidArgument, fmt.Sprintf("%s: %v", label, err))
+		}
+	}
 
 	propertyDependencies := make(map[resource.PropertyKey][]resource.URN)
 	if len(req.GetPropertyDependencies()) == 0 {
@@ -1078,17 +1106,32 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		}
 	}
 
+	var retryPolicy *resource.RetryPolicy
+	if rp := req.GetRetryPolicy(); rp != nil {
+		retryPolicy = &resource.RetryPolicy{
+			Attempts:       int(rp.GetAttempts()),
+			Backoff:        rp.GetBackoff(),
+			ErrorPatterns:  rp.GetErrorPatterns(),
+			RetryIfUnknown: rp.GetRetryIfUnknown(),
+		}
+		if err := retryPolicy.Validate(); err != nil {
+			return nil, rpcerror.New(codes.InvalidArgument, fmt.Sprintf("%s: %v", label, err))
+		}
+	}
+
 	var deleteBeforeReplace *bool
 	if deleteBeforeReplaceValue || req.GetDeleteBeforeReplaceDefined() {
 		deleteBeforeReplace = &deleteBeforeReplaceValue
 	}
 
+	sourcePosition := formatSourcePosition(rm.pwd, req.GetSourcePosition())
+
 	logging.V(5).Infof(
 		"ResourceMonitor.RegisterResource received: t=%v, name=%v, custom=%v, #props=%v, parent=%v, protect=%v, "+
 			"provider=%v, deps=%v, deleteBeforeReplace=%v, ignoreChanges=%v, aliases=%v, customTimeouts=%v, "+
-			"providers=%v, replaceOnChanges=%v, retainOnDelete=%v",
+			"providers=%v, replaceOnChanges=%v, retainOnDelete=%v, deletedWith=%v, sourcePosition=%v, retryPolicy=%v",
 		t, name, custom, len(props), parent, protect, providerRef, dependencies, deleteBeforeReplace, ignoreChanges,
-		aliases, timeouts, providerRefs, replaceOnChanges, retainOnDelete)
+		aliases, timeouts, providerRefs, replaceOnChanges, retainOnDelete, deletedWith, sourcePosition, retryPolicy)
 
 	// If this is a remote component, fetch its provider and issue the construct call. Otherwise, register the resource.
 	var result *RegisterResult
@@ -1127,7 +1170,8 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		step := &registerResourceEvent{
 			goal: resource.NewGoal(t, name, custom, props, parent, protect, dependencies,
 				providerRef.String(), nil, propertyDependencies, deleteBeforeReplace, ignoreChanges,
-				additionalSecretOutputs, aliases, id, &timeouts, replaceOnChanges, retainOnDelete),
+				additionalSecretOutputs, aliases, id, &timeouts, replaceOnChanges, retainOnDelete, deletedWith,
+				sourcePosition, retryPolicy),
 			done: make(chan *RegisterResult),
 		}
 
@@ -1147,6 +1191,10 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		}
 	}
 
+	if result.Failed {
+		return nil, rpcerror.New(codes.Unknown, fmt.Sprintf("resource %s failed", result.State.URN))
+	}
+
 	// Filter out partially-known values if the requestor does not support them.
 	outputs := result.State.Outputs
 
@@ -1181,6 +1229,8 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	// • additionalSecretOutputs
 	// • replaceOnChanges
 	// • retainOnDelete
+	// • deletedWith
+	// • retryPolicy
 	// Revisit these semantics in Pulumi v4.0
 	// See this issue for more: https://github.com/pulumi/pulumi/issues/9704
 	if !custom {
@@ -1205,6 +1255,12 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 		rm.checkComponentOption(result.State.URN, "retainOnDelete", func() bool {
 			return retainOnDelete
 		})
+		rm.checkComponentOption(result.State.URN, "deletedWith", func() bool {
+			return deletedWith != ""
+		})
+		rm.checkComponentOption(result.State.URN, "retryPolicy", func() bool {
+			return retryPolicy != nil
+		})
 	}
 
 	logging.V(5).Infof(
@@ -1375,6 +1431,33 @@ func generateTimeoutInSeconds(timeout string) (float64, error) {
 	return duration.Seconds(), nil
 }
 
+// formatSourcePosition formats a source position sent by a language SDK as "file:line[:column]". File paths that
+// lie within the program's working directory are made relative to it. Positions that do not refer to a local file or
+// that lack a line number are ignored.
+func formatSourcePosition(pwd string, pos *pulumirpc.SourcePosition) string {
+	if pos == nil || pos.GetLine() <= 0 {
+		return ""
+	}
+
+	u, err := url.Parse(pos.GetUri())
+	if err != nil || u.Scheme != "file" || u.Path == "" {
+		return ""
+	}
+
+	path := filepath.FromSlash(u.Path)
+	if pwd != "" {
+		rel, err := filepath.Rel(pwd, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			path = rel
+		}
+	}
+
+	if pos.GetColumn() > 0 {
+		return fmt.Sprintf("%s:%d:%d", path, pos.GetLine(), pos.GetColumn())
+	}
+	return fmt.Sprintf("%s:%d", path, pos.GetLine())
+}
+
 func decorateResourceSpans(span opentracing.Span, method string, req, resp interface{}, grpcError error) {
 	if req == nil {
 		return
@@ -17,6 +17,7 @@ package deploy
 import (
 	"context"
 	"encoding/json"
+	"path/filepath"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -33,6 +34,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 )
 
 type testRegEvent struct {
@@ -71,7 +73,7 @@ func fixedProgram(steps []RegisterResourceEvent) deploytest.ProgramFunc {
 			s.Done(&RegisterResult{
 				State: resource.NewState(g.Type, urn, g.Custom, false, id, g.Properties, outs, g.Parent, g.Protect,
 					false, g.Dependencies, nil, g.Provider, g.PropertyDependencies, false, nil, nil, nil,
-					"", 0, false),
+					"", 0, false, ""),
 			})
 		}
 		return nil
@@ -181,16 +183,16 @@ func TestRegisterNoDefaultProviders(t *testing.T) {
 		// Register a component resource.
 		&testRegEvent{
 			goal: resource.NewGoal(componentURN.Type(), componentURN.Name(), false, resource.PropertyMap{}, "", false,
-				nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false),
+				nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false, "", "", nil),
 		},
 		// Register a couple resources using provider A.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:index:typA", "res1", true, resource.PropertyMap{}, componentURN, false, nil,
-				providerARef.String(), []string{}, nil, nil, nil, nil, nil, "", nil, nil, false),
+				providerARef.String(), []string{}, nil, nil, nil, nil, nil, "", nil, nil, false, "", "", nil),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:index:typA", "res2", true, resource.PropertyMap{}, componentURN, false, nil,
-				providerARef.String(), []string{}, nil, nil, nil, nil, nil, "", nil, nil, false),
+				providerARef.String(), []string{}, nil, nil, nil, nil, nil, "", nil, nil, false, "", "", nil),
 		},
 		// Register two more providers.
 		newProviderEvent("pkgA", "providerB", nil, ""),
@@ -198,11 +200,11 @@ func TestRegisterNoDefaultProviders(t *testing.T) {
 		// Register a few resources that use the new providers.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:index:typB", "res3", true, resource.PropertyMap{}, "", false, nil,
-				providerBRef.String(), []string{}, nil, nil, nil, nil, nil, "", nil, nil, false),
+				providerBRef.String(), []string{}, nil, nil, nil, nil, nil, "", nil, nil, false, "", "", nil),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:index:typC", "res4", true, resource.PropertyMap{}, "", false, nil,
-				providerCRef.String(), []string{}, nil, nil, nil, nil, nil, "", nil, nil, false),
+				providerCRef.String(), []string{}, nil, nil, nil, nil, nil, "", nil, nil, false, "", "", nil),
 		},
 	}
 
@@ -236,7 +238,7 @@ func TestRegisterNoDefaultProviders(t *testing.T) {
 		reg.Done(&RegisterResult{
 			State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
 				goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider, goal.PropertyDependencies,
-				false, nil, nil, nil, "", 0, false),
+				false, nil, nil, nil, "", 0, false, ""),
 		})
 
 		processed++
@@ -267,25 +269,25 @@ func TestRegisterDefaultProviders(t *testing.T) {
 		// Register a component resource.
 		&testRegEvent{
 			goal: resource.NewGoal(componentURN.Type(), componentURN.Name(), false, resource.PropertyMap{}, "", false,
-				nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false),
+				nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false, "", "", nil),
 		},
 		// Register a couple resources from package A.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:m:typA", "res1", true, resource.PropertyMap{},
-				componentURN, false, nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false),
+				componentURN, false, nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false, "", "", nil),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgA:m:typA", "res2", true, resource.PropertyMap{},
-				componentURN, false, nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false),
+				componentURN, false, nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false, "", "", nil),
 		},
 		// Register a few resources from other packages.
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:m:typB", "res3", true, resource.PropertyMap{}, "", false,
-				nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false),
+				nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false, "", "", nil),
 		},
 		&testRegEvent{
 			goal: resource.NewGoal("pkgB:m:typC", "res4", true, resource.PropertyMap{}, "", false,
-				nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false),
+				nil, "", []string{}, nil, nil, nil, nil, nil, "", nil, nil, false, "", "", nil),
 		},
 	}
 
@@ -330,7 +332,7 @@ func TestRegisterDefaultProviders(t *testing.T) {
 		reg.Done(&RegisterResult{
 			State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
 				goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider, goal.PropertyDependencies,
-				false, nil, nil, nil, "", 0, false),
+				false, nil, nil, nil, "", 0, false, ""),
 		})
 
 		processed++
@@ -422,7 +424,7 @@ func TestReadInvokeNoDefaultProviders(t *testing.T) {
 		read.Done(&ReadResult{
 			State: resource.NewState(read.Type(), urn, true, false, read.ID(), read.Properties(),
 				resource.PropertyMap{}, read.Parent(), false, false, read.Dependencies(), nil, read.Provider(), nil,
-				false, nil, nil, nil, "", 0, false),
+				false, nil, nil, nil, "", 0, false, ""),
 		})
 		reads++
 	}
@@ -509,7 +511,7 @@ func TestReadInvokeDefaultProviders(t *testing.T) {
 			e.Done(&RegisterResult{
 				State: resource.NewState(goal.Type, urn, goal.Custom, false, id, goal.Properties, resource.PropertyMap{},
 					goal.Parent, goal.Protect, false, goal.Dependencies, nil, goal.Provider, goal.PropertyDependencies,
-					false, nil, nil, nil, "", 0, false),
+					false, nil, nil, nil, "", 0, false, ""),
 			})
 			registers++
 
@@ -518,7 +520,7 @@ func TestReadInvokeDefaultProviders(t *testing.T) {
 			e.Done(&ReadResult{
 				State: resource.NewState(e.Type(), urn, true, false, e.ID(), e.Properties(),
 					resource.PropertyMap{}, e.Parent(), false, false, e.Dependencies(), nil, e.Provider(), nil, false,
-					nil, nil, nil, "", 0, false),
+					nil, nil, nil, "", 0, false, ""),
 			})
 			reads++
 		}
@@ -673,7 +675,7 @@ func TestDisableDefaultProviders(t *testing.T) {
 					event.Done(&ReadResult{
 						State: resource.NewState(event.Type(), urn, true, false, event.ID(), event.Properties(),
 							resource.PropertyMap{}, event.Parent(), false, false, event.Dependencies(), nil, event.Provider(), nil,
-							false, nil, nil, nil, "", 0, false),
+							false, nil, nil, nil, "", 0, false, ""),
 					})
 					reads++
 				case RegisterResourceEvent:
@@ -681,7 +683,7 @@ func TestDisableDefaultProviders(t *testing.T) {
 					event.Done(&RegisterResult{
 						State: resource.NewState(event.Goal().Type, urn, true, false, event.Goal().ID, event.Goal().Properties,
 							resource.PropertyMap{}, event.Goal().Parent, false, false, event.Goal().Dependencies, nil,
-							event.Goal().Provider, nil, false, nil, nil, nil, "", 0, false),
+							event.Goal().Provider, nil, false, nil, nil, nil, "", 0, false, ""),
 					})
 					registers++
 				default:
@@ -697,6 +699,34 @@ func TestDisableDefaultProviders(t *testing.T) {
 	}
 }
 
+func TestFormatSourcePosition(t *testing.T) {
+	t.Parallel()
+
+	pwd := filepath.FromSlash("/home/user/project")
+	cases := []struct {
+		name     string
+		pos      *pulumirpc.SourcePosition
+		expected string
+	}{
+		{"nil", nil, ""},
+		{"no line", &pulumirpc.SourcePosition{Uri: "file:///home/user/project/index.ts"}, ""},
+		{"not a file", &pulumirpc.SourcePosition{Uri: "https://example.com/index.ts", Line: 1}, ""},
+		{"relative", &pulumirpc.SourcePosition{Uri: "file:///home/user/project/index.ts", Line: 12},
+			filepath.FromSlash("index.ts:12")},
+		{"nested", &pulumirpc.SourcePosition{Uri: "file:///home/user/project/src/main.go", Line: 3, Column: 7},
+			filepath.FromSlash("src/main.go:3:7")},
+		{"outside", &pulumirpc.SourcePosition{Uri: "file:///home/user/lib/index.ts", Line: 4},
+			filepath.FromSlash("/home/user/lib/index.ts:4")},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.expected, formatSourcePosition(pwd, c.pos))
+		})
+	}
+}
+
 // TODO[pulumi/pulumi#2753]: We should re-enable these tests (and fix them up as needed) once we have a solution
 // for #2753.
 // func TestReadResourceAndInvokeVersion(t *testing.T) {
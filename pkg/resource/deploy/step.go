@@ -53,6 +53,15 @@ type Step interface {
 	Res() *resource.State    // the latest state for the resource that is known (worst case, old).
 	Logical() bool           // true if this step represents a logical operation in the program.
 	Deployment() *Deployment // the owning deployment.
+	SourcePosition() string  // the source position of the registration that produced this step, if any.
+}
+
+// registrationSourcePosition returns the source position recorded in the goal of the given registration, if any.
+func registrationSourcePosition(reg RegisterResourceEvent) string {
+	if reg == nil || reg.Goal() == nil {
+		return ""
+	}
+	return reg.Goal().SourcePosition
 }
 
 // SameStep is a mutating step that does nothing.
@@ -119,6 +128,7 @@ func (s *SameStep) Old() *resource.State    { return s.old }
 func (s *SameStep) New() *resource.State    { return s.new }
 func (s *SameStep) Res() *resource.State    { return s.new }
 func (s *SameStep) Logical() bool           { return true }
+func (s *SameStep) SourcePosition() string  { return registrationSourcePosition(s.reg) }
 
 func (s *SameStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// Retain the ID and outputs
@@ -219,6 +229,7 @@ func (s *CreateStep) Keys() []resource.PropertyKey                 { return s.ke
 func (s *CreateStep) Diffs() []resource.PropertyKey                { return s.diffs }
 func (s *CreateStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 func (s *CreateStep) Logical() bool                                { return !s.replacing }
+func (s *CreateStep) SourcePosition() string                       { return registrationSourcePosition(s.reg) }
 
 func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	var resourceError error
@@ -268,25 +279,34 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 // DeleteStep is a mutating step that deletes an existing resource. If `old` is marked "External",
 // DeleteStep is a no-op.
 type DeleteStep struct {
-	deployment *Deployment     // the current deployment.
-	old        *resource.State // the state of the existing resource.
-	replacing  bool            // true if part of a replacement.
+	deployment     *Deployment           // the current deployment.
+	old            *resource.State       // the state of the existing resource.
+	replacing      bool                  // true if part of a replacement.
+	otherDeletions map[resource.URN]bool // the other resources being deleted in this deployment, if known.
 }
 
 var _ Step = (*DeleteStep)(nil)
 
-func NewDeleteStep(deployment *Deployment, old *resource.State) Step {
+// NewDeleteStep returns a step that deletes an existing resource. otherDeletions is the set of the other resources
+// that are deleted in the same deployment: if it includes the resource that old was declared to be deleted with, the
+// provider is not asked to delete old, as it will be deleted along with that resource.
+func NewDeleteStep(deployment *Deployment, otherDeletions map[resource.URN]bool, old *resource.State) Step {
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
 	contract.Assert(old.ID != "" || !old.Custom)
 	contract.Assert(!old.Custom || old.Provider != "" || providers.IsProviderType(old.Type))
 	return &DeleteStep{
-		deployment: deployment,
-		old:        old,
+		deployment:     deployment,
+		old:            old,
+		otherDeletions: otherDeletions,
 	}
 }
 
-func NewDeleteReplacementStep(deployment *Deployment, old *resource.State, pendingReplace bool) Step {
+// NewDeleteReplacementStep returns a step that deletes the old instance of a resource that is being replaced.
+// otherDeletions has the same meaning as it does for NewDeleteStep.
+func NewDeleteReplacementStep(deployment *Deployment, otherDeletions map[resource.URN]bool, old *resource.State,
+	pendingReplace bool) Step {
+
 	contract.Assert(old != nil)
 	contract.Assert(old.URN != "")
 	contract.Assert(old.ID != "" || !old.Custom)
@@ -307,9 +327,10 @@ func NewDeleteReplacementStep(deployment *Deployment, old *resource.State, pendi
 	contract.Assert(pendingReplace != old.Delete)
 	old.PendingReplacement = pendingReplace
 	return &DeleteStep{
-		deployment: deployment,
-		old:        old,
-		replacing:  true,
+		deployment:     deployment,
+		old:            old,
+		replacing:      true,
+		otherDeletions: otherDeletions,
 	}
 }
 
@@ -334,6 +355,7 @@ func (s *DeleteStep) Old() *resource.State    { return s.old }
 func (s *DeleteStep) New() *resource.State    { return nil }
 func (s *DeleteStep) Res() *resource.State    { return s.old }
 func (s *DeleteStep) Logical() bool           { return !s.replacing }
+func (s *DeleteStep) SourcePosition() string  { return "" }
 
 func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// Refuse to delete protected resources (unless we're replacing them in
@@ -352,6 +374,9 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 		// Deleting an External resource is a no-op, since Pulumi does not own the lifecycle.
 	} else if s.old.RetainOnDelete {
 		// Deleting a "drop on delete" is a no-op as the user has explicitly asked us to not delete the resource.
+	} else if isDeletedWith(s.old.DeletedWith, s.otherDeletions) {
+		// No need to delete this resource since it will be deleted along with the resource it was declared to be
+		// deleted with.
 	} else if s.old.Custom {
 		// Not preview and not external and not Drop and is custom, do the actual delete
 
@@ -369,6 +394,14 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	return resource.StatusOK, func() {}, nil
 }
 
+// isDeletedWith returns true if the resource with the given URN is among the resources being deleted.
+func isDeletedWith(with resource.URN, otherDeletions map[resource.URN]bool) bool {
+	if with == "" {
+		return false
+	}
+	return otherDeletions[with]
+}
+
 type RemovePendingReplaceStep struct {
 	deployment *Deployment     // the current deployment.
 	old        *resource.State // the state of the existing resource.
@@ -394,6 +427,7 @@ func (s *RemovePendingReplaceStep) Old() *resource.State    { return s.old }
 func (s *RemovePendingReplaceStep) New() *resource.State    { return nil }
 func (s *RemovePendingReplaceStep) Res() *resource.State    { return s.old }
 func (s *RemovePendingReplaceStep) Logical() bool           { return false }
+func (s *RemovePendingReplaceStep) SourcePosition() string  { return "" }
 
 func (s *RemovePendingReplaceStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	return resource.StatusOK, nil, nil
@@ -450,6 +484,7 @@ func (s *UpdateStep) Old() *resource.State                         { return s.ol
 func (s *UpdateStep) New() *resource.State                         { return s.new }
 func (s *UpdateStep) Res() *resource.State                         { return s.new }
 func (s *UpdateStep) Logical() bool                                { return true }
+func (s *UpdateStep) SourcePosition() string                       { return registrationSourcePosition(s.reg) }
 func (s *UpdateStep) Diffs() []resource.PropertyKey                { return s.diffs }
 func (s *UpdateStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 
@@ -543,6 +578,7 @@ func (s *ReplaceStep) Keys() []resource.PropertyKey                 { return s.k
 func (s *ReplaceStep) Diffs() []resource.PropertyKey                { return s.diffs }
 func (s *ReplaceStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 func (s *ReplaceStep) Logical() bool                                { return true }
+func (s *ReplaceStep) SourcePosition() string                       { return "" }
 
 func (s *ReplaceStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	// If this is a pending delete, we should have marked the old resource for deletion in the CreateReplacement step.
@@ -626,6 +662,7 @@ func (s *ReadStep) Old() *resource.State    { return s.old }
 func (s *ReadStep) New() *resource.State    { return s.new }
 func (s *ReadStep) Res() *resource.State    { return s.new }
 func (s *ReadStep) Logical() bool           { return !s.replacing }
+func (s *ReadStep) SourcePosition() string  { return "" }
 
 func (s *ReadStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error) {
 	urn := s.new.URN
@@ -685,10 +722,12 @@ func (s *ReadStep) Apply(preview bool) (resource.Status, StepCompleteFunc, error
 // resource by reading its current state from its provider plugin. These steps are not issued by the step generator;
 // instead, they are issued by the deployment executor as the optional first step in deployment execution.
 type RefreshStep struct {
-	deployment *Deployment     // the deployment that produced this refresh
-	old        *resource.State // the old resource state, if one exists for this urn
-	new        *resource.State // the new resource state, to be used to query the provider
-	done       chan<- bool     // the channel to use to signal completion, if any
+	deployment *Deployment           // the deployment that produced this refresh
+	reg        RegisterResourceEvent // the registration intent to convey the refreshed state back to, if any.
+	old        *resource.State       // the old resource state, if one exists for this urn
+	new        *resource.State       // the new resource state, to be used to query the provider
+	goal       *resource.State       // the state registered by the program, if any.
+	done       chan<- bool           // the channel to use to signal completion, if any
 }
 
 // NewRefreshStep creates a new Refresh step.
@@ -704,15 +743,51 @@ func NewRefreshStep(deployment *Deployment, old *resource.State, done chan<- boo
 	}
 }
 
+// NewProgramRefreshStep creates a new Refresh step for a resource registered by the program during a refresh that runs
+// the program. The resource is read using the provider that the program registered it with, and its refreshed state
+// takes the rest of its options, such as its parent and dependencies, from goal. Unlike other refreshes, the refreshed
+// state is persisted like that of any other step, and is conveyed back to the program.
+func NewProgramRefreshStep(deployment *Deployment, reg RegisterResourceEvent, old, goal *resource.State) Step {
+	contract.Assert(reg != nil)
+	contract.Assert(old != nil)
+	contract.Assert(old.Custom)
+	contract.Assert(!providers.IsProviderType(old.Type))
+	contract.Assert(goal != nil)
+	contract.Assert(goal.URN != "")
+	contract.Assert(goal.Provider != "")
+
+	return &RefreshStep{
+		deployment: deployment,
+		reg:        reg,
+		old:        old,
+		new:        old,
+		goal:       goal,
+	}
+}
+
 func (s *RefreshStep) Op() display.StepOp      { return OpRefresh }
 func (s *RefreshStep) Deployment() *Deployment { return s.deployment }
 func (s *RefreshStep) Type() tokens.Type       { return s.old.Type }
-func (s *RefreshStep) Provider() string        { return s.old.Provider }
 func (s *RefreshStep) URN() resource.URN       { return s.old.URN }
 func (s *RefreshStep) Old() *resource.State    { return s.old }
 func (s *RefreshStep) New() *resource.State    { return s.new }
 func (s *RefreshStep) Res() *resource.State    { return s.old }
-func (s *RefreshStep) Logical() bool           { return false }
+func (s *RefreshStep) Logical() bool           { return s.reg != nil }
+func (s *RefreshStep) SourcePosition() string  { return registrationSourcePosition(s.reg) }
+
+func (s *RefreshStep) Provider() string {
+	if s.goal != nil {
+		return s.goal.Provider
+	}
+	return s.old.Provider
+}
+
+// Persisted returns true if the refreshed state of the resource is persisted by this step itself, as it is for the
+// resources registered by the program during a refresh that runs the program. Other refreshes instead rewrite the base
+// snapshot in memory once they have all completed.
+func (s *RefreshStep) Persisted() bool {
+	return s.reg != nil
+}
 
 // ResultOp returns the operation that corresponds to the change to this resource after reading its current state, if
 // any.
@@ -730,6 +805,15 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 	var complete func()
 	if s.done != nil {
 		complete = func() { close(s.done) }
+	} else if s.reg != nil {
+		complete = func() {
+			// If the resource no longer exists, the program sees its last known state.
+			state := s.new
+			if state == nil {
+				state = s.old
+			}
+			s.reg.Done(&RegisterResult{State: state})
+		}
 	}
 
 	resourceID := s.old.ID
@@ -781,10 +865,21 @@ func (s *RefreshStep) Apply(preview bool) (resource.Status, StepCompleteFunc, er
 			resourceID = refreshed.ID
 		}
 
-		s.new = resource.NewState(s.old.Type, s.old.URN, s.old.Custom, s.old.Delete, resourceID, inputs, outputs,
-			s.old.Parent, s.old.Protect, s.old.External, s.old.Dependencies, initErrors, s.old.Provider,
-			s.old.PropertyDependencies, s.old.PendingReplacement, s.old.AdditionalSecretOutputs, s.old.Aliases,
-			&s.old.CustomTimeouts, s.old.ImportID, s.old.SequenceNumber, s.old.RetainOnDelete)
+		if s.goal != nil {
+			// The refreshed state takes its options from the program, but keeps the inputs of the resource as they
+			// were last deployed: the program's inputs are only applied by an update.
+			s.goal.ID = resourceID
+			s.goal.Inputs = inputs
+			s.goal.Outputs = outputs
+			s.goal.InitErrors = initErrors
+			s.goal.ImportID = s.old.ImportID
+			s.new = s.goal
+		} else {
+			s.new = resource.NewState(s.old.Type, s.old.URN, s.old.Custom, s.old.Delete, resourceID, inputs, outputs,
+				s.old.Parent, s.old.Protect, s.old.External, s.old.Dependencies, initErrors, s.old.Provider,
+				s.old.PropertyDependencies, s.old.PendingReplacement, s.old.AdditionalSecretOutputs, s.old.Aliases,
+				&s.old.CustomTimeouts, s.old.ImportID, s.old.SequenceNumber, s.old.RetainOnDelete, s.old.DeletedWith)
+		}
 	} else {
 		s.new = nil
 	}
@@ -875,6 +970,7 @@ func (s *ImportStep) Old() *resource.State                         { return s.ol
 func (s *ImportStep) New() *resource.State                         { return s.new }
 func (s *ImportStep) Res() *resource.State                         { return s.new }
 func (s *ImportStep) Logical() bool                                { return !s.replacing }
+func (s *ImportStep) SourcePosition() string                       { return registrationSourcePosition(s.reg) }
 func (s *ImportStep) Diffs() []resource.PropertyKey                { return s.diffs }
 func (s *ImportStep) DetailedDiff() map[string]plugin.PropertyDiff { return s.detailedDiff }
 
@@ -927,7 +1023,7 @@ func (s *ImportStep) Apply(preview bool) (resource.Status, StepCompleteFunc, err
 	s.old = resource.NewState(s.new.Type, s.new.URN, s.new.Custom, false, s.new.ID, read.Inputs, read.Outputs,
 		s.new.Parent, s.new.Protect, false, s.new.Dependencies, s.new.InitErrors, s.new.Provider,
 		s.new.PropertyDependencies, false, nil, nil, &s.new.CustomTimeouts, s.new.ImportID,
-		s.new.SequenceNumber, s.new.RetainOnDelete)
+		s.new.SequenceNumber, s.new.RetainOnDelete, s.new.DeletedWith)
 
 	// If this step came from an import deployment, we need to fetch any required inputs from the state.
 	if s.planned {
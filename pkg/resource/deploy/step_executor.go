@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
@@ -58,6 +59,9 @@ type chain = []Step
 // An Antichain is a set of Steps that can be executed in parallel.
 type antichain = []Step
 
+// A deleteSchedule maps each delete step to the delete steps that must complete before it may begin.
+type deleteSchedule = map[Step][]Step
+
 // A CompletionToken is a token returned by the step executor that is completed when the chain has completed execution.
 // Callers can use it to optionally wait synchronously on the completion of a chain.
 type completionToken struct {
@@ -74,8 +78,9 @@ func (c completionToken) Wait(ctx context.Context) {
 
 // incomingChain represents a request to the step executor to execute a chain.
 type incomingChain struct {
-	Chain          chain     // The chain we intend to execute
-	CompletionChan chan bool // A completion channel to be closed when the chain has completed execution
+	Chain          chain               // The chain we intend to execute
+	CompletionChan chan bool           // A completion channel to be closed when the chain has completed execution
+	Held           []*concurrencyLimit // The concurrency limits already acquired for the first step of the chain
 }
 
 // stepExecutor is the component of the engine responsible for taking steps and executing
@@ -94,10 +99,17 @@ type stepExecutor struct {
 
 	workers        sync.WaitGroup     // WaitGroup tracking the worker goroutines that are owned by this step executor.
 	incomingChains chan incomingChain // Incoming chains that we are to execute
+	readyChains    chan incomingChain // Parked chains that have acquired the concurrency limits for their next step
+	pendingChains  sync.WaitGroup     // WaitGroup tracking the chains that have been submitted but not yet completed.
 
 	ctx      context.Context    // cancellation context for the current deployment.
 	cancel   context.CancelFunc // CancelFunc that cancels the above context.
 	sawError atomic.Value       // atomic boolean indicating whether or not the step excecutor saw that there was an error.
+
+	failedLock sync.Mutex     // lock protecting failed.
+	failed     []resource.URN // the resources whose steps failed, if continueOnError is true.
+
+	limits *concurrencyLimits // the concurrency limits for each provider package and resource type.
 }
 
 //
@@ -113,9 +125,11 @@ func (se *stepExecutor) ExecuteSerial(chain chain) completionToken {
 	// If one is pending, we should exit early - we will shortly be tearing down the engine and exiting.
 
 	completion := make(chan bool)
+	se.pendingChains.Add(1)
 	select {
 	case se.incomingChains <- incomingChain{Chain: chain, CompletionChan: completion}:
 	case <-se.ctx.Done():
+		se.pendingChains.Done()
 		close(completion)
 	}
 
@@ -147,6 +161,48 @@ func (se *stepExecutor) ExecuteParallel(antichain antichain) completionToken {
 	return completionToken{channel: done}
 }
 
+// ExecuteDeletes submits a list of delete steps for execution according to the given schedule. Each step is submitted
+// as soon as the steps that it waits on have completed, so the deletes of unrelated resources proceed independently of
+// one another. If skip is non-nil, it is called once a step is ready to execute, and the step is skipped if it returns
+// true. Steps that wait on a skipped step may still execute.
+func (se *stepExecutor) ExecuteDeletes(steps []Step, schedule deleteSchedule, skip func(Step) bool) completionToken {
+	completions := make(map[Step]chan bool, len(steps))
+	for _, step := range steps {
+		completions[step] = make(chan bool)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(steps))
+	for _, step := range steps {
+		step := step
+		go func() {
+			defer wg.Done()
+			defer close(completions[step])
+
+			for _, waitOn := range schedule[step] {
+				select {
+				case <-completions[waitOn]:
+				case <-se.ctx.Done():
+					return
+				}
+			}
+
+			if skip != nil && skip(step) {
+				return
+			}
+			se.ExecuteSerial(chain{step}).Wait(se.ctx)
+		}()
+	}
+
+	done := make(chan bool)
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	return completionToken{channel: done}
+}
+
 // ExecuteRegisterResourceOutputs services a RegisterResourceOutputsEvent synchronously on the calling goroutine.
 func (se *stepExecutor) ExecuteRegisterResourceOutputs(e RegisterResourceOutputsEvent) result.Result {
 	// Look up the final state in the pending registration list.
@@ -219,9 +275,13 @@ func (se *stepExecutor) Errored() bool {
 }
 
 // SignalCompletion signals to the stepExecutor that there are no more chains left to execute. All worker
-// threads will terminate as soon as they retire all of the work they are currently executing.
+// threads will terminate as soon as they retire all of the work they are currently executing, including any
+// chains that are parked waiting for concurrency limits.
 func (se *stepExecutor) SignalCompletion() {
-	close(se.incomingChains)
+	go func() {
+		se.pendingChains.Wait()
+		close(se.incomingChains)
+	}()
 }
 
 // WaitForCompletion blocks the calling goroutine until the step executor completes execution of all in-flight
@@ -239,19 +299,38 @@ func (se *stepExecutor) WaitForCompletion() {
 //
 
 // executeChain executes a chain, one step at a time. If any step in the chain fails to execute, or if the
-// context is canceled, the chain stops execution.
-func (se *stepExecutor) executeChain(workerID int, chain chain) {
-	for _, step := range chain {
+// context is canceled, the chain stops execution. If the concurrency limits for a step are exhausted, the rest of
+// the chain is parked until they are available so that it does not occupy the worker in the meantime. Returns
+// true if the chain has completed and false if it was parked.
+func (se *stepExecutor) executeChain(workerID int, request incomingChain) bool {
+	chain := request.Chain
+	for i, step := range chain {
 		select {
 		case <-se.ctx.Done():
 			se.log(workerID, "step %v on %v canceled", step.Op(), step.URN())
-			return
+			if i == 0 {
+				releaseLimits(request.Held)
+			}
+			return true
 		default:
 		}
 
-		if err := se.executeStep(workerID, step); err != nil {
+		limits := request.Held
+		if i > 0 || limits == nil {
+			limits = se.limits.forStep(step)
+			if !tryAcquireLimits(limits) {
+				se.log(workerID, "step %v on %v is waiting for concurrency limits", step.Op(), step.URN())
+				se.park(incomingChain{Chain: chain[i:], CompletionChan: request.CompletionChan}, limits)
+				return false
+			}
+		}
+
+		if err := se.executeStep(workerID, step, limits); err != nil {
 			se.log(workerID, "step %v on %v failed, signalling cancellation", step.Op(), step.URN())
 			se.cancelDueToError()
+			if se.continueOnError {
+				se.recordFailure(step, chain[i:])
+			}
 			if err != errStepApplyFailed {
 				// Step application errors are recorded by the OnResourceStepPost callback. This is confusing,
 				// but it means that at this level we shouldn't be logging any errors that came from there.
@@ -261,11 +340,104 @@ func (se *stepExecutor) executeChain(workerID int, chain chain) {
 				diagMsg := diag.RawMessage(step.URN(), err.Error())
 				se.deployment.Diag().Errorf(diagMsg)
 			}
+			return true
+		}
+	}
+	return true
+}
+
+// park waits outside of the worker pool until the concurrency limits for the first step of a chain are available, and
+// then hands the chain back to the workers along with the limits that it now holds.
+func (se *stepExecutor) park(request incomingChain, limits []*concurrencyLimit) {
+	se.workers.Add(1)
+	go func() {
+		defer se.workers.Done()
+
+		if err := acquireLimits(se.ctx, limits); err != nil {
+			se.retireChain(request)
 			return
 		}
+		request.Held = limits
+		select {
+		case se.readyChains <- request:
+		case <-se.ctx.Done():
+			releaseLimits(limits)
+			se.retireChain(request)
+		}
+	}()
+}
+
+// retireChain signals that a chain has completed, either because all of its steps have executed or because it
+// stopped early.
+func (se *stepExecutor) retireChain(request incomingChain) {
+	close(request.CompletionChan)
+	se.pendingChains.Done()
+}
+
+// recordFailure records the failure of a step when continuing after errors, and fails the registrations of the
+// failed step and the remaining steps in its chain, which won't be executed. Failing the registrations tells the
+// program that the resources failed, so that it doesn't wait on them forever, and so that the resources that depend
+// on them are never registered.
+func (se *stepExecutor) recordFailure(failed Step, remaining chain) {
+	se.noteFailure(failed.URN())
+	for _, step := range remaining {
+		switch s := step.(type) {
+		case *SameStep:
+			failRegistration(s.reg, s.new)
+		case *CreateStep:
+			failRegistration(s.reg, s.new)
+		case *UpdateStep:
+			failRegistration(s.reg, s.new)
+		case *ImportStep:
+			failRegistration(s.reg, s.new)
+		case *RefreshStep:
+			failRegistration(s.reg, s.new)
+		case *ReadStep:
+			if s.event != nil {
+				s.event.Done(&ReadResult{State: s.new, Failed: true})
+			}
+		}
+	}
+}
+
+// failEvent fails the registration of a resource whose steps couldn't be generated when continuing after errors,
+// returning false if the event is not a resource registration.
+func (se *stepExecutor) failEvent(event SourceEvent) bool {
+	switch e := event.(type) {
+	case RegisterResourceEvent:
+		urn := se.deployment.generateEventURN(e)
+		se.noteFailure(urn)
+		e.Done(&RegisterResult{State: &resource.State{URN: urn}, Failed: true})
+		return true
+	case ReadResourceEvent:
+		urn := se.deployment.generateEventURN(e)
+		se.noteFailure(urn)
+		e.Done(&ReadResult{State: &resource.State{URN: urn}, Failed: true})
+		return true
+	}
+	return false
+}
+
+func (se *stepExecutor) noteFailure(urn resource.URN) {
+	se.sawError.Store(true)
+	se.failedLock.Lock()
+	defer se.failedLock.Unlock()
+	se.failed = append(se.failed, urn)
+}
+
+func failRegistration(reg RegisterResourceEvent, new *resource.State) {
+	if reg != nil {
+		reg.Done(&RegisterResult{State: new, Failed: true})
 	}
 }
 
+// Failed returns the URNs of the resources whose steps failed, if the step executor continues after errors.
+func (se *stepExecutor) Failed() []resource.URN {
+	se.failedLock.Lock()
+	defer se.failedLock.Unlock()
+	return append([]resource.URN(nil), se.failed...)
+}
+
 func (se *stepExecutor) cancelDueToError() {
 	se.sawError.Store(true)
 	if !se.continueOnError {
@@ -284,22 +456,22 @@ func (se *stepExecutor) cancelDueToError() {
 // verbatim to the post-step event.
 //
 
-// executeStep executes a single step, returning true if the step execution was successful and
-// false if it was not.
-func (se *stepExecutor) executeStep(workerID int, step Step) error {
+// executeStep executes a single step that holds the given concurrency limits, returning true if the step execution
+// was successful and false if it was not. The limits are released once the step has been applied.
+func (se *stepExecutor) executeStep(workerID int, step Step, limits []*concurrencyLimit) error {
 	var payload interface{}
 	events := se.opts.Events
 	if events != nil {
 		var err error
 		payload, err = events.OnResourceStepPre(step)
 		if err != nil {
+			releaseLimits(limits)
 			se.log(workerID, "step %v on %v failed pre-resource step: %v", step.Op(), step.URN(), err)
 			return fmt.Errorf("pre-step event returned an error: %w", err)
 		}
 	}
 
-	se.log(workerID, "applying step %v on %v (preview %v)", step.Op(), step.URN(), se.preview)
-	status, stepComplete, err := step.Apply(se.preview)
+	status, stepComplete, err := se.applyStep(workerID, step, limits)
 
 	if err == nil {
 		// If we have a state object, and this is a create or update, remember it, as we may need to update it later.
@@ -368,8 +540,9 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	}
 
 	// Calling stepComplete allows steps that depend on this step to continue. OnResourceStepPost saved the results
-	// of the step in the snapshot, so we are ready to go.
-	if stepComplete != nil {
+	// of the step in the snapshot, so we are ready to go. If we are continuing after errors, the steps that depend on
+	// a failed step must not continue, so its registration is failed by executeChain instead.
+	if stepComplete != nil && (err == nil || !se.continueOnError) {
 		se.log(workerID, "step %v on %v retired", step.Op(), step.URN())
 		stepComplete()
 	}
@@ -382,6 +555,89 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	return nil
 }
 
+// applyStep applies or previews a step while holding the given concurrency limits for its provider and type, and
+// releases them once it is done. If the provider throttles the step, its limits are lowered and the step is retried
+// after backing off. If the step fails with an error that its retry policy matches, it is retried as the policy
+// directs. Steps keep holding their limits while they back off, so lowered limits hold back steps that have yet to
+// start rather than steps that are already in flight.
+func (se *stepExecutor) applyStep(workerID int, step Step,
+	limits []*concurrencyLimit) (resource.Status, StepCompleteFunc, error) {
+	defer releaseLimits(limits)
+
+	policy, err := se.retryPolicy(step)
+	if err != nil {
+		return resource.StatusOK, nil, err
+	}
+	throttles, retries := 0, 0
+	for {
+		se.log(workerID, "applying step %v on %v (preview %v)", step.Op(), step.URN(), se.preview)
+		status, stepComplete, err := step.Apply(se.preview)
+
+		// Never retry steps that may have partially succeeded. Steps whose outcome is unknown, as is the case for most
+		// errors that providers return over gRPC, are only retried if they were throttled or the policy allows it.
+		var delay time.Duration
+		switch {
+		case err == nil:
+			for _, l := range limits {
+				l.succeeded()
+			}
+			return status, stepComplete, err
+		case status == resource.StatusPartialFailure:
+			return status, stepComplete, err
+		case isThrottlingError(err) && throttles < maxThrottledRetries:
+			delay = throttledBackoff(throttles)
+			throttles++
+			for _, l := range limits {
+				l.throttled()
+			}
+			if len(limits) > 0 {
+				se.deployment.Diag().Infof(diag.RawMessage(step.URN(), fmt.Sprintf(
+					"%v was throttled by its provider; retrying in %v with at most %d concurrent %v operations",
+					step.Op(), delay, limits[len(limits)-1].current(), packageOf(step.Type()))))
+			}
+			se.log(workerID, "step %v on %v was throttled, retrying in %v: %v", step.Op(), step.URN(), delay, err)
+		case policy.ShouldRetry(retries, status, err):
+			delay = policy.Delay(retries)
+			retries++
+			if events := se.opts.Events; events != nil {
+				events.OnResourceStepRetry(step, retries, policy.Attempts, delay, err)
+			}
+			se.log(workerID, "step %v on %v failed, retrying (%d/%d) in %v: %v",
+				step.Op(), step.URN(), retries, policy.Attempts, delay, err)
+		default:
+			return status, stepComplete, err
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-se.ctx.Done():
+			return status, stepComplete, err
+		}
+	}
+}
+
+// retryPolicy returns the policy that governs retries of the given step, if any. Only steps that create, update, or
+// delete a resource are retried, and never during previews. A policy set on the resource takes precedence over the
+// policy for the whole deployment.
+func (se *stepExecutor) retryPolicy(step Step) (*resource.CompiledRetryPolicy, error) {
+	if se.preview {
+		return nil, nil
+	}
+	switch step.Op() {
+	case OpCreate, OpCreateReplacement, OpUpdate, OpDelete, OpDeleteReplaced:
+	default:
+		return nil, nil
+	}
+	policy := se.opts.RetryPolicy
+	if res := step.Res(); res != nil && res.RetryPolicy != nil {
+		policy = res.RetryPolicy
+	}
+	if policy == nil {
+		return nil, nil
+	}
+	return policy.Compile()
+}
+
 // log is a simple logging helper for the step executor.
 func (se *stepExecutor) log(workerID int, msg string, args ...interface{}) {
 	if logging.V(stepExecutorLogLevel) {
@@ -395,7 +651,9 @@ func (se *stepExecutor) log(workerID int, msg string, args ...interface{}) {
 // executing steps. By default, as we ease into the waters of parallelism, there is at most one worker
 // active.
 //
-// Workers continuously pull from se.incomingChains, executing chains as they are provided to the executor.
+// Workers continuously pull from se.incomingChains, executing chains as they are provided to the executor. Chains
+// whose next step must wait for concurrency limits are parked outside of the workers and come back to them through
+// se.readyChains once the limits are held.
 // There are two reasons why a worker would exit:
 //
 //  1. A worker exits if se.ctx is canceled. There are two ways that se.ctx gets canceled: first, if there is
@@ -413,6 +671,29 @@ func (se *stepExecutor) worker(workerID int, launchAsync bool) {
 	defer se.workers.Done()
 
 	oneshotWorkerID := 0
+	execute := func(request incomingChain) {
+		if !launchAsync {
+			if se.executeChain(workerID, request) {
+				se.retireChain(request)
+			}
+			return
+		}
+
+		// If we're launching asynchronously, make up a new worker ID for this new oneshot worker and record its
+		// launch with our worker wait group.
+		se.workers.Add(1)
+		newWorkerID := oneshotWorkerID
+		go func() {
+			defer se.workers.Done()
+			se.log(newWorkerID, "launching oneshot worker")
+			if se.executeChain(newWorkerID, request) {
+				se.retireChain(request)
+			}
+		}()
+
+		oneshotWorkerID++
+	}
+
 	for {
 		se.log(workerID, "worker waiting for incoming chains")
 		select {
@@ -423,24 +704,10 @@ func (se *stepExecutor) worker(workerID int, launchAsync bool) {
 			}
 
 			se.log(workerID, "worker received chain for execution")
-			if !launchAsync {
-				se.executeChain(workerID, request.Chain)
-				close(request.CompletionChan)
-				continue
-			}
-
-			// If we're launching asynchronously, make up a new worker ID for this new oneshot worker and record its
-			// launch with our worker wait group.
-			se.workers.Add(1)
-			newWorkerID := oneshotWorkerID
-			go func() {
-				defer se.workers.Done()
-				se.log(newWorkerID, "launching oneshot worker")
-				se.executeChain(newWorkerID, request.Chain)
-				close(request.CompletionChan)
-			}()
-
-			oneshotWorkerID++
+			execute(request)
+		case request := <-se.readyChains:
+			se.log(workerID, "worker received parked chain for execution")
+			execute(request)
 		case <-se.ctx.Done():
 			se.log(workerID, "worker exiting due to cancellation")
 			return
@@ -456,8 +723,10 @@ func newStepExecutor(ctx context.Context, cancel context.CancelFunc, deployment
 		preview:         preview,
 		continueOnError: continueOnError,
 		incomingChains:  make(chan incomingChain),
+		readyChains:     make(chan incomingChain),
 		ctx:             ctx,
 		cancel:          cancel,
+		limits:          newConcurrencyLimits(opts),
 	}
 
 	exec.sawError.Store(false)
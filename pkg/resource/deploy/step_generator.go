@@ -39,6 +39,7 @@ type stepGenerator struct {
 
 	updateTargetsOpt  map[resource.URN]bool // the set of resources to update; resources not in this set will be same'd
 	replaceTargetsOpt map[resource.URN]bool // the set of resoures to replace
+	excludesOpt       map[resource.URN]bool // the set of resources to leave unchanged
 
 	// signals that one or more errors have been reported to the user, and the deployment should terminate
 	// in error. This primarily allows `preview` to aggregate many policy violation events and
@@ -53,6 +54,9 @@ type stepGenerator struct {
 	creates  map[resource.URN]bool // set of URNs created in this deployment
 	sames    map[resource.URN]bool // set of URNs that were not changed in this deployment
 
+	// the refresh steps for the resources registered by the program during a refresh that runs the program.
+	refreshes map[resource.URN]*RefreshStep
+
 	// set of URNs that would have been created, but were filtered out because the user didn't
 	// specify them with --target
 	skippedCreates map[resource.URN]bool
@@ -76,6 +80,9 @@ func (sg *stepGenerator) isTargetedUpdate() bool {
 // `--target-dependents`. `targetDependentsForUpdate` should probably be called if this function
 // returns true.
 func (sg *stepGenerator) isTargetedForUpdate(res *resource.State) bool {
+	if sg.isExcluded(res) {
+		return false
+	}
 	if sg.updateTargetsOpt == nil || sg.updateTargetsOpt[res.URN] {
 		return true
 	} else if !sg.opts.TargetDependents {
@@ -101,6 +108,33 @@ func (sg *stepGenerator) isTargetedForUpdate(res *resource.State) bool {
 	return false
 }
 
+// isExcluded returns if `res` is excluded from the deployment. The function accommodates `--exclude-dependents`: a
+// resource that depends on an excluded resource, or is its child, is itself recorded as excluded so that its own
+// dependents are excluded in turn.
+func (sg *stepGenerator) isExcluded(res *resource.State) bool {
+	if sg.excludesOpt == nil {
+		return false
+	} else if sg.excludesOpt[res.URN] {
+		return true
+	} else if !sg.opts.ExcludeDependents {
+		return false
+	}
+
+	excluded := res.Parent != "" && sg.excludesOpt[res.Parent]
+	if res.Provider != "" {
+		ref, err := providers.ParseReference(res.Provider)
+		contract.AssertNoError(err)
+		excluded = excluded || sg.excludesOpt[ref.URN()]
+	}
+	for _, dep := range res.Dependencies {
+		excluded = excluded || sg.excludesOpt[dep]
+	}
+	if excluded {
+		sg.excludesOpt[res.URN] = true
+	}
+	return excluded
+}
+
 func (sg *stepGenerator) isTargetedReplace(urn resource.URN) bool {
 	return sg.replaceTargetsOpt != nil && sg.replaceTargetsOpt[urn]
 }
@@ -197,6 +231,7 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, res
 		"",    /* importID */
 		1,     /* sequenceNumber */
 		false, /* retainOnDelete */
+		"",    /* deletedWith */
 	)
 	old, hasOld := sg.deployment.Olds()[urn]
 
@@ -310,6 +345,9 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, res
 				// in an error state so that we eventually will error out of the entire
 				// application run.
 				d := diag.GetResourceWillBeCreatedButWasNotSpecifiedInTargetList(step.URN())
+				if sg.excludesOpt[urn] {
+					d = diag.GetResourceWillBeCreatedButWasExcluded(step.URN())
+				}
 
 				sg.deployment.Diag().Errorf(d, step.URN(), urn)
 				sg.sawError = true
@@ -397,7 +435,8 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 	// get serialized into the checkpoint file.
 	new := resource.NewState(goal.Type, urn, goal.Custom, false, "", inputs, nil, goal.Parent, goal.Protect, false,
 		goal.Dependencies, goal.InitErrors, goal.Provider, goal.PropertyDependencies, false,
-		goal.AdditionalSecretOutputs, alias, &goal.CustomTimeouts, "", 1, goal.RetainOnDelete)
+		goal.AdditionalSecretOutputs, alias, &goal.CustomTimeouts, "", 1, goal.RetainOnDelete, goal.DeletedWith)
+	new.RetryPolicy = goal.RetryPolicy
 	if hasOld {
 		new.SequenceNumber = old.SequenceNumber
 	}
@@ -415,6 +454,12 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 		return nil, res
 	}
 
+	// A refresh that runs the program only reads the resources that the program registers. Providers are still
+	// configured as they would be by an update, so that the resources that use them can be read.
+	if sg.opts.RefreshProgram && !providers.IsProviderType(goal.Type) {
+		return sg.generateRefreshSteps(event, old, new), nil
+	}
+
 	// We only allow unknown property values to be exposed to the provider if we are performing an update preview.
 	allowUnknowns := sg.deployment.preview
 
@@ -670,9 +715,17 @@ func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, res
 	if hasOld {
 		contract.Assert(old != nil)
 
-		// If the user requested only specific resources to update, and this resource was not in
-		// that set, then do nothing but create a SameStep for it.
-		if !isTargeted {
+		// A refresh that runs the program never replaces providers: it just configures them with their new inputs.
+		if sg.opts.RefreshProgram {
+			contract.Assert(providers.IsProviderType(goal.Type))
+			if !old.Inputs.DeepEquals(new.Inputs) {
+				logging.V(7).Infof("Planner decided to reconfigure provider '%v' for refresh", urn)
+				sg.updates[urn] = true
+				return []Step{NewUpdateStep(sg.deployment, event, old, new, nil, nil, nil, nil)}, nil
+			}
+		} else if !isTargeted {
+			// If the user requested only specific resources to update, and this resource was not in
+			// that set, then do nothing but create a SameStep for it.
 			logging.V(7).Infof(
 				"Planner decided not to update '%v' due to not being in target group (same) (inputs=%v)", urn, new.Inputs)
 		} else {
@@ -857,7 +910,12 @@ func (sg *stepGenerator) generateStepsFromDiff(
 				//
 				// To do this, we'll utilize the dependency information contained in the snapshot if it is
 				// trustworthy, which is interpreted by the DependencyGraph type.
+				//
+				// The delete steps share the set of resources that this replacement deletes, so that a dependent
+				// that is deleted with the resource being replaced (or with another dependent) can skip its own
+				// deletion.
 				var steps []Step
+				deleting := map[resource.URN]bool{old.URN: true}
 				if sg.opts.TrustDependencies {
 					toReplace, res := sg.calculateDependentReplacements(old)
 					if res != nil {
@@ -889,15 +947,16 @@ func (sg *stepGenerator) generateStepsFromDiff(
 						logging.V(7).Infof("Planner decided to delete '%v' due to dependence on condemned resource '%v'",
 							dependentResource.URN, urn)
 
-						steps = append(steps, NewDeleteReplacementStep(sg.deployment, dependentResource, true))
+						steps = append(steps, NewDeleteReplacementStep(sg.deployment, deleting, dependentResource, true))
 						// Mark the condemned resource as deleted. We won't know until later in the deployment whether
 						// or not we're going to be replacing this resource.
 						sg.deletes[dependentResource.URN] = true
+						deleting[dependentResource.URN] = true
 					}
 				}
 
 				return append(steps,
-					NewDeleteReplacementStep(sg.deployment, old, true),
+					NewDeleteReplacementStep(sg.deployment, deleting, old, true),
 					NewReplaceStep(sg.deployment, old, new, diff.ReplaceKeys, diff.ChangedKeys, diff.DetailedDiff, false),
 					NewCreateReplacementStep(
 						sg.deployment, event, old, new, diff.ReplaceKeys, diff.ChangedKeys, diff.DetailedDiff, false),
@@ -938,7 +997,11 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 	// To compute the deletion list, we must walk the list of old resources *backwards*.  This is because the list is
 	// stored in dependency order, and earlier elements are possibly leaf nodes for later elements.  We must not delete
 	// dependencies prior to their dependent nodes.
+	//
+	// The delete steps share the set of resources that are actually being deleted, which is filled in once the steps
+	// have been filtered below, so that resources that are deleted with another resource can skip their own deletion.
 	var dels []Step
+	deleting := make(map[resource.URN]bool)
 	if prev := sg.deployment.prev; prev != nil {
 		for i := len(prev.Resources) - 1; i >= 0; i-- {
 			// If this resource is explicitly marked for deletion or wasn't seen at all, delete it.
@@ -973,7 +1036,7 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 
 				logging.V(7).Infof("Planner decided to delete '%v' due to replacement", res.URN)
 				sg.deletes[res.URN] = true
-				dels = append(dels, NewDeleteReplacementStep(sg.deployment, res, false))
+				dels = append(dels, NewDeleteReplacementStep(sg.deployment, deleting, res, false))
 			} else if _, aliased := sg.aliased[res.URN]; !sg.sames[res.URN] && !sg.updates[res.URN] && !sg.replaces[res.URN] &&
 				!sg.reads[res.URN] && !aliased {
 				// NOTE: we deliberately do not check sg.deletes here, as it is possible for us to issue multiple
@@ -981,7 +1044,7 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 				logging.V(7).Infof("Planner decided to delete '%v'", res.URN)
 				sg.deletes[res.URN] = true
 				if !res.PendingReplacement {
-					dels = append(dels, NewDeleteStep(sg.deployment, res))
+					dels = append(dels, NewDeleteStep(sg.deployment, deleting, res))
 				} else {
 					dels = append(dels, NewRemovePendingReplaceStep(sg.deployment, res))
 				}
@@ -1044,6 +1107,22 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 		dels = filtered
 	}
 
+	// If --exclude was provided, leave the excluded resources in place, along with the resources that they depend on,
+	// which can't be deleted while the excluded resources still refer to them.
+	if sg.excludesOpt != nil && len(dels) > 0 {
+		kept := sg.getExcludedDependencies()
+		filtered := []Step{}
+		for _, step := range dels {
+			if kept[step.URN()] {
+				logging.V(7).Infof("Planner decided not to delete '%v' due to it being excluded", step.URN())
+				continue
+			}
+			filtered = append(filtered, step)
+		}
+
+		dels = filtered
+	}
+
 	deletingUnspecifiedTarget := false
 	for _, step := range dels {
 		urn := step.URN()
@@ -1072,22 +1151,141 @@ func (sg *stepGenerator) GenerateDeletes(targetsOpt map[resource.URN]bool) ([]St
 		return nil, result.Bail()
 	}
 
+	for _, step := range dels {
+		if op := step.Op(); op == OpDelete || op == OpDeleteReplaced {
+			deleting[step.URN()] = true
+		}
+	}
+
 	return dels, nil
 }
 
+// generateRefreshSteps produces the step for a resource other than a provider that is registered by the program during
+// a refresh that runs the program. Resources that don't exist yet are not created, and custom resources that do are
+// read using the provider that the program registered them with. Either way, the state of the resource takes its
+// options from the program, but keeps its inputs as they were last deployed.
+func (sg *stepGenerator) generateRefreshSteps(event RegisterResourceEvent, old, new *resource.State) []Step {
+	urn := new.URN
+
+	// Drop any references to resources that the refresh found to have been deleted. These resources must have been
+	// registered (and so refreshed) before this one, since the program waits on the resources that it refers to.
+	new.Dependencies = sg.removeRefreshedDeletes(new.Dependencies)
+	for key, deps := range new.PropertyDependencies {
+		new.PropertyDependencies[key] = sg.removeRefreshedDeletes(deps)
+	}
+	if new.DeletedWith != "" && len(sg.removeRefreshedDeletes([]resource.URN{new.DeletedWith})) == 0 {
+		new.DeletedWith = ""
+	}
+
+	if old == nil || old.External {
+		logging.V(7).Infof("Planner decided not to create '%v' during refresh", urn)
+		sg.sames[urn] = true
+		sg.skippedCreates[urn] = true
+		return []Step{NewSkippedCreateStep(sg.deployment, event, new)}
+	}
+	new.Inputs = old.Inputs
+
+	refreshTargetsOpt := createTargetMap(sg.opts.RefreshTargets)
+	isTargeted := (refreshTargetsOpt == nil || refreshTargetsOpt[old.URN]) && !sg.excludesOpt[old.URN]
+	if !new.Custom || old.PendingReplacement || !isTargeted {
+		logging.V(7).Infof("Planner decided not to read '%v' during refresh (same)", urn)
+		new.PendingReplacement = old.PendingReplacement
+		sg.sames[urn] = true
+		return []Step{NewSameStep(sg.deployment, event, old, new)}
+	}
+
+	logging.V(7).Infof("Planner decided to read '%v' during refresh", urn)
+	step := NewProgramRefreshStep(sg.deployment, event, old, new).(*RefreshStep)
+	sg.refreshes[urn] = step
+	return []Step{step}
+}
+
+// removeRefreshedDeletes removes the URNs of resources that a refresh that runs the program found to have been
+// deleted from the given list.
+func (sg *stepGenerator) removeRefreshedDeletes(urns []resource.URN) []resource.URN {
+	var result []resource.URN
+	for _, urn := range urns {
+		if step, has := sg.refreshes[urn]; !has || step.New() != nil {
+			result = append(result, urn)
+		}
+	}
+	return result
+}
+
+// GenerateRefreshes produces refresh steps for the resources in the base snapshot that the program didn't register
+// during a refresh that runs the program. These are read using the providers recorded in the snapshot, as they are by
+// a refresh that doesn't run the program. The refresh steps of the resources that the program did register and that
+// were found to have been deleted are returned along with them, keyed by the resources that they refreshed.
+func (sg *stepGenerator) GenerateRefreshes() ([]Step, map[*resource.State]Step) {
+	var steps []Step
+	resourceToStep := make(map[*resource.State]Step)
+	if prev := sg.deployment.prev; prev != nil {
+		refreshTargetsOpt := createTargetMap(sg.opts.RefreshTargets)
+		for _, res := range prev.Resources {
+			if step, has := sg.refreshes[res.URN]; has && step.Old() == res {
+				if step.New() == nil {
+					resourceToStep[res] = step
+				}
+				continue
+			}
+
+			_, aliased := sg.aliased[res.URN]
+			registered := sg.sames[res.URN] || sg.updates[res.URN] || sg.replaces[res.URN] || sg.reads[res.URN] ||
+				aliased
+			if (registered && !res.Delete) || (refreshTargetsOpt != nil && !refreshTargetsOpt[res.URN]) ||
+				sg.excludesOpt[res.URN] {
+				continue
+			}
+
+			logging.V(7).Infof("Planner decided to refresh unregistered resource '%v'", res.URN)
+			step := NewRefreshStep(sg.deployment, res, nil)
+			steps = append(steps, step)
+			resourceToStep[res] = step
+		}
+	}
+	return steps, resourceToStep
+}
+
 // getTargetDependents returns the (transitive) set of dependents on the target resources.
 // This includes both implicit and explicit dependents in the DAG itself, as well as children.
 func (sg *stepGenerator) getTargetDependents(targetsOpt map[resource.URN]bool) map[resource.URN]bool {
+	return transitiveDependents(sg.deployment.prev.Resources, targetsOpt)
+}
+
+// getExcludedDependencies returns the set of excluded resources along with the (transitive) set of resources that
+// they depend on in the base snapshot, none of which may be deleted.
+func (sg *stepGenerator) getExcludedDependencies() map[resource.URN]bool {
+	kept := make(map[resource.URN]bool)
+	if sg.deployment.prev == nil {
+		return kept
+	}
+
+	dg := graph.NewDependencyGraph(sg.deployment.prev.Resources)
+	for _, res := range sg.deployment.prev.Resources {
+		if !sg.excludesOpt[res.URN] {
+			continue
+		}
+		kept[res.URN] = true
+		for dep := range dg.TransitiveDependenciesOf(res) {
+			kept[dep.URN] = true
+		}
+	}
+	return kept
+}
+
+// transitiveDependents returns the (transitive) set of resources that depend on the target resources, including
+// the targets themselves. This includes both implicit and explicit dependents in the DAG itself, as well as children.
+func transitiveDependents(resources []*resource.State, targetsOpt map[resource.URN]bool) map[resource.URN]bool {
 	// Seed the list with the initial set of targets.
 	var frontier []*resource.State
-	for _, res := range sg.deployment.prev.Resources {
+	for _, res := range resources {
 		if _, has := targetsOpt[res.URN]; has {
 			frontier = append(frontier, res)
 		}
 	}
 
 	// Produce a dependency graph of resources.
-	dg := graph.NewDependencyGraph(sg.deployment.prev.Resources)
+	dg := graph.NewDependencyGraph(resources)
 
 	// Now accumulate a list of targets that are implicated because they depend upon the targets.
 	targets := make(map[resource.URN]bool)
@@ -1179,98 +1377,58 @@ func (sg *stepGenerator) GeneratePendingDeletes() []Step {
 				logging.V(7).Infof(
 					"stepGenerator.GeneratePendingDeletes(): resource (%v, %v) is pending deletion", res.URN, res.ID)
 				sg.pendingDeletes[res] = true
-				dels = append(dels, NewDeleteStep(sg.deployment, res))
+				dels = append(dels, NewDeleteStep(sg.deployment, nil, res))
 			}
 		}
 	}
 	return dels
 }
 
-// ScheduleDeletes takes a list of steps that will delete resources and "schedules" them by producing a list of list of
-// steps, where each list can be executed in parallel but a previous list must be executed to completion before
-// advancing to the next list.
+// ScheduleDeletes takes a list of steps that will delete resources and "schedules" them by computing, for each step,
+// the delete steps that must complete before it may begin.
 //
-// In lieu of tracking per-step dependencies and orienting the step executor around these dependencies, this function
-// provides a conservative approximation of what deletions can safely occur in parallel. The insight here is that the
-// resource dependency graph is a partially-ordered set and all partially-ordered sets can be easily decomposed into
-// antichains - subsets of the set that are all not comparable to one another. (In this definition, "not comparable"
-// means "do not depend on one another").
+// A resource may only be deleted once every resource that depends on it has been deleted, so each step waits on the
+// steps that delete its condemned dependents. Unlike a decomposition of the dependency graph into antichains, this
+// allows each delete to begin as soon as its own dependents are gone, so a single slow delete only holds up the
+// resources that it actually depends on.
 //
-// The algorithm for decomposing a poset into antichains is:
-//  1. While there exist elements in the poset,
-//    1a. There must exist at least one "maximal" element of the poset. Let E_max be those elements.
-//    2a. Remove all elements E_max from the poset. E_max is an antichain.
-//    3a. Goto 1.
-//
-// Translated to our dependency graph:
-//  1. While the set of condemned resources is not empty:
-//    1a. Remove all resources with no outgoing edges from the graph and add them to the current antichain.
-//    2a. Goto 1.
-//
-// The resulting list of antichains is a list of list of steps that can be safely executed in parallel. Since we must
-// process deletes in reverse (so we don't delete resources upon which other resources depend), we reverse the list and
-// hand it back to the deployment executor for safe execution.
-func (sg *stepGenerator) ScheduleDeletes(deleteSteps []Step) []antichain {
-	var antichains []antichain                // the list of parallelizable steps we intend to return.
-	dg := sg.deployment.depGraph              // the current deployment's dependency graph.
-	condemned := make(graph.ResourceSet)      // the set of condemned resources.
-	stepMap := make(map[*resource.State]Step) // a map from resource states to the steps that delete them.
+// If we don't trust the dependency graph, each step waits on the step before it and the deletes execute serially.
+func (sg *stepGenerator) ScheduleDeletes(deleteSteps []Step) deleteSchedule {
+	schedule := make(deleteSchedule, len(deleteSteps))
 
 	// If we don't trust the dependency graph we've been given, we must be conservative and delete everything serially.
 	if !sg.opts.TrustDependencies {
 		logging.V(7).Infof("Planner does not trust dependency graph, scheduling deletions serially")
-		for _, step := range deleteSteps {
-			antichains = append(antichains, antichain{step})
+		for i, step := range deleteSteps {
+			schedule[step] = nil
+			if i > 0 {
+				schedule[step] = []Step{deleteSteps[i-1]}
+			}
 		}
-
-		return antichains
+		return schedule
 	}
 
 	logging.V(7).Infof("Planner trusts dependency graph, scheduling deletions in parallel")
 
-	// For every step we've been given, record it as condemned and save the step that will be used to delete it. We'll
-	// iteratively place these steps into antichains as we remove elements from the condemned set.
+	// Record the step that will be used to delete each condemned resource.
+	stepMap := make(map[*resource.State]Step) // a map from resource states to the steps that delete them.
 	for _, step := range deleteSteps {
-		condemned[step.Res()] = true
 		stepMap[step.Res()] = step
+		schedule[step] = nil
 	}
 
-	for len(condemned) > 0 {
-		var steps antichain
-		logging.V(7).Infof("Planner beginning schedule of new deletion antichain")
-		for res := range condemned {
-			// Does res have any outgoing edges to resources that haven't already been removed from the graph?
-			condemnedDependencies := dg.DependenciesOf(res).Intersect(condemned)
-			if len(condemnedDependencies) == 0 {
-				// If not, it's safe to delete res at this stage.
-				logging.V(7).Infof("Planner scheduling deletion of '%v'", res.URN)
-				steps = append(steps, stepMap[res])
+	// Each resource's condemned dependencies must wait until the resource itself has been deleted.
+	dg := sg.deployment.depGraph
+	for _, step := range deleteSteps {
+		for dep := range dg.DependenciesOf(step.Res()) {
+			if depStep, ok := stepMap[dep]; ok && depStep != step {
+				logging.V(7).Infof("Planner scheduling deletion of '%v' after '%v'", dep.URN, step.URN())
+				schedule[depStep] = append(schedule[depStep], step)
 			}
-
-			// If one of this resource's dependencies or this resource's parent hasn't been removed from the graph yet,
-			// it can't be deleted this round.
 		}
-
-		// For all reosurces that are to be deleted in this round, remove them from the graph.
-		for _, step := range steps {
-			delete(condemned, step.Res())
-		}
-
-		antichains = append(antichains, steps)
-	}
-
-	// Up until this point, all logic has been "backwards" - we're scheduling resources for deletion when all of their
-	// dependencies finish deletion, but that's exactly the opposite of what we need to do. We can only delete a
-	// resource when all *resources that depend on it* complete deletion. Our solution is still correct, though, it's
-	// just backwards.
-	//
-	// All we have to do here is reverse the list and then our solution is correct.
-	for i := len(antichains)/2 - 1; i >= 0; i-- {
-		opp := len(antichains) - 1 - i
-		antichains[i], antichains[opp] = antichains[opp], antichains[i]
 	}
 
-	return antichains
+	return schedule
 }
 
 // providerChanged diffs the Provider field of old and new resources, returning true if the rest of the step generator
@@ -1811,14 +1969,15 @@ func (sg *stepGenerator) AnalyzeResources() result.Result {
 }
 
 // newStepGenerator creates a new step generator that operates on the given deployment.
-func newStepGenerator(
-	deployment *Deployment, opts Options, updateTargetsOpt, replaceTargetsOpt map[resource.URN]bool) *stepGenerator {
+func newStepGenerator(deployment *Deployment, opts Options,
+	updateTargetsOpt, replaceTargetsOpt, excludesOpt map[resource.URN]bool) *stepGenerator {
 
 	return &stepGenerator{
 		deployment:           deployment,
 		opts:                 opts,
 		updateTargetsOpt:     updateTargetsOpt,
 		replaceTargetsOpt:    replaceTargetsOpt,
+		excludesOpt:          excludesOpt,
 		urns:                 make(map[resource.URN]bool),
 		reads:                make(map[resource.URN]bool),
 		creates:              make(map[resource.URN]bool),
@@ -1827,6 +1986,7 @@ func newStepGenerator(
 		updates:              make(map[resource.URN]bool),
 		deletes:              make(map[resource.URN]bool),
 		skippedCreates:       make(map[resource.URN]bool),
+		refreshes:            make(map[resource.URN]*RefreshStep),
 		pendingDeletes:       make(map[*resource.State]bool),
 		providers:            make(map[resource.URN]*resource.State),
 		dependentReplaceKeys: make(map[resource.URN][]resource.PropertyKey),
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/v3/resource/graph"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/stretchr/testify/assert"
@@ -314,3 +315,47 @@ func TestEngineDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduleDeletes(t *testing.T) {
+	t.Parallel()
+
+	newState := func(name string, deps ...resource.URN) *resource.State {
+		urn := resource.URN("urn:pulumi:stack::project::pkgA:m:typA::" + name)
+		return &resource.State{Type: urn.Type(), URN: urn, Custom: true, Dependencies: deps}
+	}
+
+	// a <- b <- c, and d is unrelated.
+	a := newState("a")
+	b := newState("b", a.URN)
+	c := newState("c", b.URN)
+	d := newState("d")
+	resources := []*resource.State{a, b, c, d}
+
+	var steps []Step
+	stepOf := make(map[*resource.State]Step)
+	for _, res := range resources {
+		step := &DeleteStep{old: res}
+		steps = append(steps, step)
+		stepOf[res] = step
+	}
+
+	sg := &stepGenerator{
+		deployment: &Deployment{depGraph: graph.NewDependencyGraph(resources)},
+		opts:       Options{TrustDependencies: true},
+	}
+
+	// Each delete waits only on the deletes of the resources that depend on it.
+	schedule := sg.ScheduleDeletes(steps)
+	assert.Equal(t, []Step{stepOf[b]}, schedule[stepOf[a]])
+	assert.Equal(t, []Step{stepOf[c]}, schedule[stepOf[b]])
+	assert.Empty(t, schedule[stepOf[c]])
+	assert.Empty(t, schedule[stepOf[d]])
+
+	// If the dependency graph is not trusted, each delete waits on the one before it.
+	sg.opts.TrustDependencies = false
+	schedule = sg.ScheduleDeletes(steps)
+	assert.Empty(t, schedule[stepOf[a]])
+	assert.Equal(t, []Step{stepOf[a]}, schedule[stepOf[b]])
+	assert.Equal(t, []Step{stepOf[b]}, schedule[stepOf[c]])
+	assert.Equal(t, []Step{stepOf[c]}, schedule[stepOf[d]])
+}
@@ -0,0 +1,154 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// projectTransformation is the compiled form of a transformation declared in a project's options.
+type projectTransformation struct {
+	types       []*regexp.Regexp     // the type patterns to match; nil matches every type.
+	components  bool                 // true if component resources are transformed as well as custom resources.
+	properties  resource.PropertyMap // the properties to merge into matching resources' inputs.
+	namePattern *regexp.Regexp       // the pattern matching resources' names must match, if any.
+}
+
+// newProjectTransformations compiles the transformations declared in the given project's options.
+func newProjectTransformations(proj *workspace.Project) ([]projectTransformation, error) {
+	if proj == nil || proj.Options == nil {
+		return nil, nil
+	}
+
+	var transformations []projectTransformation
+	for i, decl := range proj.Options.Transformations {
+		t := projectTransformation{components: decl.IncludeComponents}
+		for _, pattern := range decl.Types {
+			t.types = append(t.types, compileTypePattern(pattern))
+		}
+		if len(decl.Properties) != 0 {
+			t.properties = resource.NewPropertyMapFromMap(stringKeyedMap(decl.Properties).(map[string]interface{}))
+		}
+		if decl.NamePattern != "" {
+			re, err := regexp.Compile(decl.NamePattern)
+			if err != nil {
+				return nil, fmt.Errorf("project option 'transformations[%d]': invalid name pattern '%s': %w",
+					i, decl.NamePattern, err)
+			}
+			t.namePattern = re
+		}
+		transformations = append(transformations, t)
+	}
+	return transformations, nil
+}
+
+// compileTypePattern turns a type pattern into an anchored regular expression in which "*" matches any sequence of
+// characters.
+func compileTypePattern(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+// stringKeyedMap converts the `map[interface{}]interface{}` values produced by the YAML decoder into
+// `map[string]interface{}` values so that they can be turned into property values.
+func stringKeyedMap(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[fmt.Sprintf("%v", key)] = stringKeyedMap(val)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[key] = stringKeyedMap(val)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, val := range v {
+			a[i] = stringKeyedMap(val)
+		}
+		return a
+	}
+	return v
+}
+
+// matches returns true if the transformation applies to resources of the given type. Component resources only match
+// if the transformation includes components.
+func (t projectTransformation) matches(typ tokens.Type, custom bool) bool {
+	if !custom && !t.components {
+		return false
+	}
+	if len(t.types) == 0 {
+		return true
+	}
+	for _, re := range t.types {
+		if re.MatchString(string(typ)) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyProjectTransformations applies each matching transformation in turn to the inputs of a resource with the given
+// type and name, and returns the transformed inputs. An error is returned if the resource's name does not match the
+// name pattern of a matching transformation.
+func applyProjectTransformations(transformations []projectTransformation, typ tokens.Type, name tokens.QName,
+	custom bool, props resource.PropertyMap) (resource.PropertyMap, error) {
+
+	for _, t := range transformations {
+		if !t.matches(typ, custom) {
+			continue
+		}
+		if t.namePattern != nil && !t.namePattern.MatchString(string(name)) {
+			return nil, fmt.Errorf("resource name '%s' does not match the project's naming pattern '%s'",
+				name, t.namePattern)
+		}
+		if t.properties != nil {
+			props = mergeTransformedProperties(props, t.properties)
+		}
+	}
+	return props, nil
+}
+
+// mergeTransformedProperties returns a copy of props with the values in overrides merged in. Objects are merged
+// recursively; any other value in overrides replaces the corresponding value in props, unless that value is unknown.
+// Unknown values are left alone, since the program's value may yet differ from the override once it is known.
+func mergeTransformedProperties(props, overrides resource.PropertyMap) resource.PropertyMap {
+	result := props.Copy()
+	for k, v := range overrides {
+		existing, has := result[k]
+		switch {
+		case has && existing.IsObject() && v.IsObject():
+			result[k] = resource.NewObjectProperty(mergeTransformedProperties(existing.ObjectValue(), v.ObjectValue()))
+		case has && existing.ContainsUnknowns():
+			continue
+		default:
+			result[k] = v
+		}
+	}
+	return result
+}
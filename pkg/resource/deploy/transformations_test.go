@@ -0,0 +1,122 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func TestProjectTransformationMatches(t *testing.T) {
+	t.Parallel()
+
+	transformations, err := newProjectTransformations(&workspace.Project{
+		Options: &workspace.ProjectOptions{
+			Transformations: []workspace.ProjectTransformation{
+				{},
+				{Types: []string{"aws:*", "*:s3/bucket:Bucket"}},
+				{IncludeComponents: true},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, transformations, 3)
+
+	assert.True(t, transformations[0].matches("random:index/randomPet:RandomPet", true))
+	assert.True(t, transformations[1].matches("aws:ec2/instance:Instance", true))
+	assert.True(t, transformations[1].matches("aws-native:s3/bucket:Bucket", true))
+	assert.False(t, transformations[1].matches("gcp:storage/bucket:Bucket", true))
+	assert.False(t, transformations[1].matches("my:aws:Component", true))
+
+	// Components, including the root stack, are only matched by transformations that include them.
+	assert.False(t, transformations[0].matches("my:index:Component", false))
+	assert.False(t, transformations[0].matches(resource.RootStackType, false))
+	assert.False(t, transformations[1].matches("aws:index:Component", false))
+	assert.True(t, transformations[2].matches("my:index:Component", false))
+	assert.True(t, transformations[2].matches(resource.RootStackType, false))
+}
+
+func TestApplyProjectTransformations(t *testing.T) {
+	t.Parallel()
+
+	transformations, err := newProjectTransformations(&workspace.Project{
+		Options: &workspace.ProjectOptions{
+			Transformations: []workspace.ProjectTransformation{
+				{
+					Types: []string{"pkgA:*"},
+					Properties: map[string]interface{}{
+						"tags":   map[interface{}]interface{}{"env": "prod"},
+						"region": "us-west-2",
+					},
+				},
+				{Types: []string{"pkgA:*"}, NamePattern: "^[a-z]+$"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	props := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"tags":   map[string]interface{}{"env": "dev", "owner": "me"},
+		"region": "us-east-1",
+	})
+	transformed, err := applyProjectTransformations(transformations, "pkgA:m:typA", "res", true, props)
+	assert.NoError(t, err)
+	assert.Equal(t, resource.NewPropertyMapFromMap(map[string]interface{}{
+		"tags":   map[string]interface{}{"env": "prod", "owner": "me"},
+		"region": "us-west-2",
+	}), transformed)
+
+	// The original inputs are not modified.
+	assert.Equal(t, "dev", props["tags"].ObjectValue()["env"].StringValue())
+
+	// Resources of other types are left alone.
+	transformed, err = applyProjectTransformations(transformations, "pkgB:m:typB", "Res-1", true, props)
+	assert.NoError(t, err)
+	assert.Equal(t, props, transformed)
+
+	// So are components.
+	transformed, err = applyProjectTransformations(transformations, "pkgA:m:component", "Res-1", false, props)
+	assert.NoError(t, err)
+	assert.Equal(t, props, transformed)
+
+	// Unknown values are not replaced.
+	unknowns := resource.PropertyMap{
+		"tags":   resource.MakeComputed(resource.NewStringProperty("")),
+		"region": resource.NewOutputProperty(resource.Output{Element: resource.NewStringProperty("")}),
+	}
+	transformed, err = applyProjectTransformations(transformations, "pkgA:m:typA", "res", true, unknowns)
+	assert.NoError(t, err)
+	assert.Equal(t, unknowns, transformed)
+
+	unknowns = resource.PropertyMap{
+		"tags": resource.NewObjectProperty(resource.PropertyMap{
+			"env":   resource.MakeComputed(resource.NewStringProperty("")),
+			"owner": resource.NewStringProperty("me"),
+		}),
+	}
+	transformed, err = applyProjectTransformations(transformations, "pkgA:m:typA", "res", true, unknowns)
+	assert.NoError(t, err)
+	assert.Equal(t, resource.PropertyMap{
+		"tags":   unknowns["tags"],
+		"region": resource.NewStringProperty("us-west-2"),
+	}, transformed)
+
+	_, err = applyProjectTransformations(transformations, "pkgA:m:typA", "Res-1", true, props)
+	assert.EqualError(t, err, "resource name 'Res-1' does not match the project's naming pattern '^[a-z]+$'")
+}
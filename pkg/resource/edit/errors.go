@@ -39,3 +39,24 @@ type ResourceProtectedError struct {
 func (ResourceProtectedError) Error() string {
 	return "Can't delete protected resource"
 }
+
+// ResourceHasDependentsError is returned by MoveResources if a resource can't be moved because resources that remain
+// in the source stack depend upon it.
+type ResourceHasDependentsError struct {
+	Moved      *resource.State
+	Dependents []*resource.State
+}
+
+func (r ResourceHasDependentsError) Error() string {
+	return fmt.Sprintf("Can't move resource %q due to dependent resources", r.Moved.URN)
+}
+
+// RepairNotAllowedError is returned by ProposeRepair if the only repair for a problem isn't allowed by the options it
+// was given.
+type RepairNotAllowedError struct {
+	Repair *Repair
+}
+
+func (r RepairNotAllowedError) Error() string {
+	return fmt.Sprintf("%s; the fix would %s, which must be allowed explicitly", r.Repair.Problem, r.Repair.Fix)
+}
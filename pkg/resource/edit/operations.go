@@ -135,6 +135,10 @@ func RenameStack(snap *deploy.Snapshot, newName tokens.Name, newProject tokens.P
 			}
 		}
 
+		if res.DeletedWith != "" {
+			res.DeletedWith = rewriteUrn(res.DeletedWith)
+		}
+
 		if res.Provider != "" {
 			providerRef, err := providers.ParseReference(res.Provider)
 			contract.AssertNoErrorf(err, "failed to parse provider reference from validated checkpoint")
@@ -160,3 +164,215 @@ func RenameStack(snap *deploy.Snapshot, newName tokens.Name, newProject tokens.P
 
 	return nil
 }
+
+// MoveResources moves the resources with the given URNs, along with all of their descendants, from one snapshot to
+// another, rewriting their URNs to belong to the given stack and project. The moved resources are returned in their
+// rewritten form.
+//
+// Provider resources used by the moved resources are copied to the destination, unless they are moved themselves or
+// the destination already contains the same provider. Default providers are never copied over a default provider of
+// the same package and version in the destination: the moved resources use the destination's instead, as they would
+// have if they had been created in the destination stack. Dependencies on resources that aren't moved are dropped, and
+// resources whose parents aren't moved are parented to the destination's root stack resource, if it has one. A
+// resource can't be moved if resources that remain in the source depend on it, in which case MoveResources returns
+// an error instance of `ResourceHasDependentsError`. Neither snapshot is modified if an error is returned.
+func MoveResources(source, dest *deploy.Snapshot, urns []resource.URN,
+	destStack tokens.QName, destProject tokens.PackageName) ([]*resource.State, error) {
+	contract.Require(source != nil, "source")
+	contract.Require(dest != nil, "dest")
+
+	// Find the resources to move: those with the given URNs and all of their descendants. Parents always precede
+	// their children in a snapshot, so a single pass suffices to find every descendant.
+	moving := make(map[resource.URN]bool)
+	for _, urn := range urns {
+		if len(LocateResource(source, urn)) == 0 {
+			return nil, fmt.Errorf("No such resource %q exists in the source stack", urn)
+		}
+		moving[urn] = true
+	}
+	var moved, remaining []*resource.State
+	for _, res := range source.Resources {
+		if !moving[res.URN] && (res.Parent == "" || !moving[res.Parent]) {
+			remaining = append(remaining, res)
+			continue
+		}
+		if res.Type == resource.RootStackType {
+			return nil, fmt.Errorf("The root stack resource %q can't be moved", res.URN)
+		}
+		moving[res.URN] = true
+		moved = append(moved, res)
+	}
+
+	// Make sure that nothing left behind depends on a resource that is moving.
+	dependents := make(map[resource.URN][]*resource.State)
+	for _, res := range remaining {
+		for _, dep := range resourceReferences(res) {
+			if moving[dep] {
+				dependents[dep] = append(dependents[dep], res)
+			}
+		}
+	}
+	for _, res := range moved {
+		if deps, has := dependents[res.URN]; has {
+			return nil, ResourceHasDependentsError{Moved: res, Dependents: deps}
+		}
+	}
+
+	var destRoot resource.URN
+	existing := make(map[resource.URN]*resource.State)
+	for _, res := range dest.Resources {
+		existing[res.URN] = res
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			destRoot = res.URN
+		}
+	}
+
+	// Rewrite the resources in order, so that the URN of every resource that has been placed in the destination is
+	// known by the time anything that depends on it is rewritten.
+	var added []*resource.State
+	placed := make(map[resource.URN]resource.URN)
+	rewriteURN := func(res *resource.State) resource.URN {
+		var parentType tokens.Type
+		if newParent, ok := placed[res.Parent]; ok {
+			parentType = newParent.QualifiedType()
+		}
+		return resource.NewURN(destStack, destProject, parentType, res.URN.Type(), res.URN.Name())
+	}
+	rewriteDependencies := func(deps []resource.URN) []resource.URN {
+		var rewritten []resource.URN
+		for _, dep := range deps {
+			if newDep, ok := placed[dep]; ok {
+				rewritten = append(rewritten, newDep)
+			}
+		}
+		return rewritten
+	}
+	rewriteState := func(res *resource.State) error {
+		newURN := rewriteURN(res)
+		if other, has := existing[newURN]; has && !other.Delete && !res.Delete {
+			return fmt.Errorf("A resource named %q already exists in the destination stack", newURN)
+		}
+
+		if newParent, ok := placed[res.Parent]; ok {
+			res.Parent = newParent
+		} else {
+			res.Parent = destRoot
+		}
+		res.Dependencies = rewriteDependencies(res.Dependencies)
+		if res.PropertyDependencies != nil {
+			propertyDependencies := make(map[resource.PropertyKey][]resource.URN)
+			for key, deps := range res.PropertyDependencies {
+				if rewritten := rewriteDependencies(deps); len(rewritten) != 0 {
+					propertyDependencies[key] = rewritten
+				}
+			}
+			res.PropertyDependencies = propertyDependencies
+		}
+		// A resource that isn't moved along with this one won't delete it in the destination.
+		if newWith, ok := placed[res.DeletedWith]; ok {
+			res.DeletedWith = newWith
+		} else {
+			res.DeletedWith = ""
+		}
+		// Aliases refer to URNs in the source stack, which are meaningless in the destination.
+		res.Aliases = nil
+
+		placed[res.URN] = newURN
+		existing[newURN] = res
+		res.URN = newURN
+		added = append(added, res)
+		return nil
+	}
+
+	// Rewrite copies of the moved resources, so that the snapshots are left untouched if anything goes wrong.
+	copies := make([]*resource.State, len(moved))
+	for i, res := range moved {
+		copied := *res
+		copies[i] = &copied
+	}
+	newProviders := make(map[string]string)
+	for _, res := range copies {
+		if res.Provider != "" {
+			newRef, ok := newProviders[res.Provider]
+			if !ok {
+				ref, err := providers.ParseReference(res.Provider)
+				if err != nil {
+					return nil, fmt.Errorf("parsing provider reference of %q: %w", res.URN, err)
+				}
+
+				// Providers that aren't moving are copied to the destination the first time that they are needed.
+				var rewritten providers.Reference
+				if moving[ref.URN()] {
+					rewritten, err = providers.NewReference(placed[ref.URN()], ref.ID())
+					contract.AssertNoErrorf(err, "failed to generate provider reference from valid reference")
+				} else {
+					rewritten, err = copyProvider(source, ref, rewriteURN, rewriteState, existing, placed)
+					if err != nil {
+						return nil, err
+					}
+				}
+				newRef = rewritten.String()
+				newProviders[res.Provider] = newRef
+			}
+			res.Provider = newRef
+		}
+
+		if err := rewriteState(res); err != nil {
+			return nil, err
+		}
+	}
+
+	source.Resources = remaining
+	dest.Resources = append(dest.Resources, added...)
+	return copies, nil
+}
+
+// copyProvider copies the provider resource with the given reference in the source snapshot to the destination and
+// returns its reference in the destination. If the destination already contains the same provider, or a default
+// provider with the same name, that provider is referenced instead.
+func copyProvider(source *deploy.Snapshot, ref providers.Reference,
+	rewriteURN func(*resource.State) resource.URN, rewriteState func(*resource.State) error,
+	existing map[resource.URN]*resource.State, placed map[resource.URN]resource.URN) (providers.Reference, error) {
+
+	var provider *resource.State
+	for _, res := range LocateResource(source, ref.URN()) {
+		if res.ID == ref.ID() && !res.Delete {
+			provider = res
+		}
+	}
+	if provider == nil {
+		return providers.Reference{}, fmt.Errorf("No provider %q with ID %q exists in the source stack",
+			ref.URN(), ref.ID())
+	}
+
+	copied := *provider
+	if other, has := existing[rewriteURN(&copied)]; has && !other.Delete {
+		if other.ID != copied.ID && !providers.IsDefaultProvider(other.URN) {
+			return providers.Reference{}, fmt.Errorf(
+				"A different provider named %q already exists in the destination stack", other.URN)
+		}
+		placed[copied.URN] = other.URN
+		return providers.NewReference(other.URN, other.ID)
+	}
+	if err := rewriteState(&copied); err != nil {
+		return providers.Reference{}, err
+	}
+	return providers.NewReference(copied.URN, copied.ID)
+}
+
+// resourceReferences returns the URNs of all the resources that the given resource refers to.
+func resourceReferences(res *resource.State) []resource.URN {
+	refs := append([]resource.URN{}, res.Dependencies...)
+	for _, deps := range res.PropertyDependencies {
+		refs = append(refs, deps...)
+	}
+	if res.Parent != "" {
+		refs = append(refs, res.Parent)
+	}
+	if res.Provider != "" {
+		if ref, err := providers.ParseReference(res.Provider); err == nil {
+			refs = append(refs, ref.URN())
+		}
+	}
+	return refs
+}
@@ -343,3 +343,115 @@ func TestRenameStack(t *testing.T) {
 		assert.Len(t, LocateResource(snap, updatedResourceURN), 1)
 	})
 }
+
+func TestMoveResources(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	b.PropertyDependencies = map[resource.PropertyKey][]resource.URN{"x": {a.URN}}
+	child := NewResource("child", pA, b.URN)
+	child.URN = resource.NewURN("test", "test", b.URN.QualifiedType(), "a:b:c", "child")
+	child.Parent = b.URN
+	source := NewSnapshot([]*resource.State{pA, a, b, child})
+
+	root := &resource.State{
+		Type: resource.RootStackType,
+		URN:  resource.DefaultRootStackURN("dest", "proj"),
+	}
+	dest := NewSnapshot([]*resource.State{root})
+
+	moved, err := MoveResources(source, dest, []resource.URN{b.URN}, "dest", "proj")
+	assert.NoError(t, err)
+	assert.Len(t, moved, 2)
+
+	// The provider stays in the source, where it is still used.
+	assert.Equal(t, []*resource.State{pA, a}, source.Resources)
+	assert.Equal(t, resource.URN("urn:pulumi:test::test::a:b:c::b"), b.URN)
+
+	// The provider is copied to the destination ahead of the moved resources, and the moved resources are rewritten.
+	if assert.Len(t, dest.Resources, 4) {
+		newP, newB, newChild := dest.Resources[1], dest.Resources[2], dest.Resources[3]
+		assert.Equal(t, resource.URN("urn:pulumi:dest::proj::pulumi:providers:a::p1"), newP.URN)
+		assert.Equal(t, root.URN, newP.Parent)
+		assert.Equal(t, pA.ID, newP.ID)
+
+		assert.Equal(t, resource.URN("urn:pulumi:dest::proj::a:b:c::b"), newB.URN)
+		assert.Equal(t, root.URN, newB.Parent)
+		assert.Equal(t, "urn:pulumi:dest::proj::pulumi:providers:a::p1::0", newB.Provider)
+		assert.Empty(t, newB.Dependencies)
+		assert.Empty(t, newB.PropertyDependencies)
+
+		assert.Equal(t, resource.URN("urn:pulumi:dest::proj::a:b:c$a:b:c::child"), newChild.URN)
+		assert.Equal(t, newB.URN, newChild.Parent)
+		assert.Equal(t, []resource.URN{newB.URN}, newChild.Dependencies)
+	}
+	assert.NoError(t, source.VerifyIntegrity())
+	assert.NoError(t, dest.VerifyIntegrity())
+
+	// Moving a resource with the same name again conflicts with the one that was moved already.
+	other := NewResource("b", pA)
+	source.Resources = append(source.Resources, other)
+	_, err = MoveResources(source, dest, []resource.URN{other.URN}, "dest", "proj")
+	assert.ErrorContains(t, err, "already exists in the destination stack")
+	assert.Len(t, source.Resources, 3)
+	assert.Len(t, dest.Resources, 4)
+}
+
+func TestMoveResourcesDefaultProvider(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "default_1_0_0", "0")
+	a := NewResource("a", pA)
+	pB := NewProviderResource("b", "p1", "0")
+	b := NewResource("b", pB)
+	source := NewSnapshot([]*resource.State{pA, a, pB, b})
+
+	root := &resource.State{
+		Type: resource.RootStackType,
+		URN:  resource.DefaultRootStackURN("dest", "proj"),
+	}
+	destA := NewProviderResource("a", "default_1_0_0", "1")
+	destA.URN = resource.NewURN("dest", "proj", "", destA.Type, "default_1_0_0")
+	destB := NewProviderResource("b", "p1", "1")
+	destB.URN = resource.NewURN("dest", "proj", "", destB.Type, "p1")
+	dest := NewSnapshot([]*resource.State{root, destA, destB})
+
+	// The moved resource uses the destination's default provider rather than a conflicting copy of the source's.
+	moved, err := MoveResources(source, dest, []resource.URN{a.URN}, "dest", "proj")
+	assert.NoError(t, err)
+	if assert.Len(t, moved, 1) {
+		assert.Equal(t, "urn:pulumi:dest::proj::pulumi:providers:a::default_1_0_0::1", moved[0].Provider)
+	}
+	assert.Equal(t, []*resource.State{root, destA, destB, moved[0]}, dest.Resources)
+	assert.NoError(t, dest.VerifyIntegrity())
+
+	// Explicit providers with the same name but a different ID still conflict.
+	_, err = MoveResources(source, dest, []resource.URN{b.URN}, "dest", "proj")
+	assert.ErrorContains(t, err, "A different provider named")
+	assert.Equal(t, []*resource.State{pA, pB, b}, source.Resources)
+}
+
+func TestFailedMoveResourcesDependents(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	source := NewSnapshot([]*resource.State{pA, a, b})
+	dest := NewSnapshot(nil)
+
+	_, err := MoveResources(source, dest, []resource.URN{a.URN}, "dest", "proj")
+	depErr, ok := err.(ResourceHasDependentsError)
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+	assert.Equal(t, a, depErr.Moved)
+	assert.Equal(t, []*resource.State{b}, depErr.Dependents)
+	assert.Equal(t, []*resource.State{pA, a, b}, source.Resources)
+	assert.Empty(t, dest.Resources)
+
+	_, err = MoveResources(source, dest, []resource.URN{"urn:pulumi:test::test::a:b:c::missing"}, "dest", "proj")
+	assert.ErrorContains(t, err, "No such resource")
+}
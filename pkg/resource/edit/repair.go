@@ -0,0 +1,356 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// Repair is a proposed fix for a problem with a snapshot, such as one that causes it to fail its integrity checks.
+type Repair struct {
+	// Problem describes the problem with the snapshot.
+	Problem string
+	// Fix describes the change to the snapshot that fixes the problem.
+	Fix string
+
+	removes bool // true if the repair removes resources from the snapshot
+	apply   func(snap *deploy.Snapshot)
+}
+
+// RemovesResources returns true if the repair removes resources from the snapshot.
+func (r *Repair) RemovesResources() bool {
+	return r.removes
+}
+
+// Apply makes the repair to the snapshot that it was proposed for.
+func (r *Repair) Apply(snap *deploy.Snapshot) {
+	r.apply(snap)
+}
+
+// RepairOptions controls which of the more drastic repairs ProposeRepair may propose.
+type RepairOptions struct {
+	// RebindProviders allows resources that refer to missing providers to be switched to another provider for the
+	// same package. The other provider may be configured differently, e.g. for another region or account.
+	RebindProviders bool
+	// RemoveResources allows resources that can't be repaired any other way to be removed from the snapshot. The
+	// resources that are removed must be imported again if they still exist.
+	RemoveResources bool
+}
+
+// ProposeRepair examines a snapshot and proposes a repair for the first problem it finds, returning nil if there are
+// no problems. Repairs should be applied one at a time, since each may change the problems that remain. An error is
+// returned if there is a problem that can't be repaired automatically.
+//
+// The problems that can be repaired are:
+//
+//   - a manifest whose magic cookie doesn't match its contents, which is recomputed;
+//   - pending operations left behind by an interrupted update, which are cleared;
+//   - resources that precede the resources they refer to, which are reordered;
+//   - references to missing providers, which are switched to another provider for the same package, or if there is
+//     no such provider, whose resources are removed, if allowed by the options;
+//   - references to missing parents, which are replaced with the root stack resource;
+//   - dependencies on missing resources, which are dropped;
+//   - duplicate resources that aren't marked for deletion, which are either marked for deletion or moved ahead of the
+//     duplicates that are.
+//
+// If the only repair for a problem isn't allowed by the options, a RepairNotAllowedError is returned.
+func ProposeRepair(snap *deploy.Snapshot, opts RepairOptions) (*Repair, error) {
+	contract.Require(snap != nil, "snap")
+
+	if magic := snap.Manifest.NewMagic(); snap.Manifest.Magic != magic {
+		return &Repair{
+			Problem: "the magic cookie of the manifest doesn't match its contents",
+			Fix:     "recompute the magic cookie",
+			apply:   func(snap *deploy.Snapshot) { snap.Manifest.Magic = magic },
+		}, nil
+	}
+
+	if len(snap.PendingOperations) != 0 {
+		return proposePendingOperationRepair(snap.PendingOperations[0]), nil
+	}
+
+	all := make(map[resource.URN]*resource.State)
+	var root resource.URN
+	for _, res := range snap.Resources {
+		all[res.URN] = res
+		if res.Type == resource.RootStackType && res.Parent == "" {
+			root = res.URN
+		}
+	}
+
+	// Walk the resources in order, checking the references of each against the resources that precede it.
+	seen := make(map[resource.URN]*resource.State)
+	provs := make(map[providers.Reference]struct{})
+	for _, res := range snap.Resources {
+		if providers.IsProviderType(res.Type) {
+			ref, err := providers.NewReference(res.URN, res.ID)
+			if err != nil {
+				return nil, fmt.Errorf("provider %s is not referenceable and can't be repaired automatically: %w",
+					res.URN, err)
+			}
+			provs[ref] = struct{}{}
+		}
+
+		if res.Provider != "" {
+			ref, err := providers.ParseReference(res.Provider)
+			if _, has := provs[ref]; err != nil || !has {
+				if err == nil && isProviderLater(snap, ref) {
+					return proposeReorder(snap)
+				}
+				return proposeProviderRepair(snap, res, opts)
+			}
+		}
+
+		if res.Parent != "" {
+			if _, has := seen[res.Parent]; !has {
+				if _, exists := all[res.Parent]; exists {
+					return proposeReorder(snap)
+				}
+				return proposeParentRepair(res, root), nil
+			}
+		}
+
+		for _, dep := range res.Dependencies {
+			if _, has := seen[dep]; !has {
+				if _, exists := all[dep]; exists {
+					return proposeReorder(snap)
+				}
+				return proposeDependencyRepair(res, dep), nil
+			}
+		}
+		for _, deps := range res.PropertyDependencies {
+			for _, dep := range deps {
+				if _, exists := all[dep]; !exists {
+					return proposeDependencyRepair(res, dep), nil
+				}
+			}
+		}
+
+		if other, has := seen[res.URN]; has && !res.Delete {
+			return proposeDuplicateRepair(res, other), nil
+		}
+		if _, has := seen[res.URN]; !has {
+			seen[res.URN] = res
+		}
+	}
+
+	return nil, nil
+}
+
+// proposeDuplicateRepair proposes a fix for a resource that isn't marked for deletion but follows another resource
+// with the same URN. Only the first of the resources with the same URN may be live.
+func proposeDuplicateRepair(res, first *resource.State) *Repair {
+	problem := fmt.Sprintf("there are multiple resources named %s that are not marked for deletion", res.URN)
+	if !first.Delete {
+		return &Repair{
+			Problem: problem,
+			Fix:     "mark the later resource for deletion",
+			apply:   func(*deploy.Snapshot) { res.Delete = true },
+		}
+	}
+	return &Repair{
+		Problem: fmt.Sprintf("resource %s follows resources with the same name that are marked for deletion", res.URN),
+		Fix:     "move the resource ahead of the resources that are marked for deletion",
+		apply: func(snap *deploy.Snapshot) {
+			resources := make([]*resource.State, 0, len(snap.Resources))
+			for _, other := range snap.Resources {
+				if other == first {
+					resources = append(resources, res)
+				}
+				if other != res {
+					resources = append(resources, other)
+				}
+			}
+			snap.Resources = resources
+		},
+	}
+}
+
+func proposePendingOperationRepair(op resource.Operation) *Repair {
+	problem := fmt.Sprintf("an interrupted update left a pending %s operation on %s", op.Type, op.Resource.URN)
+	switch op.Type {
+	case resource.OperationTypeCreating, resource.OperationTypeImporting:
+		problem += "; the resource may exist even though it isn't in the state, in which case it must be " +
+			"imported or deleted by hand"
+	case resource.OperationTypeUpdating, resource.OperationTypeDeleting:
+		problem += "; run `pulumi refresh` afterwards to bring the state of the resource up to date"
+	}
+	return &Repair{
+		Problem: problem,
+		Fix:     "clear the pending operation",
+		apply: func(snap *deploy.Snapshot) {
+			snap.PendingOperations = snap.PendingOperations[1:]
+		},
+	}
+}
+
+// isProviderLater returns true if the provider with the given reference appears anywhere in the snapshot. This is
+// only used once the provider has been found not to precede a resource that uses it.
+func isProviderLater(snap *deploy.Snapshot, ref providers.Reference) bool {
+	for _, res := range snap.Resources {
+		if res.URN == ref.URN() && res.ID == ref.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+// proposeReorder proposes sorting the resources of a snapshot so that every resource follows the resources that it
+// refers to. Resources are otherwise kept in their original order.
+func proposeReorder(snap *deploy.Snapshot) (*Repair, error) {
+	byURN := make(map[resource.URN][]*resource.State)
+	for _, res := range snap.Resources {
+		byURN[res.URN] = append(byURN[res.URN], res)
+	}
+
+	// Visit the resources depth-first, placing the resources that each refers to before the resource itself.
+	const visiting, visited = 1, 2
+	state := make(map[*resource.State]int)
+	sorted := make([]*resource.State, 0, len(snap.Resources))
+	var visit func(res *resource.State) error
+	visit = func(res *resource.State) error {
+		switch state[res] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("resource %s refers to itself through a cycle of references and can't be reordered "+
+				"automatically", res.URN)
+		}
+		state[res] = visiting
+		for _, ref := range resourceReferences(res) {
+			if ref == res.URN {
+				continue
+			}
+			// Any resources with the same URN are alternate versions of the first, which is the one that counts.
+			if others := byURN[ref]; len(others) != 0 {
+				if err := visit(others[0]); err != nil {
+					return err
+				}
+			}
+		}
+		state[res] = visited
+		sorted = append(sorted, res)
+		return nil
+	}
+	for _, res := range snap.Resources {
+		if err := visit(res); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Repair{
+		Problem: "some resources come before the resources that they refer to",
+		Fix:     "reorder the resources so that every resource comes after the resources that it refers to",
+		apply:   func(snap *deploy.Snapshot) { snap.Resources = sorted },
+	}, nil
+}
+
+// proposeProviderRepair proposes a fix for a resource that refers to a missing provider: either switching it to
+// another provider for the same package, or removing it from the snapshot if there is none. Both must be allowed by
+// the options.
+func proposeProviderRepair(snap *deploy.Snapshot, res *resource.State, opts RepairOptions) (*Repair, error) {
+	problem := fmt.Sprintf("resource %s refers to missing provider %s", res.URN, res.Provider)
+
+	if ref, err := providers.ParseReference(res.Provider); err == nil {
+		var candidate *resource.State
+		for _, other := range snap.Resources {
+			if other.Type != ref.URN().Type() || other.Delete {
+				continue
+			}
+			// Prefer default providers, which are the ones used by resources that don't specify a provider.
+			if candidate == nil || providers.IsDefaultProvider(other.URN) && !providers.IsDefaultProvider(candidate.URN) {
+				candidate = other
+			}
+		}
+		if candidate != nil {
+			newRef, err := providers.NewReference(candidate.URN, candidate.ID)
+			if err == nil {
+				repair := &Repair{
+					Problem: problem,
+					Fix:     fmt.Sprintf("use provider %s instead", newRef),
+					apply:   func(*deploy.Snapshot) { res.Provider = newRef.String() },
+				}
+				if !opts.RebindProviders {
+					return nil, RepairNotAllowedError{Repair: repair}
+				}
+				return repair, nil
+			}
+		}
+	}
+
+	repair := &Repair{
+		Problem: problem,
+		Fix: "remove the resource from the state, as there is no other provider for it to use; the resource " +
+			"must be imported again if it still exists",
+		removes: true,
+		apply: func(snap *deploy.Snapshot) {
+			var resources []*resource.State
+			for _, other := range snap.Resources {
+				if other != res {
+					resources = append(resources, other)
+				}
+			}
+			snap.Resources = resources
+		},
+	}
+	if !opts.RemoveResources {
+		return nil, RepairNotAllowedError{Repair: repair}
+	}
+	return repair, nil
+}
+
+// proposeParentRepair proposes replacing the missing parent of a resource with the root stack resource.
+func proposeParentRepair(res *resource.State, root resource.URN) *Repair {
+	fix := "remove the reference to the parent"
+	if root != "" && root != res.URN {
+		fix = "make the root stack resource the parent instead"
+	} else {
+		root = ""
+	}
+	return &Repair{
+		Problem: fmt.Sprintf("resource %s refers to missing parent %s", res.URN, res.Parent),
+		Fix:     fix,
+		apply:   func(*deploy.Snapshot) { res.Parent = root },
+	}
+}
+
+// proposeDependencyRepair proposes dropping a dependency on a missing resource.
+func proposeDependencyRepair(res *resource.State, dep resource.URN) *Repair {
+	return &Repair{
+		Problem: fmt.Sprintf("resource %s depends on missing resource %s", res.URN, dep),
+		Fix:     "remove the dependency",
+		apply: func(*deploy.Snapshot) {
+			res.Dependencies = removeURN(res.Dependencies, dep)
+			for key, deps := range res.PropertyDependencies {
+				res.PropertyDependencies[key] = removeURN(deps, dep)
+			}
+		},
+	}
+}
+
+func removeURN(urns []resource.URN, urn resource.URN) []resource.URN {
+	var result []resource.URN
+	for _, u := range urns {
+		if u != urn {
+			result = append(result, u)
+		}
+	}
+	return result
+}
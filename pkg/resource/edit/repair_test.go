@@ -0,0 +1,139 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// repairAll applies repairs to a snapshot until there are none left, returning the fixes that were made.
+func repairAll(t *testing.T, snap *deploy.Snapshot, opts RepairOptions) []string {
+	var fixes []string
+	for i := 0; i < 10; i++ {
+		repair, err := ProposeRepair(snap, opts)
+		require.NoError(t, err)
+		if repair == nil {
+			assert.NoError(t, snap.VerifyIntegrity())
+			return fixes
+		}
+		repair.Apply(snap)
+		fixes = append(fixes, repair.Fix)
+	}
+	t.Fatalf("repairs did not converge: %v", fixes)
+	return nil
+}
+
+func TestRepairNothing(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	snap := NewSnapshot([]*resource.State{pA, a, b})
+
+	assert.Empty(t, repairAll(t, snap, RepairOptions{}))
+	assert.Equal(t, []*resource.State{pA, a, b}, snap.Resources)
+}
+
+func TestRepairDanglingReferences(t *testing.T) {
+	t.Parallel()
+
+	root := &resource.State{
+		Type: resource.RootStackType,
+		URN:  resource.DefaultRootStackURN("test", "test"),
+	}
+	pA := NewProviderResource("a", "p1", "0")
+	missingProvider := NewProviderResource("a", "p2", "1")
+	a := NewResource("a", missingProvider)
+	b := NewResource("b", pA, a.URN, "urn:pulumi:test::test::a:b:c::missing")
+	b.PropertyDependencies = map[resource.PropertyKey][]resource.URN{
+		"x": {"urn:pulumi:test::test::a:b:c::missing"},
+	}
+	c := NewResource("c", pA)
+	c.Parent = "urn:pulumi:test::test::a:b:c::gone"
+	snap := NewSnapshot([]*resource.State{root, pA, a, b, c})
+
+	// Switching to another provider must be allowed explicitly.
+	_, err := ProposeRepair(snap, RepairOptions{RemoveResources: true})
+	var notAllowed RepairNotAllowedError
+	require.ErrorAs(t, err, &notAllowed)
+	assert.False(t, notAllowed.Repair.RemovesResources())
+
+	assert.Equal(t, []string{
+		"use provider urn:pulumi:test::test::pulumi:providers:a::p1::0 instead",
+		"remove the dependency",
+		"make the root stack resource the parent instead",
+	}, repairAll(t, snap, RepairOptions{RebindProviders: true}))
+	assert.Equal(t, "urn:pulumi:test::test::pulumi:providers:a::p1::0", a.Provider)
+	assert.Equal(t, []resource.URN{a.URN}, b.Dependencies)
+	assert.Empty(t, b.PropertyDependencies["x"])
+	assert.Equal(t, root.URN, c.Parent)
+}
+
+func TestRepairMissingProviderWithoutAlternative(t *testing.T) {
+	t.Parallel()
+
+	missingProvider := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", missingProvider)
+	b := NewResource("b", nil)
+	snap := NewSnapshot([]*resource.State{a, b})
+
+	// Removing the resource must be allowed explicitly.
+	_, err := ProposeRepair(snap, RepairOptions{RebindProviders: true})
+	var notAllowed RepairNotAllowedError
+	require.ErrorAs(t, err, &notAllowed)
+	assert.True(t, notAllowed.Repair.RemovesResources())
+	assert.Equal(t, []*resource.State{a, b}, snap.Resources)
+
+	assert.Len(t, repairAll(t, snap, RepairOptions{RemoveResources: true}), 1)
+	assert.Equal(t, []*resource.State{b}, snap.Resources)
+}
+
+func TestRepairOrderAndDuplicates(t *testing.T) {
+	t.Parallel()
+
+	pA := NewProviderResource("a", "p1", "0")
+	a := NewResource("a", pA)
+	b := NewResource("b", pA, a.URN)
+	dupA := NewResource("a", pA)
+	snap := NewSnapshot([]*resource.State{b, pA, a, dupA})
+	snap.PendingOperations = []resource.Operation{resource.NewOperation(b, resource.OperationTypeUpdating)}
+
+	assert.Equal(t, []string{
+		"clear the pending operation",
+		"reorder the resources so that every resource comes after the resources that it refers to",
+		"mark the later resource for deletion",
+	}, repairAll(t, snap, RepairOptions{}))
+	assert.Equal(t, []*resource.State{pA, a, b, dupA}, snap.Resources)
+	assert.True(t, dupA.Delete)
+	assert.Empty(t, snap.PendingOperations)
+}
+
+func TestRepairCycle(t *testing.T) {
+	t.Parallel()
+
+	a := NewResource("a", nil, "urn:pulumi:test::test::a:b:c::b")
+	b := NewResource("b", nil, a.URN)
+	snap := NewSnapshot([]*resource.State{a, b})
+
+	_, err := ProposeRepair(snap, RepairOptions{})
+	assert.ErrorContains(t, err, "cycle")
+}
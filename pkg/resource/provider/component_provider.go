@@ -201,6 +201,17 @@ func (p *componentProvider) Cancel(context.Context, *pbempty.Empty) (*pbempty.Em
 	return &pbempty.Empty{}, nil
 }
 
+// GetMapping returns the mapping for this provider from another ecosystem. Component providers have no mappings.
+func (p *componentProvider) GetMapping(ctx context.Context,
+	req *pulumirpc.GetMappingRequest) (*pulumirpc.GetMappingResponse, error) {
+	return &pulumirpc.GetMappingResponse{}, nil
+}
+
+// List enumerates the live resources of the given type. Component providers have no resources to list.
+func (p *componentProvider) List(context.Context, *pulumirpc.ListRequest) (*pulumirpc.ListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "List is not yet implemented")
+}
+
 // Attach attaches to the engine for an already running provider.
 func (p *componentProvider) Attach(ctx context.Context,
 	req *pulumirpc.PluginAttach) (*pbempty.Empty, error) {
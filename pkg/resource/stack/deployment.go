@@ -323,6 +323,10 @@ func SerializeResource(res *resource.State, enc config.Encrypter, showSecrets bo
 		ImportID:                res.ImportID,
 		SequenceNumber:          res.SequenceNumber,
 		RetainOnDelete:          res.RetainOnDelete,
+		DeletedWith:             res.DeletedWith,
+		Created:                 res.Created,
+		Modified:                res.Modified,
+		RetryPolicy:             res.RetryPolicy,
 	}
 
 	if res.CustomTimeouts.IsNotEmpty() {
@@ -500,11 +504,14 @@ func DeserializeResource(res apitype.ResourceV3, dec config.Decrypter, enc confi
 		return nil, fmt.Errorf("resource '%s' has 'custom' false but non-empty ID", res.URN)
 	}
 
-	return resource.NewState(
+	state := resource.NewState(
 		res.Type, res.URN, res.Custom, res.Delete, res.ID,
 		inputs, outputs, res.Parent, res.Protect, res.External, res.Dependencies, res.InitErrors, res.Provider,
 		res.PropertyDependencies, res.PendingReplacement, res.AdditionalSecretOutputs, res.Aliases, res.CustomTimeouts,
-		res.ImportID, res.SequenceNumber, res.RetainOnDelete), nil
+		res.ImportID, res.SequenceNumber, res.RetainOnDelete, res.DeletedWith)
+	state.Created, state.Modified = res.Created, res.Modified
+	state.RetryPolicy = res.RetryPolicy
+	return state, nil
 }
 
 func DeserializeOperation(op apitype.OperationV2, dec config.Decrypter,
@@ -42,10 +42,20 @@ case "$1" in
         VERSION="$2"
         (cd pkg   && go mod edit -require github.com/pulumi/pulumi/sdk/v3@${VERSION})
         (cd tests && go mod edit -require github.com/pulumi/pulumi/sdk/v3@${VERSION})
+        for lang in nodejs python dotnet; do
+            dir="sdk/${lang}/cmd/pulumi-language-${lang}"
+            (cd "$dir" && go mod edit \
+                -require github.com/pulumi/pulumi/pkg/v3@${VERSION} \
+                -require github.com/pulumi/pulumi/sdk/v3@${VERSION})
+        done
+        (cd sdk/go/pulumi-language-go && go mod edit \
+            -require github.com/pulumi/pulumi/pkg/v3@${VERSION} \
+            -require github.com/pulumi/pulumi/sdk/v3@${VERSION})
         make tidy
 
         git add pkg
         git add tests
+        git add sdk
         git commit -m "Release ${VERSION}"
 
         echo "### Improvements" >  CHANGELOG_PENDING.md
@@ -32,12 +32,15 @@ import (
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
+	codegen "github.com/pulumi/pulumi/pkg/v3/codegen/dotnet"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/executable"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/version"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 	"google.golang.org/grpc"
 )
@@ -675,6 +678,116 @@ func (host *dotnetLanguageHost) GetPluginInfo(ctx context.Context, req *pbempty.
 	}, nil
 }
 
+// GenerateProgram generates .NET source code for the given PCL program. The program may refer to variables that it
+// does not declare, as the resource definitions generated by `pulumi import` do.
+func (host *dotnetLanguageHost) GenerateProgram(ctx context.Context,
+	req *pulumirpc.GenerateProgramRequest) (*pulumirpc.GenerateProgramResponse, error) {
+
+	program, diags, err := pcl.BindProgramSource(req.GetSource(), pcl.AllowMissingVariables)
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	files, diags, err := codegen.GenerateProgram(program)
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return &pulumirpc.GenerateProgramResponse{Source: files}, nil
+}
+
+// GenerateProject generates an .NET project for the given PCL program and writes it to the requested directory.
+func (host *dotnetLanguageHost) GenerateProject(ctx context.Context,
+	req *pulumirpc.GenerateProjectRequest) (*pulumirpc.GenerateProjectResponse, error) {
+
+	var project workspace.Project
+	if err := json.Unmarshal([]byte(req.GetProject()), &project); err != nil {
+		return nil, fmt.Errorf("unmarshaling project: %w", err)
+	}
+
+	program, diags, err := pcl.BindProgramSource(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	if err = codegen.GenerateProject(req.GetDirectory(), project, program); err != nil {
+		return nil, err
+	}
+	return &pulumirpc.GenerateProjectResponse{}, nil
+}
+
+// GetProgramDependencies returns the NuGet packages that the program references, as reported by
+// `dotnet list package`.
+func (host *dotnetLanguageHost) GetProgramDependencies(ctx context.Context,
+	req *pulumirpc.GetProgramDependenciesRequest) (*pulumirpc.GetProgramDependenciesResponse, error) {
+
+	if host.binary != "" {
+		return nil, errors.New("could not get dependencies because pulumi specifies a binary")
+	}
+
+	ex, err := executable.FindExecutable("dotnet")
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"list", "package"}
+	if req.TransitiveDependencies {
+		args = append(args, "--include-transitive")
+	}
+	cmd := exec.Command(ex, args...)
+	cmd.Dir = req.Pwd
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to call \"%s %s\": %w", ex, strings.Join(args, " "), err)
+	}
+
+	dependencies, err := parseDotnetPackageList(string(out))
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.GetProgramDependenciesResponse{
+		Dependencies: dependencies,
+	}, nil
+}
+
+// parseDotnetPackageList parses the output of `dotnet list package`. Top-level packages are listed as
+// "> name requested resolved" and transitive packages as "> name resolved".
+func parseDotnetPackageList(out string) ([]*pulumirpc.DependencyInfo, error) {
+	lines := strings.Split(strings.ReplaceAll(out, "\r\n", "\n"), "\n")
+	var packages []*pulumirpc.DependencyInfo
+	for _, p := range lines {
+		p := strings.TrimSpace(p)
+		if !strings.HasPrefix(p, ">") {
+			continue
+		}
+
+		fields := strings.Fields(strings.TrimPrefix(p, ">"))
+		var version int
+		switch len(fields) {
+		case 3:
+			// Top level package => name requested resolved
+			version = 2
+		case 2:
+			// Transitive package => name resolved
+			version = 1
+		default:
+			return nil, fmt.Errorf("failed to parse %q", p)
+		}
+		packages = append(packages, &pulumirpc.DependencyInfo{
+			Name:    fields[0],
+			Version: fields[version],
+		})
+	}
+	return packages, nil
+}
+
 func (host *dotnetLanguageHost) InstallDependencies(
 	req *pulumirpc.InstallDependenciesRequest, server pulumirpc.LanguageRuntime_InstallDependenciesServer) error {
 
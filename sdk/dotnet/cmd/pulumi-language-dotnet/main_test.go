@@ -178,3 +178,27 @@ func TestDeterminePluginDependency(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDotnetPackageList(t *testing.T) {
+	t.Parallel()
+
+	out := "Project 'Example' has the following package references\r\n" +
+		"   [net6.0]: \r\n" +
+		"   Top-level Package      Requested   Resolved\r\n" +
+		"   > Pulumi               3.*         3.35.2  \r\n" +
+		"   > Pulumi.Aws           5.*         5.10.0  \r\n" +
+		"\r\n" +
+		"   Transitive Package     Resolved\r\n" +
+		"   > Grpc.Net.Client      2.43.0\r\n"
+
+	packages, err := parseDotnetPackageList(out)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pulumirpc.DependencyInfo{
+		{Name: "Pulumi", Version: "3.35.2"},
+		{Name: "Pulumi.Aws", Version: "5.10.0"},
+		{Name: "Grpc.Net.Client", Version: "2.43.0"},
+	}, packages)
+
+	_, err = parseDotnetPackageList("   > Pulumi\n")
+	assert.Error(t, err)
+}
@@ -66,6 +66,20 @@ func Target(urns []string) Option {
 	})
 }
 
+// Exclude specifies a list of resource URNs to leave unchanged
+func Exclude(urns []string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.Exclude = urns
+	})
+}
+
+// ExcludeDependents leaves the resources that depend on the resources in the Exclude list unchanged as well
+func ExcludeDependents() Option {
+	return optionFunc(func(opts *Options) {
+		opts.ExcludeDependents = true
+	})
+}
+
 // TargetDependents allows updating of dependent targets discovered but not specified in the Target list
 func TargetDependents() Option {
 	return optionFunc(func(opts *Options) {
@@ -130,6 +144,10 @@ type Options struct {
 	Replace []string
 	// Specify an exclusive list of resource URNs to update
 	Target []string
+	// Specify a list of resource URNs to leave unchanged
+	Exclude []string
+	// Leave the resources that depend on the resources in the Exclude list unchanged as well
+	ExcludeDependents bool
 	// Allows updating of dependent targets discovered but not specified in the Target list
 	TargetDependents bool
 	// DebugLogOpts specifies additional settings for debug logging
@@ -52,6 +52,20 @@ func Target(urns []string) Option {
 	})
 }
 
+// Exclude specifies a list of resource URNs to leave unchanged
+func Exclude(urns []string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.Exclude = urns
+	})
+}
+
+// ExcludeDependents leaves the resources that depend on the resources in the Exclude list unchanged as well
+func ExcludeDependents() Option {
+	return optionFunc(func(opts *Options) {
+		opts.ExcludeDependents = true
+	})
+}
+
 // ProgressStreams allows specifying one or more io.Writers to redirect incremental refresh output
 func ProgressStreams(writers ...io.Writer) Option {
 	return optionFunc(func(opts *Options) {
@@ -105,6 +119,10 @@ type Options struct {
 	ExpectNoChanges bool
 	// Specify an exclusive list of resource URNs to re
 	Target []string
+	// Specify a list of resource URNs to leave unchanged
+	Exclude []string
+	// Leave the resources that depend on the resources in the Exclude list unchanged as well
+	ExcludeDependents bool
 	// ProgressStreams allows specifying one or more io.Writers to redirect incremental refresh output
 	ProgressStreams []io.Writer
 	// EventStreams allows specifying one or more channels to receive the Pulumi event stream
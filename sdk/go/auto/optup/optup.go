@@ -66,6 +66,20 @@ func Target(urns []string) Option {
 	})
 }
 
+// Exclude specifies a list of resource URNs to leave unchanged
+func Exclude(urns []string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.Exclude = urns
+	})
+}
+
+// ExcludeDependents leaves the resources that depend on the resources in the Exclude list unchanged as well
+func ExcludeDependents() Option {
+	return optionFunc(func(opts *Options) {
+		opts.ExcludeDependents = true
+	})
+}
+
 // TargetDependents allows updating of dependent targets discovered but not specified in the Target list
 func TargetDependents() Option {
 	return optionFunc(func(opts *Options) {
@@ -73,6 +87,14 @@ func TargetDependents() Option {
 	})
 }
 
+// ContinueOnError continues updating resources that are independent of a failed resource, reporting all failures at
+// the end of the operation
+func ContinueOnError() Option {
+	return optionFunc(func(opts *Options) {
+		opts.ContinueOnError = true
+	})
+}
+
 // ProgressStreams allows specifying one or more io.Writers to redirect incremental update output
 func ProgressStreams(writers ...io.Writer) Option {
 	return optionFunc(func(opts *Options) {
@@ -137,8 +159,14 @@ type Options struct {
 	Replace []string
 	// Specify an exclusive list of resource URNs to update
 	Target []string
+	// Specify a list of resource URNs to leave unchanged
+	Exclude []string
+	// Leave the resources that depend on the resources in the Exclude list unchanged as well
+	ExcludeDependents bool
 	// Allows updating of dependent targets discovered but not specified in the Target list
 	TargetDependents bool
+	// Continue updating resources that are independent of a failed resource
+	ContinueOnError bool
 	// DebugLogOpts specifies additional settings for debug logging
 	DebugLogOpts debug.LoggingOptions
 	// ProgressStreams allows specifying one or more io.Writers to redirect incremental update output
@@ -103,6 +103,8 @@ import (
 	"github.com/nxadm/tail"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/debug"
 	"github.com/pulumi/pulumi/sdk/v3/go/auto/events"
@@ -233,6 +235,12 @@ func (s *Stack) Preview(ctx context.Context, opts ...optpreview.Option) (Preview
 	for _, tURN := range preOpts.Target {
 		sharedArgs = append(sharedArgs, fmt.Sprintf("--target=%s", tURN))
 	}
+	for _, eURN := range preOpts.Exclude {
+		sharedArgs = append(sharedArgs, fmt.Sprintf("--exclude=%s", eURN))
+	}
+	if preOpts.ExcludeDependents {
+		sharedArgs = append(sharedArgs, "--exclude-dependents")
+	}
 	for _, pack := range preOpts.PolicyPacks {
 		sharedArgs = append(sharedArgs, fmt.Sprintf("--policy-pack=%s", pack))
 	}
@@ -346,6 +354,12 @@ func (s *Stack) Up(ctx context.Context, opts ...optup.Option) (UpResult, error)
 	for _, tURN := range upOpts.Target {
 		sharedArgs = append(sharedArgs, fmt.Sprintf("--target=%s", tURN))
 	}
+	for _, eURN := range upOpts.Exclude {
+		sharedArgs = append(sharedArgs, fmt.Sprintf("--exclude=%s", eURN))
+	}
+	if upOpts.ExcludeDependents {
+		sharedArgs = append(sharedArgs, "--exclude-dependents")
+	}
 	for _, pack := range upOpts.PolicyPacks {
 		sharedArgs = append(sharedArgs, fmt.Sprintf("--policy-pack=%s", pack))
 	}
@@ -355,6 +369,9 @@ func (s *Stack) Up(ctx context.Context, opts ...optup.Option) (UpResult, error)
 	if upOpts.TargetDependents {
 		sharedArgs = append(sharedArgs, "--target-dependents")
 	}
+	if upOpts.ContinueOnError {
+		sharedArgs = append(sharedArgs, "--continue-on-error")
+	}
 	if upOpts.Parallel > 0 {
 		sharedArgs = append(sharedArgs, fmt.Sprintf("--parallel=%d", upOpts.Parallel))
 	}
@@ -446,6 +463,12 @@ func (s *Stack) Refresh(ctx context.Context, opts ...optrefresh.Option) (Refresh
 	for _, tURN := range refreshOpts.Target {
 		args = append(args, fmt.Sprintf("--target=%s", tURN))
 	}
+	for _, eURN := range refreshOpts.Exclude {
+		args = append(args, fmt.Sprintf("--exclude=%s", eURN))
+	}
+	if refreshOpts.ExcludeDependents {
+		args = append(args, "--exclude-dependents")
+	}
 	if refreshOpts.Parallel > 0 {
 		args = append(args, fmt.Sprintf("--parallel=%d", refreshOpts.Parallel))
 	}
@@ -518,9 +541,18 @@ func (s *Stack) Destroy(ctx context.Context, opts ...optdestroy.Option) (Destroy
 	for _, tURN := range destroyOpts.Target {
 		args = append(args, fmt.Sprintf("--target=%s", tURN))
 	}
+	for _, eURN := range destroyOpts.Exclude {
+		args = append(args, fmt.Sprintf("--exclude=%s", eURN))
+	}
+	if destroyOpts.ExcludeDependents {
+		args = append(args, "--exclude-dependents")
+	}
 	if destroyOpts.TargetDependents {
 		args = append(args, "--target-dependents")
 	}
+	if destroyOpts.ContinueOnError {
+		args = append(args, "--continue-on-error")
+	}
 	if destroyOpts.Parallel > 0 {
 		args = append(args, fmt.Sprintf("--parallel=%d", destroyOpts.Parallel))
 	}
@@ -1020,6 +1052,21 @@ func (s *languageRuntimeServer) GetPluginInfo(ctx context.Context, req *pbempty.
 	}, nil
 }
 
+func (s *languageRuntimeServer) GenerateProgram(ctx context.Context,
+	req *pulumirpc.GenerateProgramRequest) (*pulumirpc.GenerateProgramResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateProgram not implemented")
+}
+
+func (s *languageRuntimeServer) GenerateProject(ctx context.Context,
+	req *pulumirpc.GenerateProjectRequest) (*pulumirpc.GenerateProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateProject not implemented")
+}
+
+func (s *languageRuntimeServer) GetProgramDependencies(ctx context.Context,
+	req *pulumirpc.GetProgramDependenciesRequest) (*pulumirpc.GetProgramDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProgramDependencies not implemented")
+}
+
 func (s *languageRuntimeServer) InstallDependencies(
 	req *pulumirpc.InstallDependenciesRequest,
 	server pulumirpc.LanguageRuntime_InstallDependenciesServer) error {
@@ -328,6 +328,53 @@ type ResourceV3 struct {
 	SequenceNumber int `json:"sequenceNumber,omitempty" yaml:"sequenceNumber,omitempty"`
 	// If set to True, the providers Delete method will not be called for this resource. Pulumi simply stops tracking the deleted resource.
 	RetainOnDelete bool `json:"retainOnDelete,omitempty" yaml:"retainOnDelete,omitempty"`
+	// If set, the providers Delete method will not be called for this resource if specified resource is being deleted as well.
+	DeletedWith resource.URN `json:"deletedWith,omitempty" yaml:"deletedWith,omitempty"`
+	// Created is the time when the resource was created or imported, if known.
+	Created *time.Time `json:"created,omitempty" yaml:"created,omitempty"`
+	// Modified is the time when the resource's state was last changed, if known.
+	Modified *time.Time `json:"modified,omitempty" yaml:"modified,omitempty"`
+	// RetryPolicy controls how failed create, update, and delete operations for this resource are retried, if set.
+	RetryPolicy *resource.RetryPolicy `json:"retryPolicy,omitempty" yaml:"retryPolicy,omitempty"`
+}
+
+// JournalEntryKind is the kind of an entry in a checkpoint journal.
+type JournalEntryKind string
+
+const (
+	// JournalEntryBegin records that a step has started.
+	JournalEntryBegin JournalEntryKind = "begin"
+	// JournalEntrySuccess records that a step has completed successfully.
+	JournalEntrySuccess JournalEntryKind = "success"
+	// JournalEntryFailure records that a step has failed.
+	JournalEntryFailure JournalEntryKind = "failure"
+	// JournalEntryOutputs records that the outputs of a resource have been registered.
+	JournalEntryOutputs JournalEntryKind = "outputs"
+)
+
+// JournalEntryV1 is a single entry in a checkpoint journal. A journal records the steps applied to a base deployment
+// during an update, so that the state of the stack can be persisted incrementally and recovered by replaying the
+// journal's entries over the base deployment.
+type JournalEntryV1 struct {
+	// Sequence is the position of this entry in the journal.
+	Sequence int64 `json:"sequence" yaml:"sequence"`
+	// Kind is the kind of this entry.
+	Kind JournalEntryKind `json:"kind" yaml:"kind"`
+	// Op is the operation performed by the step.
+	Op string `json:"op" yaml:"op"`
+	// Old is the state of the resource before the step, if any.
+	Old *JournalResourceV1 `json:"old,omitempty" yaml:"old,omitempty"`
+	// New is the state of the resource after the step, if any.
+	New *JournalResourceV1 `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// JournalResourceV1 is a resource state referenced by a journal entry.
+type JournalResourceV1 struct {
+	// ID identifies the resource state within the journal. IDs less than the number of resources in the base
+	// deployment refer to the resource at that index; greater IDs refer to states produced during the update.
+	ID int `json:"id" yaml:"id"`
+	// State is the resource state at the time the entry was recorded.
+	State ResourceV3 `json:"state" yaml:"state"`
 }
 
 // ManifestV1 captures meta-information about this checkpoint file, such as versions of binaries, etc.
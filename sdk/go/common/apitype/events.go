@@ -80,6 +80,8 @@ type SummaryEvent struct {
 	// compatibility. For older clients this will map to the version, while for newer ones
 	// it will be the version tag prepended with "v".
 	PolicyPacks map[string]string `json:"PolicyPacks"`
+	// FailedResources are the URNs of the resources whose operations failed, if any.
+	FailedResources []string `json:"failedResources,omitempty"`
 }
 
 // DiffKind describes the kind of a particular property diff.
@@ -132,6 +134,8 @@ type StepEventMetadata struct {
 	Logical bool `json:"logical,omitempty"`
 	// Provider actually performing the step.
 	Provider string `json:"provider"`
+	// SourcePosition is the position in the program that registered the resource, if known.
+	SourcePosition string `json:"sourcePosition,omitempty"`
 }
 
 // StepEventStateMetadata is the more detailed state information for a resource as it relates to
@@ -181,6 +185,16 @@ type ResOpFailedEvent struct {
 	Steps    int               `json:"steps"`
 }
 
+// ResRetryEvent is emitted when a failed resource operation is about to be retried according to the
+// resource's retry policy.
+type ResRetryEvent struct {
+	Metadata     StepEventMetadata `json:"metadata"`
+	Attempt      int               `json:"attempt"`
+	MaxAttempts  int               `json:"maxAttempts"`
+	DelaySeconds int               `json:"delaySeconds"`
+	Error        string            `json:"error"`
+}
+
 // EngineEvent describes a Pulumi engine event, such as a change to a resource or diagnostic
 // message. EngineEvent is a discriminated union of all possible event types, and exactly one
 // field will be non-nil.
@@ -205,6 +219,7 @@ type EngineEvent struct {
 	ResourcePreEvent *ResourcePreEvent  `json:"resourcePreEvent,omitempty"`
 	ResOutputsEvent  *ResOutputsEvent   `json:"resOutputsEvent,omitempty"`
 	ResOpFailedEvent *ResOpFailedEvent  `json:"resOpFailedEvent,omitempty"`
+	ResRetryEvent    *ResRetryEvent     `json:"resRetryEvent,omitempty"`
 	PolicyEvent      *PolicyEvent       `json:"policyEvent,omitempty"`
 }
 
@@ -84,3 +84,8 @@ Either include resource in --target list or pass --target-dependents to proceed.
 func GetDefaultProviderDenied(urn resource.URN) *Diag {
 	return newError(urn, 2015, `Default provider for '%v' disabled. '%v' must use an explicit provider.`)
 }
+
+func GetResourceWillBeCreatedButWasExcluded(urn resource.URN) *Diag {
+	return newError(urn, 2016, `Resource '%v' depends on '%v' which was excluded with --exclude.
+Either stop excluding that resource or pass --exclude-dependents to exclude this resource as well.`)
+}
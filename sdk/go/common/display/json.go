@@ -82,3 +82,40 @@ type PreviewDiagnostic struct {
 	Message  string        `json:"message,omitempty"`
 	Severity diag.Severity `json:"severity,omitempty"`
 }
+
+// DriftReport is a JSON-serializable report of the differences between the recorded state of a stack's resources
+// and their live state, as read from their providers.
+type DriftReport struct {
+	// Resources contains the drift detected for each resource whose live state differs from its recorded state.
+	Resources []ResourceDrift `json:"resources"`
+	// Checked is the number of resources whose live state was read.
+	Checked int `json:"checked"`
+	// Diagnostics contains a record of all warnings/errors that took place while reading the live state.
+	Diagnostics []PreviewDiagnostic `json:"diagnostics,omitempty"`
+}
+
+// ResourceDrift describes how the live state of a single resource differs from its recorded state.
+type ResourceDrift struct {
+	// URN is the resource that has drifted.
+	URN resource.URN `json:"urn"`
+	// Type is the type of the resource that has drifted.
+	Type string `json:"type"`
+	// ID is the provider ID of the resource that has drifted.
+	ID string `json:"id,omitempty"`
+	// Deleted is true if the resource no longer exists.
+	Deleted bool `json:"deleted,omitempty"`
+	// Properties contains the output properties that differ from their recorded values.
+	Properties []PropertyDrift `json:"properties,omitempty"`
+}
+
+// PropertyDrift describes how the live value of a single output property differs from its recorded value.
+type PropertyDrift struct {
+	// Key is the name of the property.
+	Key resource.PropertyKey `json:"key"`
+	// Kind is the kind of difference: "add", "delete", or "update".
+	Kind string `json:"kind"`
+	// Recorded is the value recorded in the stack's state, if any. Secrets are blinded.
+	Recorded interface{} `json:"recorded,omitempty"`
+	// Live is the live value read from the provider, if any. Secrets are blinded.
+	Live interface{} `json:"live,omitempty"`
+}
@@ -41,6 +41,23 @@ type LanguageRuntime interface {
 
 	// InstallDependencies will install dependencies for the project, e.g. by running `npm install` for nodejs projects.
 	InstallDependencies(directory string) error
+
+	// GenerateProgram generates a program in this language from the given PCL source files, keyed by file name. It
+	// returns the generated source files, keyed by file name.
+	GenerateProgram(program map[string]string) (map[string][]byte, error)
+	// GenerateProject generates a project in this language from the given PCL source files, keyed by file name, and
+	// writes it to the given directory.
+	GenerateProject(directory string, project *workspace.Project, program map[string]string) error
+
+	// GetProgramDependencies returns the packages, with their versions, that a program depends on. If
+	// transitiveDependencies is true, the packages that those packages depend on are included as well.
+	GetProgramDependencies(info ProgInfo, transitiveDependencies bool) ([]DependencyInfo, error)
+}
+
+// DependencyInfo describes a package that a program depends on.
+type DependencyInfo struct {
+	Name    string // the name of the package.
+	Version string // the version of the package that the program uses.
 }
 
 // ProgInfo contains minimal information about the program to be run.
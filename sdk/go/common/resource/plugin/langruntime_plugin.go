@@ -15,6 +15,7 @@
 package plugin
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -284,3 +285,85 @@ func (h *langhost) InstallDependencies(directory string) error {
 	return nil
 
 }
+
+// GenerateProgram generates a program in this language from the given PCL source files, keyed by file name.
+func (h *langhost) GenerateProgram(program map[string]string) (map[string][]byte, error) {
+	logging.V(7).Infof("langhost[%v].GenerateProgram(#files=%d) executing", h.runtime, len(program))
+	resp, err := h.client.GenerateProgram(h.ctx.Request(), &pulumirpc.GenerateProgramRequest{
+		Source: program,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("langhost[%v].GenerateProgram(#files=%d) failed: err=%v", h.runtime, len(program), rpcError)
+		return nil, rpcError
+	}
+
+	logging.V(7).Infof("langhost[%v].GenerateProgram(#files=%d) success: #files=%d",
+		h.runtime, len(program), len(resp.GetSource()))
+	return resp.GetSource(), nil
+}
+
+// GenerateProject generates a project in this language from the given PCL source files, keyed by file name, and
+// writes it to the given directory.
+func (h *langhost) GenerateProject(directory string, project *workspace.Project, program map[string]string) error {
+	logging.V(7).Infof("langhost[%v].GenerateProject(directory=%s,#files=%d) executing",
+		h.runtime, directory, len(program))
+	projectJSON, err := json.Marshal(project)
+	if err != nil {
+		return fmt.Errorf("marshaling project: %w", err)
+	}
+
+	_, err = h.client.GenerateProject(h.ctx.Request(), &pulumirpc.GenerateProjectRequest{
+		Directory: directory,
+		Project:   string(projectJSON),
+		Source:    program,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("langhost[%v].GenerateProject(directory=%s,#files=%d) failed: err=%v",
+			h.runtime, directory, len(program), rpcError)
+		return rpcError
+	}
+
+	logging.V(7).Infof("langhost[%v].GenerateProject(directory=%s,#files=%d) success",
+		h.runtime, directory, len(program))
+	return nil
+}
+
+// GetProgramDependencies returns the packages, with their versions, that a program depends on.
+func (h *langhost) GetProgramDependencies(info ProgInfo, transitiveDependencies bool) ([]DependencyInfo, error) {
+	proj := string(info.Proj.Name)
+	logging.V(7).Infof("langhost[%v].GetProgramDependencies(proj=%s,pwd=%s,program=%s,transitive=%v) executing",
+		h.runtime, proj, info.Pwd, info.Program, transitiveDependencies)
+	resp, err := h.client.GetProgramDependencies(h.ctx.Request(), &pulumirpc.GetProgramDependenciesRequest{
+		Project:                proj,
+		Pwd:                    info.Pwd,
+		Program:                info.Program,
+		TransitiveDependencies: transitiveDependencies,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("langhost[%v].GetProgramDependencies(proj=%s,pwd=%s,program=%s,transitive=%v) failed: err=%v",
+			h.runtime, proj, info.Pwd, info.Program, transitiveDependencies, rpcError)
+
+		// Older language hosts, and hosts for languages that do not track their dependencies, do not implement this
+		// method. In such cases, we report no dependencies (with the above log left behind).
+		if rpcError.Code() == codes.Unimplemented {
+			return nil, nil
+		}
+
+		return nil, rpcError
+	}
+
+	results := make([]DependencyInfo, len(resp.GetDependencies()))
+	for i, dep := range resp.GetDependencies() {
+		results[i] = DependencyInfo{
+			Name:    dep.GetName(),
+			Version: dep.GetVersion(),
+		}
+	}
+
+	logging.V(7).Infof("langhost[%v].GetProgramDependencies(proj=%s,pwd=%s,program=%s,transitive=%v) success: #deps=%d",
+		h.runtime, proj, info.Pwd, info.Program, transitiveDependencies, len(results))
+	return results, nil
+}
@@ -101,6 +101,15 @@ type Provider interface {
 	// non-blocking; it is up to the host to decide how long to wait after SignalCancellation is
 	// called before (e.g.) hard-closing any gRPC connection.
 	SignalCancellation() error
+
+	// GetMapping returns the mapping (if any) for the provider from another ecosystem, such as Terraform, along with
+	// that ecosystem's name for the provider. The format of the mapping data is specific to the given key. A provider
+	// without a mapping for the key returns no data and no error.
+	GetMapping(key string) ([]byte, string, error)
+
+	// List returns the live resources of the given type that the provider can see, narrowed by the given
+	// provider-specific filter, so that they can be imported. The resources need not be managed by Pulumi.
+	List(t tokens.Type, filter map[string]string) ([]ListedResource, error)
 }
 
 type GrpcProvider interface {
@@ -318,6 +327,14 @@ func (e DiffUnavailableError) Error() string {
 	return e.reason
 }
 
+// ListedResource is a live resource returned by a call to List.
+type ListedResource struct {
+	// ID is the provider-assigned ID of the resource.
+	ID resource.ID
+	// Name is a suggested name for the resource, if the provider has one; it may be empty.
+	Name string
+}
+
 // ReadResult is the result of a call to Read.
 type ReadResult struct {
 	// This is the ID for the resource. This ID will always be populated and will ensure we get the most up-to-date
@@ -1504,6 +1504,55 @@ func (p *provider) GetPluginInfo() (workspace.PluginInfo, error) {
 	}, nil
 }
 
+// GetMapping returns the mapping (if any) for the provider from another ecosystem, such as Terraform.
+func (p *provider) GetMapping(key string) ([]byte, string, error) {
+	label := fmt.Sprintf("%s.GetMapping(%s)", p.label(), key)
+	logging.V(7).Infof("%s executing", label)
+
+	// Like GetSchema, GetMapping does not require the provider to be configured.
+	resp, err := p.clientRaw.GetMapping(p.requestContext(), &pulumirpc.GetMappingRequest{Key: key})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		// Providers that predate GetMapping have no mappings.
+		if rpcError.Code() == codes.Unimplemented {
+			logging.V(7).Infof("%s unimplemented", label)
+			return nil, "", nil
+		}
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError.Message())
+		return nil, "", rpcError
+	}
+
+	logging.V(7).Infof("%s success: provider=%s, #data=%d", label, resp.GetProvider(), len(resp.GetData()))
+	return resp.GetData(), resp.GetProvider(), nil
+}
+
+// List enumerates the live resources of the given type that the provider can see.
+func (p *provider) List(t tokens.Type, filter map[string]string) ([]ListedResource, error) {
+	label := fmt.Sprintf("%s.List(%s)", p.label(), t)
+	logging.V(7).Infof("%s executing (#filter=%d)", label, len(filter))
+
+	// Get the RPC client and ensure it's configured.
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.List(p.requestContext(), &pulumirpc.ListRequest{Type: string(t), Filter: filter})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError.Message())
+		return nil, rpcError
+	}
+
+	var results []ListedResource
+	for _, r := range resp.GetResources() {
+		results = append(results, ListedResource{ID: resource.ID(r.GetId()), Name: r.GetName()})
+	}
+
+	logging.V(7).Infof("%s success: #resources=%d", label, len(results))
+	return results, nil
+}
+
 // Attach attaches this plugin to the engine
 func (p *provider) Attach(address string) error {
 	label := fmt.Sprintf("%s.Attach()", p.label())
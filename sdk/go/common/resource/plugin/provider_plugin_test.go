@@ -1,12 +1,18 @@
 package plugin
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 )
 
 func TestAnnotateSecrets(t *testing.T) {
@@ -225,3 +231,91 @@ func TestNestedSecret(t *testing.T) {
 
 	assert.Truef(t, reflect.DeepEqual(to, expected), "did not match expected after annotation")
 }
+
+type mappingProviderClient struct {
+	pulumirpc.ResourceProviderClient
+
+	resp *pulumirpc.GetMappingResponse
+	err  error
+}
+
+func (c *mappingProviderClient) GetMapping(ctx context.Context, req *pulumirpc.GetMappingRequest,
+	opts ...grpc.CallOption) (*pulumirpc.GetMappingResponse, error) {
+	return c.resp, c.err
+}
+
+func TestGetMapping(t *testing.T) {
+	t.Parallel()
+
+	p := NewProviderWithClient(nil, "aws", &mappingProviderClient{
+		resp: &pulumirpc.GetMappingResponse{Provider: "aws", Data: []byte("{}")},
+	}, false)
+	data, provider, err := p.GetMapping("terraform")
+	assert.NoError(t, err)
+	assert.Equal(t, "aws", provider)
+	assert.Equal(t, []byte("{}"), data)
+
+	// Providers that do not implement GetMapping have no mappings.
+	p = NewProviderWithClient(nil, "aws", &mappingProviderClient{
+		err: status.Error(codes.Unimplemented, "GetMapping is not yet implemented"),
+	}, false)
+	data, provider, err = p.GetMapping("terraform")
+	assert.NoError(t, err)
+	assert.Equal(t, "", provider)
+	assert.Nil(t, data)
+
+	p = NewProviderWithClient(nil, "aws", &mappingProviderClient{
+		err: status.Error(codes.Internal, "boom"),
+	}, false)
+	_, _, err = p.GetMapping("terraform")
+	assert.EqualError(t, err, "boom")
+}
+
+type listProviderClient struct {
+	pulumirpc.ResourceProviderClient
+
+	req  *pulumirpc.ListRequest
+	resp *pulumirpc.ListResponse
+	err  error
+}
+
+func (c *listProviderClient) Configure(ctx context.Context, req *pulumirpc.ConfigureRequest,
+	opts ...grpc.CallOption) (*pulumirpc.ConfigureResponse, error) {
+	return &pulumirpc.ConfigureResponse{}, nil
+}
+
+func (c *listProviderClient) List(ctx context.Context, req *pulumirpc.ListRequest,
+	opts ...grpc.CallOption) (*pulumirpc.ListResponse, error) {
+	c.req = req
+	return c.resp, c.err
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	client := &listProviderClient{
+		resp: &pulumirpc.ListResponse{Resources: []*pulumirpc.ListedResource{
+			{Id: "i-1234", Name: "web"},
+			{Id: "i-5678"},
+		}},
+	}
+	p := NewProviderWithClient(nil, "aws", client, false)
+	assert.NoError(t, p.Configure(resource.PropertyMap{}))
+
+	resources, err := p.List("aws:ec2/instance:Instance", map[string]string{"region": "us-west-2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "aws:ec2/instance:Instance", client.req.GetType())
+	assert.Equal(t, map[string]string{"region": "us-west-2"}, client.req.GetFilter())
+	assert.Equal(t, []ListedResource{
+		{ID: "i-1234", Name: "web"},
+		{ID: "i-5678"},
+	}, resources)
+
+	// Unlike GetMapping, List reports providers that do not implement it as errors.
+	p = NewProviderWithClient(nil, "aws", &listProviderClient{
+		err: status.Error(codes.Unimplemented, "List is not yet implemented"),
+	}, false)
+	assert.NoError(t, p.Configure(resource.PropertyMap{}))
+	_, err = p.List("aws:ec2/instance:Instance", nil)
+	assert.Equal(t, codes.Unimplemented, rpcerror.Convert(err).Code())
+}
@@ -143,6 +143,29 @@ func (p *providerServer) GetPluginInfo(ctx context.Context, req *pbempty.Empty)
 	return &pulumirpc.PluginInfo{Version: info.Version.String()}, nil
 }
 
+func (p *providerServer) GetMapping(ctx context.Context,
+	req *pulumirpc.GetMappingRequest) (*pulumirpc.GetMappingResponse, error) {
+
+	data, provider, err := p.provider.GetMapping(req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.GetMappingResponse{Provider: provider, Data: data}, nil
+}
+
+func (p *providerServer) List(ctx context.Context, req *pulumirpc.ListRequest) (*pulumirpc.ListResponse, error) {
+	resources, err := p.provider.List(tokens.Type(req.GetType()), req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make([]*pulumirpc.ListedResource, len(resources))
+	for i, r := range resources {
+		listed[i] = &pulumirpc.ListedResource{Id: string(r.ID), Name: r.Name}
+	}
+	return &pulumirpc.ListResponse{Resources: listed}, nil
+}
+
 func (p *providerServer) Attach(ctx context.Context, req *pulumirpc.PluginAttach) (*pbempty.Empty, error) {
 	// NewProviderServer should take a GrpcProvider instead of Provider, but that's a breaking change
 	// so for now we type test here
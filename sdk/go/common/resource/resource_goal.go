@@ -40,6 +40,13 @@ type Goal struct {
 	ReplaceOnChanges        []string              // a list of property paths that if changed should force a replacement.
 	// if set to True, the providers Delete method will not be called for this resource.
 	RetainOnDelete bool
+	// if set, the providers Delete method will not be called for this resource
+	// if specified resource is being deleted as well.
+	DeletedWith URN
+	// if set, the position in the program's source code that registered this resource, as "file:line[:column]".
+	SourcePosition string
+	// if set, how failed create, update, and delete operations for this resource are retried.
+	RetryPolicy *RetryPolicy
 }
 
 // NewGoal allocates a new resource goal state.
@@ -47,7 +54,8 @@ func NewGoal(t tokens.Type, name tokens.QName, custom bool, props PropertyMap,
 	parent URN, protect bool, dependencies []URN, provider string, initErrors []string,
 	propertyDependencies map[PropertyKey][]URN, deleteBeforeReplace *bool, ignoreChanges []string,
 	additionalSecretOutputs []PropertyKey, aliases []URN, id ID, customTimeouts *CustomTimeouts,
-	replaceOnChanges []string, retainOnDelete bool) *Goal {
+	replaceOnChanges []string, retainOnDelete bool, deletedWith URN, sourcePosition string,
+	retryPolicy *RetryPolicy) *Goal {
 
 	g := &Goal{
 		Type:                    t,
@@ -67,6 +75,9 @@ func NewGoal(t tokens.Type, name tokens.QName, custom bool, props PropertyMap,
 		ID:                      id,
 		ReplaceOnChanges:        replaceOnChanges,
 		RetainOnDelete:          retainOnDelete,
+		DeletedWith:             deletedWith,
+		SourcePosition:          sourcePosition,
+		RetryPolicy:             retryPolicy,
 	}
 
 	if customTimeouts != nil {
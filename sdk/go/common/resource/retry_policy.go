@@ -0,0 +1,120 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// maxRetryDelay bounds the delay between retries of a failed resource operation.
+const maxRetryDelay = 5 * time.Minute
+
+// RetryPolicy describes how the engine retries create, update, and delete operations that fail with transient
+// provider errors.
+type RetryPolicy struct {
+	// Attempts is the number of times a failed operation is retried.
+	Attempts int `json:"attempts,omitempty" yaml:"attempts,omitempty"`
+	// Backoff is the delay before the first retry, e.g. "5s". The delay doubles after each retry.
+	Backoff string `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// ErrorPatterns is a list of regular expressions matched against error messages. If set, only errors that match
+	// one of the patterns are retried.
+	ErrorPatterns []string `json:"errorPatterns,omitempty" yaml:"errorPatterns,omitempty"`
+	// RetryIfUnknown retries operations that fail without the provider saying whether the resource was changed, as
+	// happens when a provider returns an unexpected error. Such failures are otherwise only retried if they match
+	// one of the error patterns.
+	RetryIfUnknown bool `json:"retryIfUnknown,omitempty" yaml:"retryIfUnknown,omitempty"`
+}
+
+// Validate returns an error if the policy's backoff or error patterns are malformed.
+func (p *RetryPolicy) Validate() error {
+	_, err := p.Compile()
+	return err
+}
+
+// Compile validates the policy and compiles its error patterns, ready to decide which failures to retry.
+func (p *RetryPolicy) Compile() (*CompiledRetryPolicy, error) {
+	if p.Attempts < 0 {
+		return nil, fmt.Errorf("retry attempts must not be negative")
+	}
+	if p.Backoff != "" {
+		if d, err := time.ParseDuration(p.Backoff); err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid retry backoff '%s'", p.Backoff)
+		}
+	}
+	patterns := make([]*regexp.Regexp, len(p.ErrorPatterns))
+	for i, pattern := range p.ErrorPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry error pattern '%s': %w", pattern, err)
+		}
+		patterns[i] = re
+	}
+	return &CompiledRetryPolicy{RetryPolicy: p, errorPatterns: patterns}, nil
+}
+
+// CompiledRetryPolicy is a RetryPolicy whose error patterns have been compiled.
+type CompiledRetryPolicy struct {
+	*RetryPolicy
+
+	errorPatterns []*regexp.Regexp
+}
+
+// ShouldRetry returns true if an operation that has already been retried the given number of times and then failed
+// with the given status and error should be retried again. Operations that may have partially succeeded are never
+// retried, and operations whose outcome is unknown are only retried if the policy says so or the error matches one
+// of its patterns.
+func (p *CompiledRetryPolicy) ShouldRetry(retries int, status Status, err error) bool {
+	if p == nil || err == nil || retries >= p.Attempts {
+		return false
+	}
+
+	switch status {
+	case StatusOK:
+		if len(p.errorPatterns) == 0 {
+			return true
+		}
+	case StatusUnknown:
+		if p.RetryIfUnknown {
+			return true
+		}
+	default:
+		return false
+	}
+
+	for _, re := range p.errorPatterns {
+		if re.MatchString(err.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Delay returns how long to wait before retrying an operation that has already been retried the given number of
+// times.
+func (p *RetryPolicy) Delay(retries int) time.Duration {
+	backoff, err := time.ParseDuration(p.Backoff)
+	if err != nil || backoff <= 0 {
+		return 0
+	}
+	for i := 0; i < retries && backoff < maxRetryDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return backoff
+}
@@ -0,0 +1,78 @@
+// Copyright 2016-2022, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, (&RetryPolicy{Attempts: 3, Backoff: "5s", ErrorPatterns: []string{"timeout"}}).Validate())
+	assert.Error(t, (&RetryPolicy{Attempts: -1}).Validate())
+	assert.Error(t, (&RetryPolicy{Backoff: "soon"}).Validate())
+	assert.Error(t, (&RetryPolicy{ErrorPatterns: []string{"("}}).Validate())
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	t.Parallel()
+
+	compile := func(p *RetryPolicy) *CompiledRetryPolicy {
+		compiled, err := p.Compile()
+		require.NoError(t, err)
+		return compiled
+	}
+
+	var none *CompiledRetryPolicy
+	assert.False(t, none.ShouldRetry(0, StatusOK, errors.New("boom")))
+
+	always := compile(&RetryPolicy{Attempts: 2})
+	assert.True(t, always.ShouldRetry(0, StatusOK, errors.New("boom")))
+	assert.True(t, always.ShouldRetry(1, StatusOK, errors.New("boom")))
+	assert.False(t, always.ShouldRetry(2, StatusOK, errors.New("boom")))
+	assert.False(t, always.ShouldRetry(0, StatusOK, nil))
+	assert.False(t, always.ShouldRetry(0, StatusUnknown, errors.New("boom")))
+	assert.False(t, always.ShouldRetry(0, StatusPartialFailure, errors.New("boom")))
+
+	matching := compile(&RetryPolicy{Attempts: 1, ErrorPatterns: []string{"(?i)connection reset", "^timeout"}})
+	assert.True(t, matching.ShouldRetry(0, StatusOK, errors.New("read: Connection reset by peer")))
+	assert.True(t, matching.ShouldRetry(0, StatusOK, errors.New("timeout waiting for instance")))
+	assert.False(t, matching.ShouldRetry(0, StatusOK, errors.New("bucket name already taken")))
+	assert.True(t, matching.ShouldRetry(0, StatusUnknown, errors.New("read: connection reset by peer")))
+	assert.False(t, matching.ShouldRetry(0, StatusUnknown, errors.New("bucket name already taken")))
+	assert.False(t, matching.ShouldRetry(0, StatusPartialFailure, errors.New("timeout waiting for instance")))
+
+	unknown := compile(&RetryPolicy{Attempts: 1, RetryIfUnknown: true})
+	assert.True(t, unknown.ShouldRetry(0, StatusUnknown, errors.New("boom")))
+	assert.False(t, unknown.ShouldRetry(0, StatusPartialFailure, errors.New("boom")))
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Duration(0), (&RetryPolicy{Attempts: 1}).Delay(0))
+
+	p := &RetryPolicy{Attempts: 10, Backoff: "2s"}
+	assert.Equal(t, 2*time.Second, p.Delay(0))
+	assert.Equal(t, 4*time.Second, p.Delay(1))
+	assert.Equal(t, 16*time.Second, p.Delay(3))
+	assert.Equal(t, 5*time.Minute, p.Delay(9))
+}
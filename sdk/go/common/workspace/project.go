@@ -19,9 +19,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/encoding"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
@@ -61,6 +63,39 @@ type ProjectBackend struct {
 type ProjectOptions struct {
 	// Refresh is the ability to always run a refresh as part of a pulumi update / preview / destroy
 	Refresh string `json:"refresh,omitempty" yaml:"refresh,omitempty"`
+	// Concurrency limits the number of resource operations that may run at once for specific provider packages
+	// (e.g. "aws") or resource types (e.g. "aws:s3/bucket:Bucket").
+	Concurrency map[string]int `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	// Retry is the policy for retrying failed resource operations, for resources that do not set their own.
+	Retry *resource.RetryPolicy `json:"retry,omitempty" yaml:"retry,omitempty"`
+	// Transformations are applied by the engine to every resource registration, regardless of the program's language.
+	Transformations []ProjectTransformation `json:"transformations,omitempty" yaml:"transformations,omitempty"`
+}
+
+// ProjectTransformation rewrites the goal state of resources registered by a project's program before the engine acts
+// on it. Transformations apply to custom resources, including the children of component resources, but not to
+// providers. Component resources, including the root stack resource, are only transformed if IncludeComponents is set.
+type ProjectTransformation struct {
+	// Types is a list of resource type patterns this transformation applies to. A "*" in a pattern matches any
+	// sequence of characters (e.g. "aws:*" or "*:s3/bucket:Bucket"). An empty list matches every resource type.
+	Types []string `json:"types,omitempty" yaml:"types,omitempty"`
+	// IncludeComponents applies this transformation to component resources of matching types as well.
+	IncludeComponents bool `json:"includeComponents,omitempty" yaml:"includeComponents,omitempty"`
+	// Properties are merged into the input properties of matching resources. Objects are merged recursively; any
+	// other value replaces the value set by the program, unless the program's value is unknown.
+	Properties map[string]interface{} `json:"properties,omitempty" yaml:"properties,omitempty"`
+	// NamePattern is an optional regular expression that the names of matching resources must match.
+	NamePattern string `json:"namePattern,omitempty" yaml:"namePattern,omitempty"`
+}
+
+// Validate checks that the transformation's name pattern, if any, is a valid regular expression.
+func (t ProjectTransformation) Validate() error {
+	if t.NamePattern != "" {
+		if _, err := regexp.Compile(t.NamePattern); err != nil {
+			return errors.Errorf("invalid name pattern '%s': %v", t.NamePattern, err)
+		}
+	}
+	return nil
 }
 
 // Project is a Pulumi project manifest.
@@ -111,6 +146,23 @@ func (proj *Project) Validate() error {
 	if proj.Runtime.Name() == "" {
 		return errors.New("project is missing a 'runtime' attribute")
 	}
+	if proj.Options != nil {
+		for key, limit := range proj.Options.Concurrency {
+			if limit < 1 {
+				return errors.Errorf("project option 'concurrency' for '%s' must be at least 1", key)
+			}
+		}
+		if retry := proj.Options.Retry; retry != nil {
+			if err := retry.Validate(); err != nil {
+				return errors.Wrap(err, "project option 'retry'")
+			}
+		}
+		for i, transformation := range proj.Options.Transformations {
+			if err := transformation.Validate(); err != nil {
+				return errors.Wrapf(err, "project option 'transformations[%d]'", i)
+			}
+		}
+	}
 
 	return nil
 }
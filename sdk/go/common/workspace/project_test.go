@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 )
 
 func TestProjectRuntimeInfoRoundtripYAML(t *testing.T) {
@@ -38,3 +40,55 @@ func TestProjectRuntimeInfoRoundtripYAML(t *testing.T) {
 	doTest(yaml.Marshal, yaml.Unmarshal)
 	doTest(json.Marshal, json.Unmarshal)
 }
+
+func TestProjectValidateConcurrency(t *testing.T) {
+	t.Parallel()
+
+	proj := &Project{
+		Name:    "test",
+		Runtime: NewProjectRuntimeInfo("nodejs", nil),
+		Options: &ProjectOptions{
+			Concurrency: map[string]int{"aws": 4, "azure-native:storage:StorageAccount": 1},
+		},
+	}
+	assert.NoError(t, proj.Validate())
+
+	proj.Options.Concurrency["gcp"] = 0
+	assert.EqualError(t, proj.Validate(), "project option 'concurrency' for 'gcp' must be at least 1")
+}
+
+func TestProjectValidateRetry(t *testing.T) {
+	t.Parallel()
+
+	proj := &Project{
+		Name:    "test",
+		Runtime: NewProjectRuntimeInfo("nodejs", nil),
+		Options: &ProjectOptions{
+			Retry: &resource.RetryPolicy{Attempts: 3, Backoff: "10s", ErrorPatterns: []string{"timeout"}},
+		},
+	}
+	assert.NoError(t, proj.Validate())
+
+	proj.Options.Retry.Backoff = "soon"
+	assert.EqualError(t, proj.Validate(), "project option 'retry': invalid retry backoff 'soon'")
+}
+
+func TestProjectValidateTransformations(t *testing.T) {
+	t.Parallel()
+
+	proj := &Project{
+		Name:    "test",
+		Runtime: NewProjectRuntimeInfo("nodejs", nil),
+		Options: &ProjectOptions{
+			Transformations: []ProjectTransformation{
+				{Types: []string{"aws:*"}, Properties: map[string]interface{}{"tags": map[string]interface{}{"team": "a"}}},
+				{NamePattern: "^[a-z-]+$"},
+			},
+		},
+	}
+	assert.NoError(t, proj.Validate())
+
+	proj.Options.Transformations[1].NamePattern = "[a-z"
+	assert.EqualError(t, proj.Validate(), "project option 'transformations[1]': invalid name pattern '[a-z': "+
+		"error parsing regexp: missing closing ]: `[a-z`")
+}
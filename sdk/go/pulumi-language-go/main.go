@@ -34,6 +34,8 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
+	codegen "github.com/pulumi/pulumi/pkg/v3/codegen/go"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/buildutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -42,6 +44,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/version"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 )
@@ -147,11 +150,13 @@ func newLanguageHost(engineAddress, tracing, binary string) pulumirpc.LanguageRu
 }
 
 // modInfo is the useful portion of the output from `go list -m -json all`
-// with respect to plugin acquisition
+// with respect to plugin acquisition and dependency reporting
 type modInfo struct {
-	Path    string
-	Version string
-	Dir     string
+	Path     string
+	Version  string
+	Dir      string
+	Indirect bool
+	Main     bool
 }
 
 // Returns the pulumi-plugin.json if found. If not found, then returns nil, nil.
@@ -434,6 +439,97 @@ func (host *goLanguageHost) GetPluginInfo(ctx context.Context, req *pbempty.Empt
 	}, nil
 }
 
+// GenerateProgram generates Go source code for the given PCL program. The program may refer to variables that it
+// does not declare, as the resource definitions generated by `pulumi import` do.
+func (host *goLanguageHost) GenerateProgram(ctx context.Context,
+	req *pulumirpc.GenerateProgramRequest) (*pulumirpc.GenerateProgramResponse, error) {
+
+	program, diags, err := pcl.BindProgramSource(req.GetSource(), pcl.AllowMissingVariables)
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	files, diags, err := codegen.GenerateProgram(program)
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return &pulumirpc.GenerateProgramResponse{Source: files}, nil
+}
+
+// GenerateProject generates a Go project for the given PCL program and writes it to the requested directory.
+func (host *goLanguageHost) GenerateProject(ctx context.Context,
+	req *pulumirpc.GenerateProjectRequest) (*pulumirpc.GenerateProjectResponse, error) {
+
+	var project workspace.Project
+	if err := json.Unmarshal([]byte(req.GetProject()), &project); err != nil {
+		return nil, fmt.Errorf("unmarshaling project: %w", err)
+	}
+
+	program, diags, err := pcl.BindProgramSource(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	if err = codegen.GenerateProject(req.GetDirectory(), project, program); err != nil {
+		return nil, err
+	}
+	return &pulumirpc.GenerateProjectResponse{}, nil
+}
+
+// GetProgramDependencies returns the Go modules that the program depends on, as reported by `go list -m`.
+func (host *goLanguageHost) GetProgramDependencies(ctx context.Context,
+	req *pulumirpc.GetProgramDependenciesRequest) (*pulumirpc.GetProgramDependenciesResponse, error) {
+
+	gobin, err := executable.FindExecutable("go")
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't find go binary")
+	}
+
+	if err = goversion.CheckMinimumGoVersion(gobin); err != nil {
+		return nil, err
+	}
+
+	args := []string{"list", "-m", "-json", "-mod=mod", "all"}
+	cmd := exec.Command(gobin, args...)
+	cmd.Env = os.Environ()
+	stdout, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get modules")
+	}
+
+	var dependencies []*pulumirpc.DependencyInfo
+	dec := json.NewDecoder(bytes.NewReader(stdout))
+	for {
+		var m modInfo
+		if err := dec.Decode(&m); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrapf(err, "failed to parse \"%s %s\" output", gobin, strings.Join(args, " "))
+		}
+
+		if !m.Main && (!m.Indirect || req.TransitiveDependencies) {
+			dependencies = append(dependencies, &pulumirpc.DependencyInfo{
+				Name:    m.Path,
+				Version: m.Version,
+			})
+		}
+	}
+
+	return &pulumirpc.GetProgramDependenciesResponse{
+		Dependencies: dependencies,
+	}, nil
+}
+
 func (host *goLanguageHost) InstallDependencies(
 	req *pulumirpc.InstallDependenciesRequest, server pulumirpc.LanguageRuntime_InstallDependenciesServer) error {
 
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -22,6 +23,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetPlugin(t *testing.T) {
@@ -231,3 +233,41 @@ func TestGetPlugin(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateProgram(t *testing.T) {
+	t.Parallel()
+
+	source := map[string]string{
+		"main.pp": `config "name" "string" {}
+
+output "greeting" {
+	value = "Hello, ${name}!"
+}
+`,
+	}
+
+	host := &goLanguageHost{}
+
+	program, err := host.GenerateProgram(context.Background(), &pulumirpc.GenerateProgramRequest{Source: source})
+	require.NoError(t, err)
+	assert.Contains(t, string(program.Source["main.go"]), `ctx.Export("greeting"`)
+
+	dir := t.TempDir()
+	_, err = host.GenerateProject(context.Background(), &pulumirpc.GenerateProjectRequest{
+		Directory: dir,
+		Project:   `{"name": "greeter", "runtime": "yaml"}`,
+		Source:    source,
+	})
+	require.NoError(t, err)
+	for _, file := range []string{"Pulumi.yaml", "main.go", "go.mod"} {
+		assert.FileExists(t, filepath.Join(dir, file))
+	}
+	project, err := os.ReadFile(filepath.Join(dir, "Pulumi.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(project), "runtime: go")
+
+	_, err = host.GenerateProgram(context.Background(), &pulumirpc.GenerateProgramRequest{
+		Source: map[string]string{"main.pp": `output "bad" {`},
+	})
+	assert.Error(t, err)
+}
@@ -20,8 +20,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"path"
+	"path/filepath"
 	"reflect"
+	"runtime"
 	"sort"
 	"strings"
 	"sync"
@@ -791,6 +795,9 @@ func (ctx *Context) registerResource(
 	resState := ctx.makeResourceState(t, name, resource, providers, provider,
 		options.Version, options.PluginDownloadURL, aliasURNs, transformations)
 
+	// Record where in the program this resource is being registered.
+	sourcePos := callerSourcePosition()
+
 	// Kick off the resource registration.  If we are actually performing a deployment, the resulting properties
 	// will be resolved asynchronously as the RPC operation completes.  If we're just planning, values won't resolve.
 	go func() {
@@ -845,6 +852,9 @@ func (ctx *Context) registerResource(
 				Remote:                  remote,
 				ReplaceOnChanges:        inputs.replaceOnChanges,
 				RetainOnDelete:          inputs.retainOnDelete,
+				DeletedWith:             inputs.deletedWith,
+				SourcePosition:          sourcePos,
+				RetryPolicy:             inputs.retryPolicy,
 			})
 			if err != nil {
 				logging.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -869,6 +879,48 @@ func (ctx *Context) registerResource(
 	return nil
 }
 
+// sdkDir is the directory that contains this package's sources. Frames within it are never user code.
+var sdkDir = func() string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		return ""
+	}
+	return path.Dir(file)
+}()
+
+// callerSourcePosition returns the position of the innermost caller that is part of the user's program, or nil if
+// no such caller can be found. Frames inside this package, the Go standard library, and any dependencies that live
+// in the module cache or a vendor directory (e.g. generated provider SDKs) are skipped.
+func callerSourcePosition() *pulumirpc.SourcePosition {
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if frame.File != "" && !isLibraryFrame(frame.File) {
+			file := frame.File
+			if !strings.HasPrefix(file, "/") {
+				file = "/" + file
+			}
+			uri := url.URL{Scheme: "file", Path: file}
+			return &pulumirpc.SourcePosition{Uri: uri.String(), Line: int32(frame.Line)}
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+// isLibraryFrame returns true if the given source file is not part of the user's program.
+func isLibraryFrame(file string) bool {
+	if path.Dir(file) == sdkDir && !strings.HasSuffix(file, "_test.go") {
+		return true
+	}
+	if goroot := runtime.GOROOT(); goroot != "" && strings.HasPrefix(file, filepath.ToSlash(goroot)+"/") {
+		return true
+	}
+	return strings.Contains(file, "/pkg/mod/") || strings.Contains(file, "/vendor/")
+}
+
 func (ctx *Context) RegisterComponentResource(
 	t, name string, resource ComponentResource, opts ...ResourceOption) error {
 
@@ -1232,6 +1284,8 @@ type resourceInputs struct {
 	pluginDownloadURL       string
 	replaceOnChanges        []string
 	retainOnDelete          bool
+	deletedWith             string
+	retryPolicy             *pulumirpc.RegisterResourceRequest_RetryPolicy
 }
 
 // prepareResourceInputs prepares the inputs for a resource operation, shared between read and register.
@@ -1319,6 +1373,8 @@ func (ctx *Context) prepareResourceInputs(res Resource, props Input, t string, o
 		pluginDownloadURL:       state.pluginDownloadURL,
 		replaceOnChanges:        resOpts.replaceOnChanges,
 		retainOnDelete:          opts.RetainOnDelete,
+		deletedWith:             string(resOpts.deletedWithURN),
+		retryPolicy:             getRetryPolicy(opts.RetryPolicy),
 	}, nil
 }
 
@@ -1332,6 +1388,18 @@ func getTimeouts(custom *CustomTimeouts) *pulumirpc.RegisterResourceRequest_Cust
 	return &timeouts
 }
 
+func getRetryPolicy(policy *RetryPolicy) *pulumirpc.RegisterResourceRequest_RetryPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &pulumirpc.RegisterResourceRequest_RetryPolicy{
+		Attempts:       int32(policy.Attempts),
+		Backoff:        policy.Backoff,
+		ErrorPatterns:  policy.ErrorPatterns,
+		RetryIfUnknown: policy.RetryIfUnknown,
+	}
+}
+
 // Helper struct for the return type of `getOpts`.
 type resourceOpts struct {
 	parentURN               URN
@@ -1344,6 +1412,7 @@ type resourceOpts struct {
 	ignoreChanges           []string
 	additionalSecretOutputs []string
 	replaceOnChanges        []string
+	deletedWithURN          URN
 }
 
 // getOpts returns a set of resource options from an array of them. This includes the parent URN, any dependency URNs,
@@ -1371,6 +1440,15 @@ func (ctx *Context) getOpts(res Resource, t string, provider ProviderResource, o
 		parentURN = urn
 	}
 
+	var deletedWithURN URN
+	if opts.DeletedWith != nil {
+		urn, _, _, err := opts.DeletedWith.URN().awaitURN(context.TODO())
+		if err != nil {
+			return resourceOpts{}, err
+		}
+		deletedWithURN = urn
+	}
+
 	var depURNs []URN
 	if opts.DependsOn != nil {
 		depSet := urnSet{}
@@ -1418,6 +1496,7 @@ func (ctx *Context) getOpts(res Resource, t string, provider ProviderResource, o
 		ignoreChanges:           opts.IgnoreChanges,
 		additionalSecretOutputs: opts.AdditionalSecretOutputs,
 		replaceOnChanges:        opts.ReplaceOnChanges,
+		deletedWithURN:          deletedWithURN,
 	}, nil
 }
 
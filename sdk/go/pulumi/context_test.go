@@ -352,3 +352,19 @@ func TestMergeProviders(t *testing.T) {
 		})
 	}
 }
+
+func TestCallerSourcePosition(t *testing.T) {
+	t.Parallel()
+
+	// Frames in test files are treated as user code, so the position should point at this call.
+	pos := callerSourcePosition()
+	if assert.NotNil(t, pos) {
+		assert.True(t, strings.HasPrefix(pos.Uri, "file:///"))
+		assert.True(t, strings.HasSuffix(pos.Uri, "/context_test.go"), pos.Uri)
+		assert.Greater(t, pos.Line, int32(0))
+	}
+
+	assert.True(t, isLibraryFrame(sdkDir+"/context.go"))
+	assert.True(t, isLibraryFrame("/home/user/go/pkg/mod/example.com/sdk@v1.0.0/bucket.go"))
+	assert.False(t, isLibraryFrame("/home/user/project/main.go"))
+}
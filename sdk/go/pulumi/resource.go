@@ -265,6 +265,20 @@ type CustomTimeouts struct {
 	Delete string
 }
 
+// RetryPolicy describes how failed create, update, and delete operations for a resource are retried.
+type RetryPolicy struct {
+	// Attempts is the number of times a failed operation is retried.
+	Attempts int
+	// Backoff is the delay before the first retry, e.g. "5s". The delay doubles after each retry.
+	Backoff string
+	// ErrorPatterns is an optional list of regular expressions. If set, only errors whose messages match one of the
+	// patterns are retried.
+	ErrorPatterns []string
+	// RetryIfUnknown retries failures that may have left the resource in an unknown state, such as unexpected errors
+	// from the provider. Otherwise such failures are only retried if they match one of the error patterns.
+	RetryIfUnknown bool
+}
+
 type resourceOptions struct {
 	// AdditionalSecretOutputs is an optional list of output properties to mark as secret.
 	AdditionalSecretOutputs []string
@@ -311,6 +325,11 @@ type resourceOptions struct {
 	PluginDownloadURL string
 	// If set to True, the providers Delete method will not be called for this resource.
 	RetainOnDelete bool
+	// If set, the providers Delete method will not be called for this resource
+	// if specified resource is being deleted as well.
+	DeletedWith Resource
+	// RetryPolicy is an optional policy for retrying failed create, update, and delete operations.
+	RetryPolicy *RetryPolicy
 }
 
 type invokeOptions struct {
@@ -590,3 +609,19 @@ func RetainOnDelete(b bool) ResourceOption {
 		ro.RetainOnDelete = b
 	})
 }
+
+// DeletedWith specifies that the providers Delete method will not be called for this resource if the specified
+// resource is being deleted as well, such as a resource that is contained by another and deleted along with it.
+func DeletedWith(r Resource) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.DeletedWith = r
+	})
+}
+
+// Retry specifies how failed create, update, and delete operations for this resource are retried, e.g. when its
+// provider reports transient errors. It overrides any retry policy set for the whole stack.
+func Retry(p *RetryPolicy) ResourceOption {
+	return resourceOption(func(ro *resourceOptions) {
+		ro.RetryPolicy = p
+	})
+}
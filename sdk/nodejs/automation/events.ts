@@ -172,6 +172,16 @@ export interface ResOpFailedEvent {
     steps: number;
 }
 
+// ResRetryEvent is emitted when a failed resource operation is about to be retried according to the
+// resource's retry policy.
+export interface ResRetryEvent {
+    metadata: StepEventMetadata;
+    attempt: number;
+    maxAttempts: number;
+    delaySeconds: number;
+    error: string;
+}
+
 // EngineEvent describes a Pulumi engine event, such as a change to a resource or diagnostic
 // message. EngineEvent is a discriminated union of all possible event types, and exactly one
 // field will be non-nil.
@@ -196,5 +206,6 @@ export interface EngineEvent {
     resourcePreEvent?: ResourcePreEvent;
     resOutputsEvent?: ResOutputsEvent;
     resOpFailedEvent?: ResOpFailedEvent;
+    resRetryEvent?: ResRetryEvent;
     policyEvent?: PolicyEvent;
 }
@@ -188,6 +188,9 @@ Event: ${line}\n${e.toString()}`);
             if (opts.targetDependents) {
                 args.push("--target-dependents");
             }
+            if (opts.continueOnError) {
+                args.push("--continue-on-error");
+            }
             if (opts.parallel) {
                 args.push("--parallel", opts.parallel.toString());
             }
@@ -470,6 +473,9 @@ Event: ${line}\n${e.toString()}`);
             if (opts.targetDependents) {
                 args.push("--target-dependents");
             }
+            if (opts.continueOnError) {
+                args.push("--continue-on-error");
+            }
             if (opts.parallel) {
                 args.push("--parallel", opts.parallel.toString());
             }
@@ -786,6 +792,10 @@ export interface UpOptions {
      * Include secrets in the UpSummary.
      */
     showSecrets?: boolean;
+    /**
+     * Continue updating resources that are independent of a failed resource, reporting all failures at the end.
+     */
+    continueOnError?: boolean;
 }
 
 /**
@@ -842,6 +852,10 @@ export interface DestroyOptions {
     color?: "always" | "never" | "raw" | "auto";
     // Include secrets in the DestroySummary
     showSecrets?: boolean;
+    /**
+     * Continue destroying resources that are independent of a failed resource, reporting all failures at the end.
+     */
+    continueOnError?: boolean;
 }
 
 const execKind = {
@@ -170,3 +170,28 @@ func TestGetRequiredPlugins(t *testing.T) {
 		"bar": "v4.5.6",
 	}, actual)
 }
+
+func TestCrossCheckPackageJSONFile(t *testing.T) {
+	t.Parallel()
+
+	packages := []*pulumirpc.DependencyInfo{
+		{Name: "@pulumi/pulumi", Version: "3.33.1"},
+		{Name: "@pulumi/aws", Version: "5.4.0"},
+		{Name: "typescript", Version: "4.7.2"},
+		{Name: "@pulumi/pulumi", Version: "3.33.1"},
+		{Name: "mime", Version: "2.6.0"},
+	}
+	file := []byte(`{
+		"name": "example",
+		"dependencies": {"@pulumi/pulumi": "^3.0.0", "@pulumi/aws": "^5.0.0"},
+		"devDependencies": {"typescript": "^4.0.0"}
+	}`)
+
+	// Only the direct dependencies are reported, once each, with their installed versions.
+	direct, err := crossCheckPackageJSONFile("package.json", file, packages)
+	assert.NoError(t, err)
+	assert.Equal(t, packages[:3], direct)
+
+	_, err = crossCheckPackageJSONFile("package.json", []byte("{"), packages)
+	assert.Error(t, err)
+}
@@ -32,6 +32,72 @@ function deserialize_google_protobuf_Empty(buffer_arg) {
   return google_protobuf_empty_pb.Empty.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_GenerateProgramRequest(arg) {
+  if (!(arg instanceof language_pb.GenerateProgramRequest)) {
+    throw new Error('Expected argument of type pulumirpc.GenerateProgramRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GenerateProgramRequest(buffer_arg) {
+  return language_pb.GenerateProgramRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_GenerateProgramResponse(arg) {
+  if (!(arg instanceof language_pb.GenerateProgramResponse)) {
+    throw new Error('Expected argument of type pulumirpc.GenerateProgramResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GenerateProgramResponse(buffer_arg) {
+  return language_pb.GenerateProgramResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_GenerateProjectRequest(arg) {
+  if (!(arg instanceof language_pb.GenerateProjectRequest)) {
+    throw new Error('Expected argument of type pulumirpc.GenerateProjectRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GenerateProjectRequest(buffer_arg) {
+  return language_pb.GenerateProjectRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_GenerateProjectResponse(arg) {
+  if (!(arg instanceof language_pb.GenerateProjectResponse)) {
+    throw new Error('Expected argument of type pulumirpc.GenerateProjectResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GenerateProjectResponse(buffer_arg) {
+  return language_pb.GenerateProjectResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_GetProgramDependenciesRequest(arg) {
+  if (!(arg instanceof language_pb.GetProgramDependenciesRequest)) {
+    throw new Error('Expected argument of type pulumirpc.GetProgramDependenciesRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GetProgramDependenciesRequest(buffer_arg) {
+  return language_pb.GetProgramDependenciesRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_GetProgramDependenciesResponse(arg) {
+  if (!(arg instanceof language_pb.GetProgramDependenciesResponse)) {
+    throw new Error('Expected argument of type pulumirpc.GetProgramDependenciesResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GetProgramDependenciesResponse(buffer_arg) {
+  return language_pb.GetProgramDependenciesResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_GetRequiredPluginsRequest(arg) {
   if (!(arg instanceof language_pb.GetRequiredPluginsRequest)) {
     throw new Error('Expected argument of type pulumirpc.GetRequiredPluginsRequest');
@@ -161,6 +227,42 @@ installDependencies: {
     responseSerialize: serialize_pulumirpc_InstallDependenciesResponse,
     responseDeserialize: deserialize_pulumirpc_InstallDependenciesResponse,
   },
+  // GenerateProgram generates a program in this language from a PCL program.
+generateProgram: {
+    path: '/pulumirpc.LanguageRuntime/GenerateProgram',
+    requestStream: false,
+    responseStream: false,
+    requestType: language_pb.GenerateProgramRequest,
+    responseType: language_pb.GenerateProgramResponse,
+    requestSerialize: serialize_pulumirpc_GenerateProgramRequest,
+    requestDeserialize: deserialize_pulumirpc_GenerateProgramRequest,
+    responseSerialize: serialize_pulumirpc_GenerateProgramResponse,
+    responseDeserialize: deserialize_pulumirpc_GenerateProgramResponse,
+  },
+  // GenerateProject generates a project in this language from a PCL program, writing it to a directory.
+generateProject: {
+    path: '/pulumirpc.LanguageRuntime/GenerateProject',
+    requestStream: false,
+    responseStream: false,
+    requestType: language_pb.GenerateProjectRequest,
+    responseType: language_pb.GenerateProjectResponse,
+    requestSerialize: serialize_pulumirpc_GenerateProjectRequest,
+    requestDeserialize: deserialize_pulumirpc_GenerateProjectRequest,
+    responseSerialize: serialize_pulumirpc_GenerateProjectResponse,
+    responseDeserialize: deserialize_pulumirpc_GenerateProjectResponse,
+  },
+  // GetProgramDependencies returns the set of packages, with their versions, that a program depends on.
+getProgramDependencies: {
+    path: '/pulumirpc.LanguageRuntime/GetProgramDependencies',
+    requestStream: false,
+    responseStream: false,
+    requestType: language_pb.GetProgramDependenciesRequest,
+    responseType: language_pb.GetProgramDependenciesResponse,
+    requestSerialize: serialize_pulumirpc_GetProgramDependenciesRequest,
+    requestDeserialize: deserialize_pulumirpc_GetProgramDependenciesRequest,
+    responseSerialize: serialize_pulumirpc_GetProgramDependenciesResponse,
+    responseDeserialize: deserialize_pulumirpc_GetProgramDependenciesResponse,
+  },
 };
 
 exports.LanguageRuntimeClient = grpc.makeGenericClientConstructor(LanguageRuntimeService);
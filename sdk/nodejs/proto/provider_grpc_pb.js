@@ -176,6 +176,28 @@ function deserialize_pulumirpc_DiffResponse(buffer_arg) {
   return provider_pb.DiffResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_GetMappingRequest(arg) {
+  if (!(arg instanceof provider_pb.GetMappingRequest)) {
+    throw new Error('Expected argument of type pulumirpc.GetMappingRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GetMappingRequest(buffer_arg) {
+  return provider_pb.GetMappingRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_GetMappingResponse(arg) {
+  if (!(arg instanceof provider_pb.GetMappingResponse)) {
+    throw new Error('Expected argument of type pulumirpc.GetMappingResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GetMappingResponse(buffer_arg) {
+  return provider_pb.GetMappingResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_GetSchemaRequest(arg) {
   if (!(arg instanceof provider_pb.GetSchemaRequest)) {
     throw new Error('Expected argument of type pulumirpc.GetSchemaRequest');
@@ -220,6 +242,28 @@ function deserialize_pulumirpc_InvokeResponse(buffer_arg) {
   return provider_pb.InvokeResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_ListRequest(arg) {
+  if (!(arg instanceof provider_pb.ListRequest)) {
+    throw new Error('Expected argument of type pulumirpc.ListRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_ListRequest(buffer_arg) {
+  return provider_pb.ListRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_ListResponse(arg) {
+  if (!(arg instanceof provider_pb.ListResponse)) {
+    throw new Error('Expected argument of type pulumirpc.ListResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_ListResponse(buffer_arg) {
+  return provider_pb.ListResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_PluginAttach(arg) {
   if (!(arg instanceof plugin_pb.PluginAttach)) {
     throw new Error('Expected argument of type pulumirpc.PluginAttach');
@@ -505,6 +549,32 @@ attach: {
     responseSerialize: serialize_google_protobuf_Empty,
     responseDeserialize: deserialize_google_protobuf_Empty,
   },
+  // GetMapping fetches the mapping for this resource provider from another ecosystem (e.g. Terraform), if any. A
+  // provider should return an empty response (not an error) if it doesn't have a mapping for the given key.
+getMapping: {
+    path: '/pulumirpc.ResourceProvider/GetMapping',
+    requestStream: false,
+    responseStream: false,
+    requestType: provider_pb.GetMappingRequest,
+    responseType: provider_pb.GetMappingResponse,
+    requestSerialize: serialize_pulumirpc_GetMappingRequest,
+    requestDeserialize: deserialize_pulumirpc_GetMappingRequest,
+    responseSerialize: serialize_pulumirpc_GetMappingResponse,
+    responseDeserialize: deserialize_pulumirpc_GetMappingResponse,
+  },
+  // List enumerates the live resources of the given type that the provider can see, so that they can be imported.
+  // Unlike other resource operations, List does not require the resources to be managed by Pulumi.
+list: {
+    path: '/pulumirpc.ResourceProvider/List',
+    requestStream: false,
+    responseStream: false,
+    requestType: provider_pb.ListRequest,
+    responseType: provider_pb.ListResponse,
+    requestSerialize: serialize_pulumirpc_ListRequest,
+    requestDeserialize: deserialize_pulumirpc_ListRequest,
+    responseSerialize: serialize_pulumirpc_ListResponse,
+    responseDeserialize: deserialize_pulumirpc_ListResponse,
+  },
 };
 
 exports.ResourceProviderClient = grpc.makeGenericClientConstructor(ResourceProviderService);
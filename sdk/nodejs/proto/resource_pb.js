@@ -27,9 +27,11 @@ goog.exportSymbol('proto.pulumirpc.RegisterResourceOutputsRequest', null, global
 goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest.CustomTimeouts', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest.PropertyDependencies', null, global);
+goog.exportSymbol('proto.pulumirpc.RegisterResourceRequest.RetryPolicy', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceResponse', null, global);
 goog.exportSymbol('proto.pulumirpc.RegisterResourceResponse.PropertyDependencies', null, global);
 goog.exportSymbol('proto.pulumirpc.ResourceInvokeRequest', null, global);
+goog.exportSymbol('proto.pulumirpc.SourcePosition', null, global);
 goog.exportSymbol('proto.pulumirpc.SupportsFeatureRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.SupportsFeatureResponse', null, global);
 /**
@@ -179,6 +181,48 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.pulumirpc.RegisterResourceRequest.CustomTimeouts.displayName = 'proto.pulumirpc.RegisterResourceRequest.CustomTimeouts';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.RegisterResourceRequest.RetryPolicy.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.RegisterResourceRequest.RetryPolicy, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.RegisterResourceRequest.RetryPolicy.displayName = 'proto.pulumirpc.RegisterResourceRequest.RetryPolicy';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.SourcePosition = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.pulumirpc.SourcePosition, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.SourcePosition.displayName = 'proto.pulumirpc.SourcePosition';
+}
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -1342,7 +1386,10 @@ proto.pulumirpc.RegisterResourceRequest.toObject = function(includeInstance, msg
     providersMap: (f = msg.getProvidersMap()) ? f.toObject(includeInstance, undefined) : [],
     replaceonchangesList: (f = jspb.Message.getRepeatedField(msg, 23)) == null ? undefined : f,
     plugindownloadurl: jspb.Message.getFieldWithDefault(msg, 24, ""),
-    retainondelete: jspb.Message.getBooleanFieldWithDefault(msg, 25, false)
+    retainondelete: jspb.Message.getBooleanFieldWithDefault(msg, 25, false),
+    deletedwith: jspb.Message.getFieldWithDefault(msg, 27, ""),
+    sourceposition: (f = msg.getSourceposition()) && proto.pulumirpc.SourcePosition.toObject(includeInstance, f),
+    retrypolicy: (f = msg.getRetrypolicy()) && proto.pulumirpc.RegisterResourceRequest.RetryPolicy.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -1485,6 +1532,20 @@ proto.pulumirpc.RegisterResourceRequest.deserializeBinaryFromReader = function(m
       var value = /** @type {boolean} */ (reader.readBool());
       msg.setRetainondelete(value);
       break;
+    case 27:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setDeletedwith(value);
+      break;
+    case 28:
+      var value = new proto.pulumirpc.SourcePosition;
+      reader.readMessage(value,proto.pulumirpc.SourcePosition.deserializeBinaryFromReader);
+      msg.setSourceposition(value);
+      break;
+    case 29:
+      var value = new proto.pulumirpc.RegisterResourceRequest.RetryPolicy;
+      reader.readMessage(value,proto.pulumirpc.RegisterResourceRequest.RetryPolicy.deserializeBinaryFromReader);
+      msg.setRetrypolicy(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1685,6 +1746,29 @@ proto.pulumirpc.RegisterResourceRequest.serializeBinaryToWriter = function(messa
       f
     );
   }
+  f = message.getDeletedwith();
+  if (f.length > 0) {
+    writer.writeString(
+      27,
+      f
+    );
+  }
+  f = message.getSourceposition();
+  if (f != null) {
+    writer.writeMessage(
+      28,
+      f,
+      proto.pulumirpc.SourcePosition.serializeBinaryToWriter
+    );
+  }
+  f = message.getRetrypolicy();
+  if (f != null) {
+    writer.writeMessage(
+      29,
+      f,
+      proto.pulumirpc.RegisterResourceRequest.RetryPolicy.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -2034,6 +2118,252 @@ proto.pulumirpc.RegisterResourceRequest.CustomTimeouts.prototype.setDelete = fun
 };
 
 
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.repeatedFields_ = [3];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.RegisterResourceRequest.RetryPolicy.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    attempts: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    backoff: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    errorpatternsList: (f = jspb.Message.getRepeatedField(msg, 3)) == null ? undefined : f,
+    retryifunknown: jspb.Message.getBooleanFieldWithDefault(msg, 4, false)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy}
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.RegisterResourceRequest.RetryPolicy;
+  return proto.pulumirpc.RegisterResourceRequest.RetryPolicy.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy}
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setAttempts(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setBackoff(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.addErrorpatterns(value);
+      break;
+    case 4:
+      var value = /** @type {boolean} */ (reader.readBool());
+      msg.setRetryifunknown(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.RegisterResourceRequest.RetryPolicy.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getAttempts();
+  if (f !== 0) {
+    writer.writeInt32(
+      1,
+      f
+    );
+  }
+  f = message.getBackoff();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getErrorpatternsList();
+  if (f.length > 0) {
+    writer.writeRepeatedString(
+      3,
+      f
+    );
+  }
+  f = message.getRetryifunknown();
+  if (f) {
+    writer.writeBool(
+      4,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional int32 attempts = 1;
+ * @return {number}
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.getAttempts = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.setAttempts = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+/**
+ * optional string backoff = 2;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.getBackoff = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.setBackoff = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * repeated string errorPatterns = 3;
+ * @return {!Array<string>}
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.getErrorpatternsList = function() {
+  return /** @type {!Array<string>} */ (jspb.Message.getRepeatedField(this, 3));
+};
+
+
+/**
+ * @param {!Array<string>} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.setErrorpatternsList = function(value) {
+  return jspb.Message.setField(this, 3, value || []);
+};
+
+
+/**
+ * @param {string} value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.addErrorpatterns = function(value, opt_index) {
+  return jspb.Message.addToRepeatedField(this, 3, value, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.clearErrorpatternsList = function() {
+  return this.setErrorpatternsList([]);
+};
+
+
+/**
+ * optional bool retryIfUnknown = 4;
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.getRetryifunknown = function() {
+  return /** @type {boolean} */ (jspb.Message.getBooleanFieldWithDefault(this, 4, false));
+};
+
+
+/**
+ * @param {boolean} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest.RetryPolicy} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.RetryPolicy.prototype.setRetryifunknown = function(value) {
+  return jspb.Message.setProto3BooleanField(this, 4, value);
+};
+
+
 /**
  * optional string type = 1;
  * @return {string}
@@ -2625,6 +2955,288 @@ proto.pulumirpc.RegisterResourceRequest.prototype.setRetainondelete = function(v
 };
 
 
+/**
+ * optional string deletedWith = 27;
+ * @return {string}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getDeletedwith = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 27, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.setDeletedwith = function(value) {
+  return jspb.Message.setProto3StringField(this, 27, value);
+};
+
+
+/**
+ * optional SourcePosition sourcePosition = 28;
+ * @return {?proto.pulumirpc.SourcePosition}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getSourceposition = function() {
+  return /** @type{?proto.pulumirpc.SourcePosition} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.SourcePosition, 28));
+};
+
+
+/**
+ * @param {?proto.pulumirpc.SourcePosition|undefined} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+*/
+proto.pulumirpc.RegisterResourceRequest.prototype.setSourceposition = function(value) {
+  return jspb.Message.setWrapperField(this, 28, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearSourceposition = function() {
+  return this.setSourceposition(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.hasSourceposition = function() {
+  return jspb.Message.getField(this, 28) != null;
+};
+
+
+/**
+ * optional RetryPolicy retryPolicy = 29;
+ * @return {?proto.pulumirpc.RegisterResourceRequest.RetryPolicy}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.getRetrypolicy = function() {
+  return /** @type{?proto.pulumirpc.RegisterResourceRequest.RetryPolicy} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.RegisterResourceRequest.RetryPolicy, 29));
+};
+
+
+/**
+ * @param {?proto.pulumirpc.RegisterResourceRequest.RetryPolicy|undefined} value
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+*/
+proto.pulumirpc.RegisterResourceRequest.prototype.setRetrypolicy = function(value) {
+  return jspb.Message.setWrapperField(this, 29, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.RegisterResourceRequest} returns this
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.clearRetrypolicy = function() {
+  return this.setRetrypolicy(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.RegisterResourceRequest.prototype.hasRetrypolicy = function() {
+  return jspb.Message.getField(this, 29) != null;
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.SourcePosition.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.SourcePosition.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.SourcePosition} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.SourcePosition.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    uri: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    line: jspb.Message.getFieldWithDefault(msg, 2, 0),
+    column: jspb.Message.getFieldWithDefault(msg, 3, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.SourcePosition}
+ */
+proto.pulumirpc.SourcePosition.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.SourcePosition;
+  return proto.pulumirpc.SourcePosition.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.SourcePosition} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.SourcePosition}
+ */
+proto.pulumirpc.SourcePosition.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUri(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setLine(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readInt32());
+      msg.setColumn(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.SourcePosition.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.SourcePosition.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.SourcePosition} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.SourcePosition.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getUri();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getLine();
+  if (f !== 0) {
+    writer.writeInt32(
+      2,
+      f
+    );
+  }
+  f = message.getColumn();
+  if (f !== 0) {
+    writer.writeInt32(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string uri = 1;
+ * @return {string}
+ */
+proto.pulumirpc.SourcePosition.prototype.getUri = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.SourcePosition} returns this
+ */
+proto.pulumirpc.SourcePosition.prototype.setUri = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional int32 line = 2;
+ * @return {number}
+ */
+proto.pulumirpc.SourcePosition.prototype.getLine = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.pulumirpc.SourcePosition} returns this
+ */
+proto.pulumirpc.SourcePosition.prototype.setLine = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
+/**
+ * optional int32 column = 3;
+ * @return {number}
+ */
+proto.pulumirpc.SourcePosition.prototype.getColumn = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.pulumirpc.SourcePosition} returns this
+ */
+proto.pulumirpc.SourcePosition.prototype.setColumn = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
 
 /**
  * List of repeated fields within this message type.
@@ -597,6 +597,16 @@ export interface ResourceOptions {
      * If set to True, the providers Delete method will not be called for this resource.
      */
     retainOnDelete?: boolean;
+    /**
+     * If set, the providers Delete method will not be called for this resource
+     * if specified resource is being deleted as well.
+     */
+    deletedWith?: Resource;
+    /**
+     * An optional policy for retrying failed create, update, and delete operations, e.g. when the
+     * resource's provider reports transient errors. Overrides any retry policy set for the stack.
+     */
+    retryPolicy?: RetryPolicy;
 
     // !!! IMPORTANT !!! If you add a new field to this type, make sure to add test that verifies
     // that mergeOptions works properly for it.
@@ -617,6 +627,29 @@ export interface CustomTimeouts {
     delete?: string;
 }
 
+export interface RetryPolicy {
+    /**
+     * The number of times a failed operation is retried.
+     */
+    attempts: number;
+    /**
+     * The optional delay before the first retry represented as a string e.g. 5s, 1m. The delay
+     * doubles after each retry.
+     */
+    backoff?: string;
+    /**
+     * An optional list of regular expressions. If set, only errors whose messages match one of
+     * the patterns are retried.
+     */
+    errorPatterns?: string[];
+    /**
+     * If true, failures that may have left the resource in an unknown state, such as unexpected
+     * errors from the provider, are retried too. Otherwise such failures are only retried if they
+     * match one of the error patterns.
+     */
+    retryIfUnknown?: boolean;
+}
+
 /**
  * ResourceTransformation is the callback signature for the `transformations` resource option.  A
  * transformation is passed the same set of inputs provided to the `Resource` constructor, and can
@@ -14,6 +14,8 @@
 
 import * as grpc from "@grpc/grpc-js";
 import * as query from "@pulumi/query";
+import * as path from "path";
+import * as url from "url";
 import * as log from "../log";
 import * as utils from "../utils";
 
@@ -86,6 +88,8 @@ interface ResourceResolverOperation {
     aliases: URN[];
     // An ID to import, if any.
     import: ID | undefined;
+    // The URN of the resource this resource is deleted with, fully resolved, if any.
+    deletedWithURN: URN | undefined;
 }
 
 /**
@@ -272,6 +276,37 @@ export function readResource(res: Resource, parent: Resource | undefined, t: str
     }), label);
 }
 
+/**
+ * sdkRoot is the root directory of this package. Stack frames within it are never user code.
+ */
+const sdkRoot = path.resolve(__dirname, "..");
+
+/**
+ * sourcePosition returns the position of the innermost stack frame of the given error that belongs to the user's
+ * program, or undefined if there is none. Frames inside this package, Node internals, and any installed packages
+ * (e.g. provider SDKs) are skipped.
+ */
+function sourcePosition(err: Error): any | undefined {
+    for (const line of (err.stack || "").split("\n")) {
+        const match = /^\s*at (?:.*\()?(.+):(\d+):(\d+)\)?$/.exec(line);
+        if (!match) {
+            continue;
+        }
+        const [file, lineNumber, column] = [match[1], +match[2], +match[3]];
+        if (!path.isAbsolute(file) || file.startsWith(sdkRoot + path.sep) ||
+            file.split(path.sep).includes("node_modules")) {
+            continue;
+        }
+
+        const pos = new resproto.SourcePosition();
+        pos.setUri(url.pathToFileURL(file).toString());
+        pos.setLine(lineNumber);
+        pos.setColumn(column);
+        return pos;
+    }
+    return undefined;
+}
+
 /**
  * registerResource registers a new resource object with a given type t and name.  It returns the auto-generated
  * URN and the ID that will resolve after the deployment has completed.  All properties will be initialized to property
@@ -317,6 +352,8 @@ export function registerResource(res: Resource, parent: Resource | undefined, t:
         req.setReplaceonchangesList(opts.replaceOnChanges || []);
         req.setPlugindownloadurl(opts.pluginDownloadURL || "");
         req.setRetainondelete(opts.retainOnDelete || false);
+        req.setDeletedwith(resop.deletedWithURN || "");
+        req.setSourceposition(sourcePosition(preallocError));
 
         const customTimeouts = new resproto.RegisterResourceRequest.CustomTimeouts();
         if (opts.customTimeouts != null) {
@@ -326,6 +363,15 @@ export function registerResource(res: Resource, parent: Resource | undefined, t:
         }
         req.setCustomtimeouts(customTimeouts);
 
+        if (opts.retryPolicy != null) {
+            const retryPolicy = new resproto.RegisterResourceRequest.RetryPolicy();
+            retryPolicy.setAttempts(opts.retryPolicy.attempts);
+            retryPolicy.setBackoff(opts.retryPolicy.backoff || "");
+            retryPolicy.setErrorpatternsList(opts.retryPolicy.errorPatterns || []);
+            retryPolicy.setRetryifunknown(!!opts.retryPolicy.retryIfUnknown);
+            req.setRetrypolicy(retryPolicy);
+        }
+
         const propertyDependencies = req.getPropertydependenciesMap();
         for (const [key, resourceURNs] of resop.propertyToDirectDependencyURNs) {
             const deps = new resproto.RegisterResourceRequest.PropertyDependencies();
@@ -520,6 +566,9 @@ async function prepareResource(label: string, res: Resource, parent: Resource |
         // If no parent was provided, parent to the root resource.
         const parentURN = parent ? await parent.urn.promise() : undefined;
 
+        // Wait for the resource this resource is deleted with, if any.
+        const deletedWithURN = opts.deletedWith ? await opts.deletedWith.urn.promise() : undefined;
+
         let providerRef: string | undefined;
         let importID: ID | undefined;
         if (custom) {
@@ -600,6 +649,7 @@ async function prepareResource(label: string, res: Resource, parent: Resource |
             propertyToDirectDependencyURNs: propertyToDirectDependencyURNs,
             aliases: aliases,
             import: importID,
+            deletedWithURN: deletedWithURN,
         };
 
     } finally {
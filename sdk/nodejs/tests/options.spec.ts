@@ -15,7 +15,7 @@
 /* eslint-disable */
 
 import * as assert from "assert";
-import { ComponentResourceOptions, ProviderResource, merge, mergeOptions } from "../resource";
+import { ComponentResourceOptions, ProviderResource, Resource, merge, mergeOptions } from "../resource";
 import { asyncTest } from "./util";
 
 describe("options", () => {
@@ -175,6 +175,28 @@ describe("options", () => {
             });
         });
 
+        describe("deletedWith", () => {
+            const resA = <Resource>{ urn: <any>"a" };
+            const resB = <Resource>{ urn: <any>"b" };
+
+            it("keeps value from opts1 if not provided in opts2", () => {
+                const result = mergeOptions({ deletedWith: resA }, {});
+                assert.strictEqual(result.deletedWith, resA);
+            });
+            it("keeps value from opts2 if not provided in opts1", () => {
+                const result = mergeOptions({}, { deletedWith: resB });
+                assert.strictEqual(result.deletedWith, resB);
+            });
+            it("overwrites value from opts1 if given value in opts2", () => {
+                const result = mergeOptions({ deletedWith: resA }, { deletedWith: resB });
+                assert.strictEqual(result.deletedWith, resB);
+            });
+            it("overwrites value from opts1 if given undefined in opts2", () => {
+                const result = mergeOptions({ deletedWith: resA }, { deletedWith: undefined });
+                assert.strictEqual(result.deletedWith, undefined);
+            });
+        });
+
         describe("dependsOn", () => {
             function mergeDependsOn(a: any, b: any): any {
                 return merge(a, b, /*alwaysCreateArray:*/ true);
@@ -41,6 +41,8 @@ import (
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
+	codegen "github.com/pulumi/pulumi/pkg/v3/codegen/python"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
@@ -48,6 +50,7 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/version"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 	"github.com/pulumi/pulumi/sdk/v3/python"
 	"google.golang.org/grpc"
@@ -486,21 +489,23 @@ func determinePluginDependency(
 // determinePluginVersion attempts to convert a PEP440 package version into a plugin version.
 //
 // Supported versions:
-//   PEP440 defines a version as `[N!]N(.N)*[{a|b|rc}N][.postN][.devN]`, but
-//   determinePluginVersion only supports a subset of that. Translations are provided for
-//   `N(.N)*[{a|b|rc}N][.postN][.devN]`.
+//
+//	PEP440 defines a version as `[N!]N(.N)*[{a|b|rc}N][.postN][.devN]`, but
+//	determinePluginVersion only supports a subset of that. Translations are provided for
+//	`N(.N)*[{a|b|rc}N][.postN][.devN]`.
 //
 // Translations:
-//   We ensure that there are at least 3 version segments. Missing segments are `0`
-//   padded.
-//   Example: 1.0 => 1.0.0
 //
-//   We translate a,b,rc to alpha,beta,rc respectively with a hyphen separator.
-//   Example: 1.2.3a4 => 1.2.3-alpha.4, 1.2.3rc4 => 1.2.3-rc.4
+//	We ensure that there are at least 3 version segments. Missing segments are `0`
+//	padded.
+//	Example: 1.0 => 1.0.0
+//
+//	We translate a,b,rc to alpha,beta,rc respectively with a hyphen separator.
+//	Example: 1.2.3a4 => 1.2.3-alpha.4, 1.2.3rc4 => 1.2.3-rc.4
 //
-//   We translate `.post` and `.dev` by replacing the `.` with a `+`. If both `.post`
-//   and `.dev` are present, only one separator is used.
-//   Example: 1.2.3.post4 => 1.2.3+post4, 1.2.3.post4.dev5 => 1.2.3+post4dev5
+//	We translate `.post` and `.dev` by replacing the `.` with a `+`. If both `.post`
+//	and `.dev` are present, only one separator is used.
+//	Example: 1.2.3.post4 => 1.2.3+post4, 1.2.3.post4.dev5 => 1.2.3+post4dev5
 //
 // Reference on PEP440: https://www.python.org/dev/peps/pep-0440/
 func determinePluginVersion(packageVersion string) (string, error) {
@@ -814,6 +819,89 @@ func validateVersion(ctx context.Context, virtualEnvPath string) {
 	}
 }
 
+// GenerateProgram generates Python source code for the given PCL program. The program may refer to variables that it
+// does not declare, as the resource definitions generated by `pulumi import` do.
+func (host *pythonLanguageHost) GenerateProgram(ctx context.Context,
+	req *pulumirpc.GenerateProgramRequest) (*pulumirpc.GenerateProgramResponse, error) {
+
+	program, diags, err := pcl.BindProgramSource(req.GetSource(), pcl.AllowMissingVariables)
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	files, diags, err := codegen.GenerateProgram(program)
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return &pulumirpc.GenerateProgramResponse{Source: files}, nil
+}
+
+// GenerateProject generates a Python project for the given PCL program and writes it to the requested directory.
+func (host *pythonLanguageHost) GenerateProject(ctx context.Context,
+	req *pulumirpc.GenerateProjectRequest) (*pulumirpc.GenerateProjectResponse, error) {
+
+	var project workspace.Project
+	if err := json.Unmarshal([]byte(req.GetProject()), &project); err != nil {
+		return nil, fmt.Errorf("unmarshaling project: %w", err)
+	}
+
+	program, diags, err := pcl.BindProgramSource(req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	if err = codegen.GenerateProject(req.GetDirectory(), project, program); err != nil {
+		return nil, err
+	}
+	return &pulumirpc.GenerateProjectResponse{}, nil
+}
+
+// GetProgramDependencies returns the packages installed for the program, as reported by `pip list`. Unless
+// transitive dependencies are requested, only packages that no other installed package requires are returned.
+func (host *pythonLanguageHost) GetProgramDependencies(ctx context.Context,
+	req *pulumirpc.GetProgramDependenciesRequest) (*pulumirpc.GetProgramDependenciesResponse, error) {
+
+	if host.virtualenv != "" && !python.IsVirtualEnv(host.virtualenvPath) {
+		return nil, python.NewVirtualEnvError(host.virtualenv, host.virtualenvPath)
+	}
+
+	args := []string{"-m", "pip", "list", "--format=json"}
+	if !req.TransitiveDependencies {
+		args = append(args, "--not-required")
+	}
+	output, err := runPythonCommand(ctx, host.virtualenvPath, host.cwd, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "calling `python %s`", strings.Join(args, " "))
+	}
+
+	var packages []pythonPackage
+	jsonDecoder := json.NewDecoder(bytes.NewBuffer(output))
+	if err := jsonDecoder.Decode(&packages); err != nil {
+		return nil, errors.Wrapf(err, "parsing `python %s` output", strings.Join(args, " "))
+	}
+
+	dependencies := make([]*pulumirpc.DependencyInfo, len(packages))
+	for i, pkg := range packages {
+		dependencies[i] = &pulumirpc.DependencyInfo{
+			Name:    pkg.Name,
+			Version: pkg.Version,
+		}
+	}
+
+	return &pulumirpc.GetProgramDependenciesResponse{
+		Dependencies: dependencies,
+	}, nil
+}
+
 func (host *pythonLanguageHost) InstallDependencies(
 	req *pulumirpc.InstallDependenciesRequest, server pulumirpc.LanguageRuntime_InstallDependenciesServer) error {
 
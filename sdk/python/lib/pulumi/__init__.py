@@ -54,6 +54,7 @@ from .resource import (
     Resource,
     CustomResource,
     CustomTimeouts,
+    RetryPolicy,
     ComponentResource,
     ProviderResource,
     ResourceOptions,
@@ -124,6 +125,7 @@ __all__ = [
     "Resource",
     "CustomResource",
     "CustomTimeouts",
+    "RetryPolicy",
     "ComponentResource",
     "ProviderResource",
     "ResourceOptions",
@@ -115,6 +115,7 @@ from .events import (
     ResOutputsEvent,
     ResourcePreEvent,
     ResOpFailedEvent,
+    ResRetryEvent,
     StdoutEngineEvent,
     StepEventStateMetadata,
     StepEventMetadata,
@@ -188,6 +189,7 @@ __all__ = [
     "ResOutputsEvent",
     "ResourcePreEvent",
     "ResOpFailedEvent",
+    "ResRetryEvent",
     "StdoutEngineEvent",
     "StepEventStateMetadata",
     "StepEventMetadata",
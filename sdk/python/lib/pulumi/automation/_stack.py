@@ -213,6 +213,7 @@ class Stack:
         program: Optional[PulumiFn] = None,
         plan: Optional[str] = None,
         show_secrets: bool = True,
+        continue_on_error: Optional[bool] = None,
     ) -> UpResult:
         """
         Creates or updates the resources in a stack by executing the program in the Workspace.
@@ -234,6 +235,8 @@ class Stack:
         :param color: Colorize output. Choices are: always, never, raw, auto (default "auto")
         :param plan: Plan specifies the path to an update plan to use for the update.
         :param show_secrets: Inclode config secrets in the UpResult summary.
+        :param continue_on_error: Continue updating resources that are independent of a failed resource, reporting
+                                  all failures at the end of the operation.
         :returns: UpResult
         """
         # Disable unused-argument because pylint doesn't understand we process them in _parse_extra_args
@@ -474,6 +477,7 @@ class Stack:
         on_output: Optional[OnOutput] = None,
         on_event: Optional[OnEvent] = None,
         show_secrets: bool = True,
+        continue_on_error: Optional[bool] = None,
     ) -> DestroyResult:
         """
         Destroy deletes all resources in a stack, leaving all history and configuration intact.
@@ -487,6 +491,8 @@ class Stack:
         :param on_event: A function to process structured events from the Pulumi event stream.
         :param color: Colorize output. Choices are: always, never, raw, auto (default "auto")
         :param show_secrets: Inclode config secrets in the DestroyResult summary.
+        :param continue_on_error: Continue destroying resources that are independent of a failed resource, reporting
+                                  all failures at the end of the operation.
         :returns: DestroyResult
         """
         # Disable unused-argument because pylint doesn't understand we process them in _parse_extra_args
@@ -696,6 +702,7 @@ def _parse_extra_args(**kwargs) -> List[str]:
     policy_packs = kwargs.get("policy_packs")
     policy_pack_configs = kwargs.get("policy_pack_configs")
     target_dependents = kwargs.get("target_dependents")
+    continue_on_error = kwargs.get("continue_on_error")
     parallel = kwargs.get("parallel")
     color = kwargs.get("color")
 
@@ -719,6 +726,8 @@ def _parse_extra_args(**kwargs) -> List[str]:
             extra_args.extend(["--policy-pack-config", p])
     if target_dependents:
         extra_args.append("--target-dependents")
+    if continue_on_error:
+        extra_args.append("--continue-on-error")
     if parallel:
         extra_args.extend(["--parallel", str(parallel)])
     if color:
@@ -509,6 +509,38 @@ class ResOpFailedEvent(BaseEvent):
         )
 
 
+class ResRetryEvent(BaseEvent):
+    """
+    ResRetryEvent is emitted when a failed resource operation is about to be retried according to the
+    resource's retry policy.
+    """
+
+    def __init__(
+        self,
+        metadata: StepEventMetadata,
+        attempt: int,
+        max_attempts: int,
+        delay_seconds: int,
+        error: str,
+    ):
+        self.metadata = metadata
+        self.attempt = attempt
+        self.max_attempts = max_attempts
+        self.delay_seconds = delay_seconds
+        self.error = error
+
+    @classmethod
+    def from_json(cls, data: dict) -> "ResRetryEvent":
+        metadata: dict = data.get("metadata", {})
+        return cls(
+            metadata=StepEventMetadata.from_json(metadata),
+            attempt=data.get("attempt", 0),
+            max_attempts=data.get("maxAttempts", 0),
+            delay_seconds=data.get("delaySeconds", 0),
+            error=data.get("error", ""),
+        )
+
+
 class EngineEvent(BaseEvent):
     """
     EngineEvent describes a Pulumi engine event, such as a change to a resource or diagnostic
@@ -540,6 +572,7 @@ class EngineEvent(BaseEvent):
         resource_pre_event: Optional[ResourcePreEvent] = None,
         res_outputs_event: Optional[ResOutputsEvent] = None,
         res_op_failed_event: Optional[ResOpFailedEvent] = None,
+        res_retry_event: Optional[ResRetryEvent] = None,
         policy_event: Optional[PolicyEvent] = None,
     ):
         self.sequence = sequence
@@ -552,6 +585,7 @@ class EngineEvent(BaseEvent):
         self.resource_pre_event = resource_pre_event
         self.res_outputs_event = res_outputs_event
         self.res_op_failed_event = res_op_failed_event
+        self.res_retry_event = res_retry_event
         self.policy_event = policy_event
 
     @classmethod
@@ -563,6 +597,7 @@ class EngineEvent(BaseEvent):
         resource_pre_event = data.get("resourcePreEvent")
         res_outputs_event = data.get("resOutputsEvent")
         res_op_failed_event = data.get("resOpFailedEvent")
+        res_retry_event = data.get("resRetryEvent")
         policy_event = data.get("policyEvent")
 
         return cls(
@@ -590,5 +625,8 @@ class EngineEvent(BaseEvent):
             res_op_failed_event=ResOpFailedEvent.from_json(res_op_failed_event)
             if res_op_failed_event
             else None,
+            res_retry_event=ResRetryEvent.from_json(res_retry_event)
+            if res_retry_event
+            else None,
             policy_event=PolicyEvent.from_json(policy_event) if policy_event else None,
         )
@@ -78,6 +78,44 @@ class CustomTimeouts:
         self.delete = delete
 
 
+class RetryPolicy:
+    attempts: int
+    """
+    attempts is the number of times a failed operation is retried.
+    """
+
+    backoff: Optional[str]
+    """
+    backoff is the optional delay before the first retry represented as a string e.g. 5s, 1m. The delay doubles after
+    each retry.
+    """
+
+    error_patterns: Optional[List[str]]
+    """
+    error_patterns is an optional list of regular expressions. If set, only errors whose messages match one of the
+    patterns are retried.
+    """
+
+    retry_if_unknown: bool
+    """
+    retry_if_unknown retries failures that may have left the resource in an unknown state, such as unexpected errors
+    from the provider. Otherwise such failures are only retried if they match one of the error patterns.
+    """
+
+    def __init__(
+        self,
+        attempts: int,
+        backoff: Optional[str] = None,
+        error_patterns: Optional[List[str]] = None,
+        retry_if_unknown: bool = False,
+    ) -> None:
+
+        self.attempts = attempts
+        self.backoff = backoff
+        self.error_patterns = error_patterns
+        self.retry_if_unknown = retry_if_unknown
+
+
 def inherited_child_alias(
     child_name: str, parent_name: str, parent_alias: "Input[str]", child_type: str
 ) -> "Output[str]":
@@ -487,6 +525,18 @@ class ResourceOptions:
     If set to True, the providers Delete method will not be called for this resource.
     """
 
+    deleted_with: Optional["Resource"]
+    """
+    If set, the providers Delete method will not be called for this resource
+    if specified resource is being deleted as well.
+    """
+
+    retry_policy: Optional["RetryPolicy"]
+    """
+    An optional policy for retrying failed create, update, and delete operations, e.g. when the resource's provider
+    reports transient errors. Overrides any retry policy set for the stack.
+    """
+
     # pylint: disable=redefined-builtin
     def __init__(
         self,
@@ -512,6 +562,8 @@ class ResourceOptions:
         replace_on_changes: Optional[List[str]] = None,
         plugin_download_url: Optional[str] = None,
         retain_on_delete: Optional[bool] = None,
+        deleted_with: Optional["Resource"] = None,
+        retry_policy: Optional["RetryPolicy"] = None,
     ) -> None:
         """
         :param Optional[Resource] parent: If provided, the currently-constructing resource should be the child of
@@ -552,6 +604,10 @@ class ResourceOptions:
                from the provided url. This url overrides the plugin download url inferred from the current package and should
                rarely be used.
         :param Optional[bool] retain_on_delete: If set to True, the providers Delete method will not be called for this resource.
+        :param Optional[Resource] deleted_with: If set, the providers Delete method will not be called for this resource
+               if specified resource is being deleted as well.
+        :param Optional[RetryPolicy] retry_policy: If provided, a policy for retrying failed create, update, and delete
+               operations. Overrides any retry policy set for the stack.
         """
 
         # Expose 'merge' again this this object, but this time as an instance method.
@@ -577,6 +633,8 @@ class ResourceOptions:
         self.replace_on_changes = replace_on_changes
         self.depends_on = depends_on
         self.retain_on_delete = retain_on_delete
+        self.deleted_with = deleted_with
+        self.retry_policy = retry_policy
 
         # Proactively check that `depends_on` values are of type
         # `Resource`. We cannot complete the check in the general case
@@ -704,6 +762,12 @@ class ResourceOptions:
             if source.retain_on_delete is None
             else source.retain_on_delete
         )
+        dest.deleted_with = (
+            dest.deleted_with if source.deleted_with is None else source.deleted_with
+        )
+        dest.retry_policy = (
+            dest.retry_policy if source.retry_policy is None else source.retry_policy
+        )
 
         # Now, if we are left with a .providers that is just a single key/value pair, then
         # collapse that down into .provider form.
@@ -16,7 +16,7 @@ from . import plugin_pb2 as plugin__pb2
 from google.protobuf import empty_pb2 as google_dot_protobuf_dot_empty__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0elanguage.proto\x12\tpulumirpc\x1a\x0cplugin.proto\x1a\x1bgoogle/protobuf/empty.proto\"J\n\x19GetRequiredPluginsRequest\x12\x0f\n\x07project\x18\x01 \x01(\t\x12\x0b\n\x03pwd\x18\x02 \x01(\t\x12\x0f\n\x07program\x18\x03 \x01(\t\"J\n\x1aGetRequiredPluginsResponse\x12,\n\x07plugins\x18\x01 \x03(\x0b\x32\x1b.pulumirpc.PluginDependency\"\xa2\x02\n\nRunRequest\x12\x0f\n\x07project\x18\x01 \x01(\t\x12\r\n\x05stack\x18\x02 \x01(\t\x12\x0b\n\x03pwd\x18\x03 \x01(\t\x12\x0f\n\x07program\x18\x04 \x01(\t\x12\x0c\n\x04\x61rgs\x18\x05 \x03(\t\x12\x31\n\x06\x63onfig\x18\x06 \x03(\x0b\x32!.pulumirpc.RunRequest.ConfigEntry\x12\x0e\n\x06\x64ryRun\x18\x07 \x01(\x08\x12\x10\n\x08parallel\x18\x08 \x01(\x05\x12\x17\n\x0fmonitor_address\x18\t \x01(\t\x12\x11\n\tqueryMode\x18\n \x01(\x08\x12\x18\n\x10\x63onfigSecretKeys\x18\x0b \x03(\t\x1a-\n\x0b\x43onfigEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"*\n\x0bRunResponse\x12\r\n\x05\x65rror\x18\x01 \x01(\t\x12\x0c\n\x04\x62\x61il\x18\x02 \x01(\x08\"D\n\x1aInstallDependenciesRequest\x12\x11\n\tdirectory\x18\x01 \x01(\t\x12\x13\n\x0bis_terminal\x18\x02 \x01(\x08\"=\n\x1bInstallDependenciesResponse\x12\x0e\n\x06stdout\x18\x01 \x01(\x0c\x12\x0e\n\x06stderr\x18\x02 \x01(\x0c\x32\xda\x02\n\x0fLanguageRuntime\x12\x63\n\x12GetRequiredPlugins\x12$.pulumirpc.GetRequiredPluginsRequest\x1a%.pulumirpc.GetRequiredPluginsResponse\"\x00\x12\x36\n\x03Run\x12\x15.pulumirpc.RunRequest\x1a\x16.pulumirpc.RunResponse\"\x00\x12@\n\rGetPluginInfo\x12\x16.google.protobuf.Empty\x1a\x15.pulumirpc.PluginInfo\"\x00\x12h\n\x13InstallDependencies\x12%.pulumirpc.InstallDependenciesRequest\x1a&.pulumirpc.InstallDependenciesResponse\"\x00\x30\x01\x42\x30Z.github.com/pulumi/pulumi/v3/proto/go/pulumirpcb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0elanguage.proto\x12\tpulumirpc\x1a\x0cplugin.proto\x1a\x1bgoogle/protobuf/empty.proto\"J\n\x19GetRequiredPluginsRequest\x12\x0f\n\x07project\x18\x01 \x01(\t\x12\x0b\n\x03pwd\x18\x02 \x01(\t\x12\x0f\n\x07program\x18\x03 \x01(\t\"J\n\x1aGetRequiredPluginsResponse\x12,\n\x07plugins\x18\x01 \x03(\x0b\x32\x1b.pulumirpc.PluginDependency\"\xa2\x02\n\nRunRequest\x12\x0f\n\x07project\x18\x01 \x01(\t\x12\r\n\x05stack\x18\x02 \x01(\t\x12\x0b\n\x03pwd\x18\x03 \x01(\t\x12\x0f\n\x07program\x18\x04 \x01(\t\x12\x0c\n\x04\x61rgs\x18\x05 \x03(\t\x12\x31\n\x06\x63onfig\x18\x06 \x03(\x0b\x32!.pulumirpc.RunRequest.ConfigEntry\x12\x0e\n\x06\x64ryRun\x18\x07 \x01(\x08\x12\x10\n\x08parallel\x18\x08 \x01(\x05\x12\x17\n\x0fmonitor_address\x18\t \x01(\t\x12\x11\n\tqueryMode\x18\n \x01(\x08\x12\x18\n\x10\x63onfigSecretKeys\x18\x0b \x03(\t\x1a-\n\x0b\x43onfigEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"*\n\x0bRunResponse\x12\r\n\x05\x65rror\x18\x01 \x01(\t\x12\x0c\n\x04\x62\x61il\x18\x02 \x01(\x08\"D\n\x1aInstallDependenciesRequest\x12\x11\n\tdirectory\x18\x01 \x01(\t\x12\x13\n\x0bis_terminal\x18\x02 \x01(\x08\"=\n\x1bInstallDependenciesResponse\x12\x0e\n\x06stdout\x18\x01 \x01(\x0c\x12\x0e\n\x06stderr\x18\x02 \x01(\x0c\"\x86\x01\n\x16GenerateProgramRequest\x12=\n\x06source\x18\x01 \x03(\x0b\x32-.pulumirpc.GenerateProgramRequest.SourceEntry\x1a-\n\x0bSourceEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"\x88\x01\n\x17GenerateProgramResponse\x12>\n\x06source\x18\x01 \x03(\x0b\x32..pulumirpc.GenerateProgramResponse.SourceEntry\x1a-\n\x0bSourceEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\"\xaa\x01\n\x16GenerateProjectRequest\x12\x11\n\tdirectory\x18\x01 \x01(\t\x12\x0f\n\x07project\x18\x02 \x01(\t\x12=\n\x06source\x18\x03 \x03(\x0b\x32-.pulumirpc.GenerateProjectRequest.SourceEntry\x1a-\n\x0bSourceEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"\x19\n\x17GenerateProjectResponse\"n\n\x1dGetProgramDependenciesRequest\x12\x0f\n\x07project\x18\x01 \x01(\t\x12\x0b\n\x03pwd\x18\x02 \x01(\t\x12\x0f\n\x07program\x18\x03 \x01(\t\x12\x1e\n\x16transitiveDependencies\x18\x04 \x01(\x08\"/\n\x0e\x44\x65pendencyInfo\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\t\"Q\n\x1eGetProgramDependenciesResponse\x12/\n\x0c\x64\x65pendencies\x18\x01 \x03(\x0b\x32\x19.pulumirpc.DependencyInfo2\x83\x05\n\x0fLanguageRuntime\x12\x63\n\x12GetRequiredPlugins\x12$.pulumirpc.GetRequiredPluginsRequest\x1a%.pulumirpc.GetRequiredPluginsResponse\"\x00\x12\x36\n\x03Run\x12\x15.pulumirpc.RunRequest\x1a\x16.pulumirpc.RunResponse\"\x00\x12@\n\rGetPluginInfo\x12\x16.google.protobuf.Empty\x1a\x15.pulumirpc.PluginInfo\"\x00\x12h\n\x13InstallDependencies\x12%.pulumirpc.InstallDependenciesRequest\x1a&.pulumirpc.InstallDependenciesResponse\"\x00\x30\x01\x12Z\n\x0fGenerateProgram\x12!.pulumirpc.GenerateProgramRequest\x1a\".pulumirpc.GenerateProgramResponse\"\x00\x12Z\n\x0fGenerateProject\x12!.pulumirpc.GenerateProjectRequest\x1a\".pulumirpc.GenerateProjectResponse\"\x00\x12o\n\x16GetProgramDependencies\x12(.pulumirpc.GetProgramDependenciesRequest\x1a).pulumirpc.GetProgramDependenciesResponse\"\x00\x42\x30Z.github.com/pulumi/pulumi/v3/proto/go/pulumirpcb\x06proto3')
 
 
 
@@ -27,6 +27,16 @@ _RUNREQUEST_CONFIGENTRY = _RUNREQUEST.nested_types_by_name['ConfigEntry']
 _RUNRESPONSE = DESCRIPTOR.message_types_by_name['RunResponse']
 _INSTALLDEPENDENCIESREQUEST = DESCRIPTOR.message_types_by_name['InstallDependenciesRequest']
 _INSTALLDEPENDENCIESRESPONSE = DESCRIPTOR.message_types_by_name['InstallDependenciesResponse']
+_GENERATEPROGRAMREQUEST = DESCRIPTOR.message_types_by_name['GenerateProgramRequest']
+_GENERATEPROGRAMREQUEST_SOURCEENTRY = _GENERATEPROGRAMREQUEST.nested_types_by_name['SourceEntry']
+_GENERATEPROGRAMRESPONSE = DESCRIPTOR.message_types_by_name['GenerateProgramResponse']
+_GENERATEPROGRAMRESPONSE_SOURCEENTRY = _GENERATEPROGRAMRESPONSE.nested_types_by_name['SourceEntry']
+_GENERATEPROJECTREQUEST = DESCRIPTOR.message_types_by_name['GenerateProjectRequest']
+_GENERATEPROJECTREQUEST_SOURCEENTRY = _GENERATEPROJECTREQUEST.nested_types_by_name['SourceEntry']
+_GENERATEPROJECTRESPONSE = DESCRIPTOR.message_types_by_name['GenerateProjectResponse']
+_GETPROGRAMDEPENDENCIESREQUEST = DESCRIPTOR.message_types_by_name['GetProgramDependenciesRequest']
+_DEPENDENCYINFO = DESCRIPTOR.message_types_by_name['DependencyInfo']
+_GETPROGRAMDEPENDENCIESRESPONSE = DESCRIPTOR.message_types_by_name['GetProgramDependenciesResponse']
 GetRequiredPluginsRequest = _reflection.GeneratedProtocolMessageType('GetRequiredPluginsRequest', (_message.Message,), {
   'DESCRIPTOR' : _GETREQUIREDPLUGINSREQUEST,
   '__module__' : 'language_pb2'
@@ -77,6 +87,73 @@ InstallDependenciesResponse = _reflection.GeneratedProtocolMessageType('InstallD
   })
 _sym_db.RegisterMessage(InstallDependenciesResponse)
 
+GenerateProgramRequest = _reflection.GeneratedProtocolMessageType('GenerateProgramRequest', (_message.Message,), {
+  'SourceEntry' : _reflection.GeneratedProtocolMessageType('SourceEntry', (_message.Message,), {
+    'DESCRIPTOR' : _GENERATEPROGRAMREQUEST_SOURCEENTRY,
+    '__module__' : 'language_pb2'
+    # @@protoc_insertion_point(class_scope:pulumirpc.GenerateProgramRequest.SourceEntry)
+    })
+  ,
+  'DESCRIPTOR' : _GENERATEPROGRAMREQUEST,
+  '__module__' : 'language_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.GenerateProgramRequest)
+  })
+_sym_db.RegisterMessage(GenerateProgramRequest)
+_sym_db.RegisterMessage(GenerateProgramRequest.SourceEntry)
+GenerateProgramResponse = _reflection.GeneratedProtocolMessageType('GenerateProgramResponse', (_message.Message,), {
+  'SourceEntry' : _reflection.GeneratedProtocolMessageType('SourceEntry', (_message.Message,), {
+    'DESCRIPTOR' : _GENERATEPROGRAMRESPONSE_SOURCEENTRY,
+    '__module__' : 'language_pb2'
+    # @@protoc_insertion_point(class_scope:pulumirpc.GenerateProgramResponse.SourceEntry)
+    })
+  ,
+  'DESCRIPTOR' : _GENERATEPROGRAMRESPONSE,
+  '__module__' : 'language_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.GenerateProgramResponse)
+  })
+_sym_db.RegisterMessage(GenerateProgramResponse)
+_sym_db.RegisterMessage(GenerateProgramResponse.SourceEntry)
+GenerateProjectRequest = _reflection.GeneratedProtocolMessageType('GenerateProjectRequest', (_message.Message,), {
+  'SourceEntry' : _reflection.GeneratedProtocolMessageType('SourceEntry', (_message.Message,), {
+    'DESCRIPTOR' : _GENERATEPROJECTREQUEST_SOURCEENTRY,
+    '__module__' : 'language_pb2'
+    # @@protoc_insertion_point(class_scope:pulumirpc.GenerateProjectRequest.SourceEntry)
+    })
+  ,
+  'DESCRIPTOR' : _GENERATEPROJECTREQUEST,
+  '__module__' : 'language_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.GenerateProjectRequest)
+  })
+_sym_db.RegisterMessage(GenerateProjectRequest)
+_sym_db.RegisterMessage(GenerateProjectRequest.SourceEntry)
+GenerateProjectResponse = _reflection.GeneratedProtocolMessageType('GenerateProjectResponse', (_message.Message,), {
+  'DESCRIPTOR' : _GENERATEPROJECTRESPONSE,
+  '__module__' : 'language_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.GenerateProjectResponse)
+  })
+_sym_db.RegisterMessage(GenerateProjectResponse)
+
+GetProgramDependenciesRequest = _reflection.GeneratedProtocolMessageType('GetProgramDependenciesRequest', (_message.Message,), {
+  'DESCRIPTOR' : _GETPROGRAMDEPENDENCIESREQUEST,
+  '__module__' : 'language_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.GetProgramDependenciesRequest)
+  })
+_sym_db.RegisterMessage(GetProgramDependenciesRequest)
+
+DependencyInfo = _reflection.GeneratedProtocolMessageType('DependencyInfo', (_message.Message,), {
+  'DESCRIPTOR' : _DEPENDENCYINFO,
+  '__module__' : 'language_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.DependencyInfo)
+  })
+_sym_db.RegisterMessage(DependencyInfo)
+
+GetProgramDependenciesResponse = _reflection.GeneratedProtocolMessageType('GetProgramDependenciesResponse', (_message.Message,), {
+  'DESCRIPTOR' : _GETPROGRAMDEPENDENCIESRESPONSE,
+  '__module__' : 'language_pb2'
+  # @@protoc_insertion_point(class_scope:pulumirpc.GetProgramDependenciesResponse)
+  })
+_sym_db.RegisterMessage(GetProgramDependenciesResponse)
+
 _LANGUAGERUNTIME = DESCRIPTOR.services_by_name['LanguageRuntime']
 if _descriptor._USE_C_DESCRIPTORS == False:
 
@@ -84,6 +161,12 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   DESCRIPTOR._serialized_options = b'Z.github.com/pulumi/pulumi/v3/proto/go/pulumirpc'
   _RUNREQUEST_CONFIGENTRY._options = None
   _RUNREQUEST_CONFIGENTRY._serialized_options = b'8\001'
+  _GENERATEPROGRAMREQUEST_SOURCEENTRY._options = None
+  _GENERATEPROGRAMREQUEST_SOURCEENTRY._serialized_options = b'8\001'
+  _GENERATEPROGRAMRESPONSE_SOURCEENTRY._options = None
+  _GENERATEPROGRAMRESPONSE_SOURCEENTRY._serialized_options = b'8\001'
+  _GENERATEPROJECTREQUEST_SOURCEENTRY._options = None
+  _GENERATEPROJECTREQUEST_SOURCEENTRY._serialized_options = b'8\001'
   _GETREQUIREDPLUGINSREQUEST._serialized_start=72
   _GETREQUIREDPLUGINSREQUEST._serialized_end=146
   _GETREQUIREDPLUGINSRESPONSE._serialized_start=148
@@ -98,6 +181,26 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _INSTALLDEPENDENCIESREQUEST._serialized_end=629
   _INSTALLDEPENDENCIESRESPONSE._serialized_start=631
   _INSTALLDEPENDENCIESRESPONSE._serialized_end=692
-  _LANGUAGERUNTIME._serialized_start=695
-  _LANGUAGERUNTIME._serialized_end=1041
+  _GENERATEPROGRAMREQUEST._serialized_start=695
+  _GENERATEPROGRAMREQUEST._serialized_end=829
+  _GENERATEPROGRAMREQUEST_SOURCEENTRY._serialized_start=784
+  _GENERATEPROGRAMREQUEST_SOURCEENTRY._serialized_end=829
+  _GENERATEPROGRAMRESPONSE._serialized_start=832
+  _GENERATEPROGRAMRESPONSE._serialized_end=968
+  _GENERATEPROGRAMRESPONSE_SOURCEENTRY._serialized_start=923
+  _GENERATEPROGRAMRESPONSE_SOURCEENTRY._serialized_end=968
+  _GENERATEPROJECTREQUEST._serialized_start=971
+  _GENERATEPROJECTREQUEST._serialized_end=1141
+  _GENERATEPROJECTREQUEST_SOURCEENTRY._serialized_start=1096
+  _GENERATEPROJECTREQUEST_SOURCEENTRY._serialized_end=1141
+  _GENERATEPROJECTRESPONSE._serialized_start=1143
+  _GENERATEPROJECTRESPONSE._serialized_end=1168
+  _GETPROGRAMDEPENDENCIESREQUEST._serialized_start=1170
+  _GETPROGRAMDEPENDENCIESREQUEST._serialized_end=1280
+  _DEPENDENCYINFO._serialized_start=1282
+  _DEPENDENCYINFO._serialized_end=1329
+  _GETPROGRAMDEPENDENCIESRESPONSE._serialized_start=1331
+  _GETPROGRAMDEPENDENCIESRESPONSE._serialized_end=1412
+  _LANGUAGERUNTIME._serialized_start=1415
+  _LANGUAGERUNTIME._serialized_end=2058
 # @@protoc_insertion_point(module_scope)
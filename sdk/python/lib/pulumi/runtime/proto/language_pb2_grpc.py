@@ -38,6 +38,21 @@ class LanguageRuntimeStub(object):
                 request_serializer=language__pb2.InstallDependenciesRequest.SerializeToString,
                 response_deserializer=language__pb2.InstallDependenciesResponse.FromString,
                 )
+        self.GenerateProgram = channel.unary_unary(
+                '/pulumirpc.LanguageRuntime/GenerateProgram',
+                request_serializer=language__pb2.GenerateProgramRequest.SerializeToString,
+                response_deserializer=language__pb2.GenerateProgramResponse.FromString,
+                )
+        self.GenerateProject = channel.unary_unary(
+                '/pulumirpc.LanguageRuntime/GenerateProject',
+                request_serializer=language__pb2.GenerateProjectRequest.SerializeToString,
+                response_deserializer=language__pb2.GenerateProjectResponse.FromString,
+                )
+        self.GetProgramDependencies = channel.unary_unary(
+                '/pulumirpc.LanguageRuntime/GetProgramDependencies',
+                request_serializer=language__pb2.GetProgramDependenciesRequest.SerializeToString,
+                response_deserializer=language__pb2.GetProgramDependenciesResponse.FromString,
+                )
 
 
 class LanguageRuntimeServicer(object):
@@ -73,6 +88,27 @@ class LanguageRuntimeServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def GenerateProgram(self, request, context):
+        """GenerateProgram generates a program in this language from a PCL program.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def GenerateProject(self, request, context):
+        """GenerateProject generates a project in this language from a PCL program, writing it to a directory.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def GetProgramDependencies(self, request, context):
+        """GetProgramDependencies returns the set of packages, with their versions, that a program depends on.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_LanguageRuntimeServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -96,6 +132,21 @@ def add_LanguageRuntimeServicer_to_server(servicer, server):
                     request_deserializer=language__pb2.InstallDependenciesRequest.FromString,
                     response_serializer=language__pb2.InstallDependenciesResponse.SerializeToString,
             ),
+            'GenerateProgram': grpc.unary_unary_rpc_method_handler(
+                    servicer.GenerateProgram,
+                    request_deserializer=language__pb2.GenerateProgramRequest.FromString,
+                    response_serializer=language__pb2.GenerateProgramResponse.SerializeToString,
+            ),
+            'GenerateProject': grpc.unary_unary_rpc_method_handler(
+                    servicer.GenerateProject,
+                    request_deserializer=language__pb2.GenerateProjectRequest.FromString,
+                    response_serializer=language__pb2.GenerateProjectResponse.SerializeToString,
+            ),
+            'GetProgramDependencies': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetProgramDependencies,
+                    request_deserializer=language__pb2.GetProgramDependenciesRequest.FromString,
+                    response_serializer=language__pb2.GetProgramDependenciesResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'pulumirpc.LanguageRuntime', rpc_method_handlers)
@@ -175,3 +226,54 @@ class LanguageRuntime(object):
             language__pb2.InstallDependenciesResponse.FromString,
             options, channel_credentials,
             insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def GenerateProgram(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/pulumirpc.LanguageRuntime/GenerateProgram',
+            language__pb2.GenerateProgramRequest.SerializeToString,
+            language__pb2.GenerateProgramResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def GenerateProject(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/pulumirpc.LanguageRuntime/GenerateProject',
+            language__pb2.GenerateProjectRequest.SerializeToString,
+            language__pb2.GenerateProjectResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def GetProgramDependencies(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/pulumirpc.LanguageRuntime/GetProgramDependencies',
+            language__pb2.GetProgramDependenciesRequest.SerializeToString,
+            language__pb2.GetProgramDependenciesResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
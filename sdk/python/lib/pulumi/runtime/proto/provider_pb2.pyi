@@ -118,3 +118,31 @@ class GetSchemaRequest:
 class GetSchemaResponse:
     def __init__(self, schema: str):
         pass
+
+class GetMappingRequest:
+    def __init__(self, key: str = "") -> None: ...
+
+    key: str
+
+class GetMappingResponse:
+    def __init__(self, provider: str = "", data: bytes = b"") -> None: ...
+
+    provider: str
+    data: bytes
+
+class ListRequest:
+    def __init__(self, type: str = "", filter: Optional[Dict[str, str]] = None) -> None: ...
+
+    type: str
+    filter: Dict[str, str]
+
+class ListedResource:
+    def __init__(self, id: str = "", name: str = "") -> None: ...
+
+    id: str
+    name: str
+
+class ListResponse:
+    def __init__(self, resources: Optional[List[ListedResource]] = None) -> None: ...
+
+    resources: List[ListedResource]
@@ -103,6 +103,16 @@ class ResourceProviderStub(object):
                 request_serializer=plugin__pb2.PluginAttach.SerializeToString,
                 response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
                 )
+        self.GetMapping = channel.unary_unary(
+                '/pulumirpc.ResourceProvider/GetMapping',
+                request_serializer=provider__pb2.GetMappingRequest.SerializeToString,
+                response_deserializer=provider__pb2.GetMappingResponse.FromString,
+                )
+        self.List = channel.unary_unary(
+                '/pulumirpc.ResourceProvider/List',
+                request_serializer=provider__pb2.ListRequest.SerializeToString,
+                response_deserializer=provider__pb2.ListResponse.FromString,
+                )
 
 
 class ResourceProviderServicer(object):
@@ -240,6 +250,22 @@ class ResourceProviderServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def GetMapping(self, request, context):
+        """GetMapping fetches the mapping for this resource provider from another ecosystem (e.g. Terraform), if any. A
+        provider should return an empty response (not an error) if it doesn't have a mapping for the given key.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def List(self, request, context):
+        """List enumerates the live resources of the given type that the provider can see, so that they can be imported.
+        Unlike other resource operations, List does not require the resources to be managed by Pulumi.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_ResourceProviderServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -328,6 +354,16 @@ def add_ResourceProviderServicer_to_server(servicer, server):
                     request_deserializer=plugin__pb2.PluginAttach.FromString,
                     response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
             ),
+            'GetMapping': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetMapping,
+                    request_deserializer=provider__pb2.GetMappingRequest.FromString,
+                    response_serializer=provider__pb2.GetMappingResponse.SerializeToString,
+            ),
+            'List': grpc.unary_unary_rpc_method_handler(
+                    servicer.List,
+                    request_deserializer=provider__pb2.ListRequest.FromString,
+                    response_serializer=provider__pb2.ListResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'pulumirpc.ResourceProvider', rpc_method_handlers)
@@ -628,3 +664,37 @@ class ResourceProvider(object):
             google_dot_protobuf_dot_empty__pb2.Empty.FromString,
             options, channel_credentials,
             insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def GetMapping(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/pulumirpc.ResourceProvider/GetMapping',
+            provider__pb2.GetMappingRequest.SerializeToString,
+            provider__pb2.GetMappingResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def List(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/pulumirpc.ResourceProvider/List',
+            provider__pb2.ListRequest.SerializeToString,
+            provider__pb2.ListResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
@@ -13,6 +13,7 @@
 # limitations under the License.
 import asyncio
 import os
+import pathlib
 import traceback
 
 from typing import (
@@ -90,6 +91,12 @@ class ResourceResolverOperations(NamedTuple):
     A list of aliases applied to this resource.
     """
 
+    deleted_with_urn: Optional[str]
+    """
+    If set, the providers Delete method will not be called for this resource
+    if specified resource is being deleted as well.
+    """
+
 
 # Prepares for an RPC that will manufacture a resource, and hence deals with input and output properties.
 # pylint: disable=too-many-locals
@@ -181,6 +188,10 @@ async def prepare_resource(
         if not alias_val in aliases:
             aliases.append(alias_val)
 
+    deleted_with_urn: Optional[str] = ""
+    if opts is not None and opts.deleted_with is not None:
+        deleted_with_urn = await opts.deleted_with.urn.future()
+
     return ResourceResolverOperations(
         parent_urn,
         serialized_props,
@@ -189,6 +200,7 @@ async def prepare_resource(
         provider_refs,
         property_dependencies,
         aliases,
+        deleted_with_urn,
     )
 
 
@@ -466,6 +478,29 @@ def read_resource(
     asyncio.ensure_future(RPC_MANAGER.do_rpc("read resource", do_read)())
 
 
+_SDK_ROOT = os.path.dirname(os.path.dirname(os.path.abspath(__file__)))
+
+
+def _source_position() -> Optional[resource_pb2.SourcePosition]:
+    """
+    Returns the position of the innermost stack frame that belongs to the user's program, or None if there is no
+    such frame. Frames inside this package and any installed packages (e.g. provider SDKs) are skipped.
+    """
+    for frame in reversed(traceback.extract_stack()):
+        filename = os.path.abspath(frame.filename)
+        parts = filename.split(os.sep)
+        if filename.startswith(_SDK_ROOT + os.sep):
+            continue
+        if "site-packages" in parts or "dist-packages" in parts:
+            continue
+        if frame.lineno is None or not os.path.isfile(filename):
+            continue
+        return resource_pb2.SourcePosition(
+            uri=pathlib.Path(filename).as_uri(), line=frame.lineno
+        )
+    return None
+
+
 def register_resource(
     res: "Resource",
     ty: str,
@@ -509,6 +544,9 @@ def register_resource(
     # passed to.  However, those futures won't actually resolve until the RPC returns
     resolvers = rpc.transfer_properties(res, props)
 
+    # Record where in the program this resource is being registered.
+    source_position = _source_position()
+
     async def do_register():
         try:
             resolver = await prepare_resource(res, ty, custom, remote, props, opts, typ)
@@ -553,6 +591,15 @@ def register_resource(
                         "Expected custom_timeouts to be a CustomTimeouts object"
                     )
 
+            retry_policy = None
+            if opts.retry_policy is not None:
+                retry_policy = resource_pb2.RegisterResourceRequest.RetryPolicy(
+                    attempts=opts.retry_policy.attempts,
+                    backoff=opts.retry_policy.backoff or "",
+                    errorPatterns=opts.retry_policy.error_patterns or [],
+                    retryIfUnknown=opts.retry_policy.retry_if_unknown,
+                )
+
             accept_resources = not (
                 os.getenv("PULUMI_DISABLE_RESOURCE_REFERENCES", "").upper()
                 in {"TRUE", "1"}
@@ -584,6 +631,9 @@ def register_resource(
                 remote=remote,
                 replaceOnChanges=replace_on_changes,
                 retainOnDelete=opts.retain_on_delete or False,
+                deletedWith=resolver.deleted_with_urn or "",
+                sourcePosition=source_position,
+                retryPolicy=retry_policy,
             )
 
             from ..resource import create_urn  # pylint: disable=import-outside-toplevel
@@ -228,6 +228,16 @@ func (p *testcomponentProvider) GetPluginInfo(context.Context, *pbempty.Empty) (
 	}, nil
 }
 
+func (p *testcomponentProvider) GetMapping(context.Context,
+	*pulumirpc.GetMappingRequest) (*pulumirpc.GetMappingResponse, error) {
+	return &pulumirpc.GetMappingResponse{}, nil
+}
+
+func (p *testcomponentProvider) List(context.Context,
+	*pulumirpc.ListRequest) (*pulumirpc.ListResponse, error) {
+	return &pulumirpc.ListResponse{}, nil
+}
+
 func (p *testcomponentProvider) Attach(ctx context.Context, req *pulumirpc.PluginAttach) (*pbempty.Empty, error) {
 	return &pbempty.Empty{}, nil
 }
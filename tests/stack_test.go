@@ -308,6 +308,131 @@ func TestStackCommands(t *testing.T) {
 	})
 }
 
+func TestStateRepairDanglingParent(t *testing.T) {
+	t.Parallel()
+
+	e := ptesting.NewEnvironment(t)
+	defer func() {
+		if !t.Failed() {
+			e.DeleteEnvironment()
+		}
+	}()
+
+	integration.CreateBasicPulumiRepo(e)
+	e.SetBackend(e.LocalURL())
+	e.RunCommand("pulumi", "stack", "init", "repair")
+
+	// Import a state in which a component refers to a parent that doesn't exist, which fails the backend's integrity
+	// checks.
+	root := resource.NewURN("repair", "pulumi-test", "", resource.RootStackType, "pulumi-test-repair")
+	missing := resource.NewURN("repair", "pulumi-test", "", "my:module:Component", "missing")
+	child := resource.NewURN("repair", "pulumi-test", "my:module:Component", "my:module:Component", "child")
+	data, err := json.Marshal(&apitype.DeploymentV3{
+		Resources: []apitype.ResourceV3{
+			{URN: root, Type: resource.RootStackType},
+			{URN: child, Type: "my:module:Component", Parent: missing},
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	bytes, err := json.Marshal(&apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: data,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	err = ioutil.WriteFile(path.Join(e.CWD, "stack.json"), bytes, 0600)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	e.RunCommand("pulumi", "stack", "import", "--force", "--disable-integrity-checking", "--file", "stack.json")
+
+	// The backend refuses to load the broken state, but repairing it doesn't require disabling the checks.
+	_, _, err = e.GetCommandResults("pulumi", "stack", "export")
+	assert.Error(t, err)
+	stdout, _ := e.RunCommand("pulumi", "state", "repair", "--yes")
+	assert.Contains(t, stdout, "make the root stack resource the parent instead")
+
+	stdout, _ = e.RunCommand("pulumi", "stack", "export")
+	var deployment apitype.UntypedDeployment
+	err = json.Unmarshal([]byte(stdout), &deployment)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var repaired apitype.DeploymentV3
+	err = json.Unmarshal(deployment.Deployment, &repaired)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if assert.Len(t, repaired.Resources, 2) {
+		assert.Equal(t, root, repaired.Resources[1].Parent)
+	}
+
+	e.RunCommand("pulumi", "stack", "rm", "--yes", "--force")
+}
+
+func TestStateMoveToEmptyStackInAnotherProject(t *testing.T) {
+	t.Parallel()
+
+	e := ptesting.NewEnvironment(t)
+	defer func() {
+		if !t.Failed() {
+			e.DeleteEnvironment()
+		}
+	}()
+
+	integration.CreateBasicPulumiRepo(e)
+	e.SetBackend(e.LocalURL())
+	e.SetEnvVars([]string{"PULUMI_CONFIG_PASSPHRASE=correct horse battery staple"})
+	e.RunCommand("pulumi", "stack", "init", "source")
+	e.RunCommand("pulumi", "stack", "init", "organization/other/dest")
+
+	root := resource.NewURN("source", "pulumi-test", "", resource.RootStackType, "pulumi-test-source")
+	comp := resource.NewURN("source", "pulumi-test", "", "my:module:Component", "comp")
+	data, err := json.Marshal(&apitype.DeploymentV3{
+		Resources: []apitype.ResourceV3{
+			{URN: root, Type: resource.RootStackType},
+			{URN: comp, Type: "my:module:Component", Parent: root},
+		},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	bytes, err := json.Marshal(&apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: data,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	err = ioutil.WriteFile(path.Join(e.CWD, "source.json"), bytes, 0600)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	e.RunCommand("pulumi", "stack", "import", "--stack", "source", "--file", "source.json")
+
+	// The destination stack has never been updated, so the project of the moved resource comes from its reference.
+	e.RunCommand("pulumi", "state", "move", "--source", "source", "--dest", "organization/other/dest", "--yes",
+		string(comp))
+
+	stdout, _ := e.RunCommand("pulumi", "stack", "export", "--stack", "organization/other/dest")
+	var deployment apitype.UntypedDeployment
+	err = json.Unmarshal([]byte(stdout), &deployment)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var moved apitype.DeploymentV3
+	err = json.Unmarshal(deployment.Deployment, &moved)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if assert.Len(t, moved.Resources, 1) {
+		assert.Equal(t, resource.NewURN("dest", "other", "", "my:module:Component", "comp"), moved.Resources[0].URN)
+	}
+}
+
 //nolint:paralleltest // mutates environment variables
 func TestStackBackups(t *testing.T) {
 	t.Run("StackBackupCreatedSanityTest", func(t *testing.T) {
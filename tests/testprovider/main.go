@@ -177,6 +177,16 @@ func (k *testproviderProvider) GetPluginInfo(context.Context, *pbempty.Empty) (*
 	}, nil
 }
 
+func (k *testproviderProvider) GetMapping(
+	context.Context, *rpc.GetMappingRequest) (*rpc.GetMappingResponse, error) {
+	return &rpc.GetMappingResponse{}, nil
+}
+
+func (k *testproviderProvider) List(
+	context.Context, *rpc.ListRequest) (*rpc.ListResponse, error) {
+	return &rpc.ListResponse{}, nil
+}
+
 func (k *testproviderProvider) Attach(ctx context.Context, req *rpc.PluginAttach) (*pbempty.Empty, error) {
 	return &pbempty.Empty{}, nil
 }